@@ -27,6 +27,11 @@ func main() {
 		log.Fatal("JWT_SECRET environment variable is required")
 	}
 
+	dailySecret := os.Getenv("DAILY_PUZZLE_SECRET")
+	if dailySecret == "" {
+		log.Fatal("DAILY_PUZZLE_SECRET environment variable is required")
+	}
+
 	frontendURL := os.Getenv("FRONTEND_URL")
 	if frontendURL == "" {
 		frontendURL = "http://localhost:5173"
@@ -40,7 +45,7 @@ func main() {
 	defer database.Close()
 
 	// Create handler with dependencies
-	h := handlers.New(database, jwtSecret)
+	h := handlers.New(database, jwtSecret, dailySecret)
 
 	// Set up routes
 	mux := http.NewServeMux()
@@ -60,9 +65,39 @@ func main() {
 	mux.HandleFunc("POST /api/friends/requests/{id}", middleware.Auth(jwtSecret, h.RespondToFriendRequest))
 	mux.HandleFunc("DELETE /api/friends/{id}", middleware.Auth(jwtSecret, h.RemoveFriend))
 
+	// Game invitation routes
+	mux.HandleFunc("POST /api/invitations", middleware.Auth(jwtSecret, h.CreateGameInvitation))
+	mux.HandleFunc("GET /api/invitations/incoming", middleware.Auth(jwtSecret, h.GetIncomingInvitations))
+	mux.HandleFunc("GET /api/invitations/outgoing", middleware.Auth(jwtSecret, h.GetOutgoingInvitations))
+	mux.HandleFunc("POST /api/invitations/{id}", middleware.Auth(jwtSecret, h.RespondToGameInvitation))
+
+	// Chat routes
+	mux.HandleFunc("GET /api/chat/games/{type}/{id}", middleware.Auth(jwtSecret, h.GetGameChat))
+	mux.HandleFunc("POST /api/chat/games/{type}/{id}", middleware.Auth(jwtSecret, h.SendGameChatMessage))
+	mux.HandleFunc("POST /api/chat/games/{type}/{id}/read", middleware.Auth(jwtSecret, h.MarkGameChatRead))
+	mux.HandleFunc("GET /api/chat/direct/{id}", middleware.Auth(jwtSecret, h.GetDirectChat))
+	mux.HandleFunc("POST /api/chat/direct/{id}", middleware.Auth(jwtSecret, h.SendDirectMessage))
+	mux.HandleFunc("POST /api/chat/direct/{id}/read", middleware.Auth(jwtSecret, h.MarkDirectChatRead))
+	mux.HandleFunc("GET /api/chat/unread", middleware.Auth(jwtSecret, h.GetChatUnread))
+	mux.HandleFunc("GET /api/chat/updates", middleware.Auth(jwtSecret, h.GetChatUpdates))
+	mux.HandleFunc("GET /api/chat/settings", middleware.Auth(jwtSecret, h.GetChatSettings))
+	mux.HandleFunc("PUT /api/chat/settings", middleware.Auth(jwtSecret, h.UpdateChatSettings))
+
+	// Spectator routes
+	mux.HandleFunc("GET /api/spectate/games", middleware.Auth(jwtSecret, h.GetWatchableGames))
+	mux.HandleFunc("GET /api/spectate/games/{type}/{id}", middleware.Auth(jwtSecret, h.SpectateGame))
+	mux.HandleFunc("GET /api/spectate/games/{type}/{id}/watchable", middleware.Auth(jwtSecret, h.GetGameWatchable))
+	mux.HandleFunc("PUT /api/spectate/games/{type}/{id}/watchable", middleware.Auth(jwtSecret, h.SetGameWatchable))
+
+	// Tournament routes
+	mux.HandleFunc("GET /api/tournaments", middleware.Auth(jwtSecret, h.GetTournaments))
+	mux.HandleFunc("POST /api/tournaments", middleware.Auth(jwtSecret, h.CreateTournament))
+	mux.HandleFunc("GET /api/tournaments/{id}", middleware.Auth(jwtSecret, h.GetTournament))
+	mux.HandleFunc("GET /api/tournaments/{id}/standings", middleware.Auth(jwtSecret, h.GetTournamentStandings))
+	mux.HandleFunc("GET /api/tournaments/{id}/bracket", middleware.Auth(jwtSecret, h.GetTournamentBracket))
+
 	// Scrabble routes
 	mux.HandleFunc("GET /api/scrabble/games", middleware.Auth(jwtSecret, h.GetScrabbleGames))
-	mux.HandleFunc("POST /api/scrabble/games", middleware.Auth(jwtSecret, h.CreateScrabbleGame))
 	mux.HandleFunc("GET /api/scrabble/games/{id}", middleware.Auth(jwtSecret, h.GetScrabbleGame))
 	mux.HandleFunc("POST /api/scrabble/games/{id}/play", middleware.Auth(jwtSecret, h.PlayScrabbleMove))
 	mux.HandleFunc("POST /api/scrabble/games/{id}/preview", middleware.Auth(jwtSecret, h.PreviewScrabbleMove))
@@ -71,29 +106,132 @@ func main() {
 	mux.HandleFunc("POST /api/scrabble/games/{id}/resign", middleware.Auth(jwtSecret, h.ResignScrabbleGame))
 	mux.HandleFunc("GET /api/scrabble/games/{id}/bag", middleware.Auth(jwtSecret, h.GetTileBag))
 	mux.HandleFunc("GET /api/scrabble/games/{id}/history", middleware.Auth(jwtSecret, h.GetGameHistory))
+	mux.HandleFunc("POST /api/scrabble/games/{id}/rematch", middleware.Auth(jwtSecret, h.Rematch("scrabble")))
+	mux.HandleFunc("GET /api/scrabble/games/{id}/series", middleware.Auth(jwtSecret, h.GameSeries("scrabble")))
 
 	// Battleship routes
 	mux.HandleFunc("GET /api/battleship/games", middleware.Auth(jwtSecret, h.GetBattleshipGames))
-	mux.HandleFunc("POST /api/battleship/games", middleware.Auth(jwtSecret, h.CreateBattleshipGame))
 	mux.HandleFunc("GET /api/battleship/games/{id}", middleware.Auth(jwtSecret, h.GetBattleshipGame))
 	mux.HandleFunc("POST /api/battleship/games/{id}/ships", middleware.Auth(jwtSecret, h.PlaceBattleshipShips))
+	mux.HandleFunc("GET /api/battleship/games/{id}/ships/suggest", middleware.Auth(jwtSecret, h.SuggestBattleshipShips))
 	mux.HandleFunc("POST /api/battleship/games/{id}/fire", middleware.Auth(jwtSecret, h.FireBattleshipShot))
 	mux.HandleFunc("POST /api/battleship/games/{id}/resign", middleware.Auth(jwtSecret, h.ResignBattleshipGame))
+	mux.HandleFunc("GET /api/battleship/games/{id}/history", middleware.Auth(jwtSecret, h.GetBattleshipHistory))
+	mux.HandleFunc("GET /api/battleship/games/{id}/analysis", middleware.Auth(jwtSecret, h.GetBattleshipAnalysis))
+	mux.HandleFunc("POST /api/battleship/games/{id}/rematch", middleware.Auth(jwtSecret, h.Rematch("battleship")))
+	mux.HandleFunc("GET /api/battleship/games/{id}/series", middleware.Auth(jwtSecret, h.GameSeries("battleship")))
 
 	// Mastermind routes
 	mux.HandleFunc("GET /api/mastermind/games", middleware.Auth(jwtSecret, h.GetMastermindGames))
-	mux.HandleFunc("POST /api/mastermind/games", middleware.Auth(jwtSecret, h.CreateMastermindGame))
 	mux.HandleFunc("GET /api/mastermind/games/{id}", middleware.Auth(jwtSecret, h.GetMastermindGame))
 	mux.HandleFunc("POST /api/mastermind/games/{id}/secret", middleware.Auth(jwtSecret, h.SetMastermindSecret))
 	mux.HandleFunc("POST /api/mastermind/games/{id}/guess", middleware.Auth(jwtSecret, h.MakeMastermindGuess))
 	mux.HandleFunc("POST /api/mastermind/games/{id}/resign", middleware.Auth(jwtSecret, h.ResignMastermindGame))
+	mux.HandleFunc("POST /api/mastermind/games/{id}/assistant", middleware.Auth(jwtSecret, h.MastermindAssistant))
+	mux.HandleFunc("POST /api/mastermind/games/{id}/rematch", middleware.Auth(jwtSecret, h.Rematch("mastermind")))
+	mux.HandleFunc("GET /api/mastermind/games/{id}/series", middleware.Auth(jwtSecret, h.GameSeries("mastermind")))
+	mux.HandleFunc("GET /api/mastermind/daily", middleware.Auth(jwtSecret, h.GetMastermindDaily))
+	mux.HandleFunc("POST /api/mastermind/daily/start", middleware.Auth(jwtSecret, h.StartMastermindDaily))
+	mux.HandleFunc("POST /api/mastermind/daily/guess", middleware.Auth(jwtSecret, h.MakeMastermindDailyGuess))
+	mux.HandleFunc("GET /api/mastermind/daily/leaderboard", middleware.Auth(jwtSecret, h.GetMastermindDailyLeaderboard))
+
+	// Word Mastermind routes
+	mux.HandleFunc("GET /api/wordmind/games", middleware.Auth(jwtSecret, h.GetWordmindGames))
+	mux.HandleFunc("GET /api/wordmind/games/{id}", middleware.Auth(jwtSecret, h.GetWordmindGame))
+	mux.HandleFunc("POST /api/wordmind/games/{id}/secret", middleware.Auth(jwtSecret, h.SetWordmindSecret))
+	mux.HandleFunc("POST /api/wordmind/games/{id}/guess", middleware.Auth(jwtSecret, h.MakeWordmindGuess))
+	mux.HandleFunc("POST /api/wordmind/games/{id}/resign", middleware.Auth(jwtSecret, h.ResignWordmindGame))
+	mux.HandleFunc("POST /api/wordmind/games/{id}/rematch", middleware.Auth(jwtSecret, h.Rematch("wordmind")))
+	mux.HandleFunc("GET /api/wordmind/games/{id}/series", middleware.Auth(jwtSecret, h.GameSeries("wordmind")))
 
 	// Memory routes
 	mux.HandleFunc("GET /api/memory/games", middleware.Auth(jwtSecret, h.GetMemoryGames))
-	mux.HandleFunc("POST /api/memory/games", middleware.Auth(jwtSecret, h.CreateMemoryGame))
+	mux.HandleFunc("GET /api/memory/themes", middleware.Auth(jwtSecret, h.GetMemoryThemes))
 	mux.HandleFunc("GET /api/memory/games/{id}", middleware.Auth(jwtSecret, h.GetMemoryGame))
 	mux.HandleFunc("POST /api/memory/games/{id}/reveal", middleware.Auth(jwtSecret, h.RevealTiles))
 	mux.HandleFunc("POST /api/memory/games/{id}/resign", middleware.Auth(jwtSecret, h.ResignMemoryGame))
+	mux.HandleFunc("GET /api/memory/games/{id}/replay", middleware.Auth(jwtSecret, h.GetMemoryReplay))
+	mux.HandleFunc("POST /api/memory/games/{id}/rematch", middleware.Auth(jwtSecret, h.Rematch("memory")))
+	mux.HandleFunc("GET /api/memory/games/{id}/series", middleware.Auth(jwtSecret, h.GameSeries("memory")))
+	mux.HandleFunc("GET /api/memory/solo/games", middleware.Auth(jwtSecret, h.GetMemorySoloGames))
+	mux.HandleFunc("POST /api/memory/solo/games", middleware.Auth(jwtSecret, h.CreateMemorySoloGame))
+	mux.HandleFunc("GET /api/memory/solo/games/{id}", middleware.Auth(jwtSecret, h.GetMemorySoloGame))
+	mux.HandleFunc("POST /api/memory/solo/games/{id}/reveal", middleware.Auth(jwtSecret, h.RevealMemorySoloTiles))
+	mux.HandleFunc("GET /api/memory/solo/bests", middleware.Auth(jwtSecret, h.GetMemorySoloBests))
+	mux.HandleFunc("GET /api/memory/solo/leaderboard", middleware.Auth(jwtSecret, h.GetMemorySoloLeaderboard))
+
+	// Connect Four routes
+	mux.HandleFunc("GET /api/connectfour/games", middleware.Auth(jwtSecret, h.GetConnectFourGames))
+	mux.HandleFunc("GET /api/connectfour/games/{id}", middleware.Auth(jwtSecret, h.GetConnectFourGame))
+	mux.HandleFunc("POST /api/connectfour/games/{id}/move", middleware.Auth(jwtSecret, h.MakeConnectFourMove))
+	mux.HandleFunc("POST /api/connectfour/games/{id}/resign", middleware.Auth(jwtSecret, h.ResignConnectFourGame))
+	mux.HandleFunc("POST /api/connectfour/games/{id}/rematch", middleware.Auth(jwtSecret, h.Rematch("connectfour")))
+	mux.HandleFunc("GET /api/connectfour/games/{id}/series", middleware.Auth(jwtSecret, h.GameSeries("connectfour")))
+
+	// Checkers routes
+	mux.HandleFunc("GET /api/checkers/games", middleware.Auth(jwtSecret, h.GetCheckersGames))
+	mux.HandleFunc("GET /api/checkers/games/{id}", middleware.Auth(jwtSecret, h.GetCheckersGame))
+	mux.HandleFunc("POST /api/checkers/games/{id}/move", middleware.Auth(jwtSecret, h.MakeCheckersMove))
+	mux.HandleFunc("POST /api/checkers/games/{id}/resign", middleware.Auth(jwtSecret, h.ResignCheckersGame))
+	mux.HandleFunc("POST /api/checkers/games/{id}/rematch", middleware.Auth(jwtSecret, h.Rematch("checkers")))
+	mux.HandleFunc("GET /api/checkers/games/{id}/series", middleware.Auth(jwtSecret, h.GameSeries("checkers")))
+
+	// Chess routes
+	mux.HandleFunc("GET /api/chess/games", middleware.Auth(jwtSecret, h.GetChessGames))
+	mux.HandleFunc("GET /api/chess/games/{id}", middleware.Auth(jwtSecret, h.GetChessGame))
+	mux.HandleFunc("POST /api/chess/games/{id}/move", middleware.Auth(jwtSecret, h.MakeChessMove))
+	mux.HandleFunc("POST /api/chess/games/{id}/resign", middleware.Auth(jwtSecret, h.ResignChessGame))
+	mux.HandleFunc("GET /api/chess/games/{id}/history", middleware.Auth(jwtSecret, h.GetChessHistory))
+	mux.HandleFunc("GET /api/chess/games/{id}/pgn", middleware.Auth(jwtSecret, h.GetChessPGN))
+	mux.HandleFunc("POST /api/chess/games/{id}/rematch", middleware.Auth(jwtSecret, h.Rematch("chess")))
+	mux.HandleFunc("GET /api/chess/games/{id}/series", middleware.Auth(jwtSecret, h.GameSeries("chess")))
+
+	// Boggle routes
+	mux.HandleFunc("GET /api/boggle/games", middleware.Auth(jwtSecret, h.GetBoggleGames))
+	mux.HandleFunc("GET /api/boggle/games/{id}", middleware.Auth(jwtSecret, h.GetBoggleGame))
+	mux.HandleFunc("POST /api/boggle/games/{id}/start", middleware.Auth(jwtSecret, h.StartBoggleGame))
+	mux.HandleFunc("POST /api/boggle/games/{id}/word", middleware.Auth(jwtSecret, h.SubmitBoggleWord))
+	mux.HandleFunc("POST /api/boggle/games/{id}/resign", middleware.Auth(jwtSecret, h.ResignBoggleGame))
+	mux.HandleFunc("POST /api/boggle/games/{id}/rematch", middleware.Auth(jwtSecret, h.Rematch("boggle")))
+	mux.HandleFunc("GET /api/boggle/games/{id}/series", middleware.Auth(jwtSecret, h.GameSeries("boggle")))
+
+	// Dots and Boxes routes
+	mux.HandleFunc("GET /api/dotsandboxes/games", middleware.Auth(jwtSecret, h.GetDotsAndBoxesGames))
+	mux.HandleFunc("GET /api/dotsandboxes/games/{id}", middleware.Auth(jwtSecret, h.GetDotsAndBoxesGame))
+	mux.HandleFunc("POST /api/dotsandboxes/games/{id}/move", middleware.Auth(jwtSecret, h.MakeDotsAndBoxesMove))
+	mux.HandleFunc("POST /api/dotsandboxes/games/{id}/resign", middleware.Auth(jwtSecret, h.ResignDotsAndBoxesGame))
+	mux.HandleFunc("POST /api/dotsandboxes/games/{id}/rematch", middleware.Auth(jwtSecret, h.Rematch("dotsandboxes")))
+	mux.HandleFunc("GET /api/dotsandboxes/games/{id}/series", middleware.Auth(jwtSecret, h.GameSeries("dotsandboxes")))
+
+	// Othello routes
+	mux.HandleFunc("GET /api/othello/games", middleware.Auth(jwtSecret, h.GetOthelloGames))
+	mux.HandleFunc("GET /api/othello/games/{id}", middleware.Auth(jwtSecret, h.GetOthelloGame))
+	mux.HandleFunc("POST /api/othello/games/{id}/move", middleware.Auth(jwtSecret, h.MakeOthelloMove))
+	mux.HandleFunc("POST /api/othello/games/{id}/resign", middleware.Auth(jwtSecret, h.ResignOthelloGame))
+	mux.HandleFunc("POST /api/othello/games/{id}/rematch", middleware.Auth(jwtSecret, h.Rematch("othello")))
+	mux.HandleFunc("GET /api/othello/games/{id}/series", middleware.Auth(jwtSecret, h.GameSeries("othello")))
+
+	// Go routes
+	mux.HandleFunc("GET /api/go/games", middleware.Auth(jwtSecret, h.GetGoGames))
+	mux.HandleFunc("GET /api/go/games/{id}", middleware.Auth(jwtSecret, h.GetGoGame))
+	mux.HandleFunc("POST /api/go/games/{id}/move", middleware.Auth(jwtSecret, h.MakeGoMove))
+	mux.HandleFunc("POST /api/go/games/{id}/mark", middleware.Auth(jwtSecret, h.MarkGoDeadStones))
+	mux.HandleFunc("POST /api/go/games/{id}/accept", middleware.Auth(jwtSecret, h.AcceptGoScore))
+	mux.HandleFunc("POST /api/go/games/{id}/resume", middleware.Auth(jwtSecret, h.ResumeGo))
+	mux.HandleFunc("POST /api/go/games/{id}/resign", middleware.Auth(jwtSecret, h.ResignGoGame))
+	mux.HandleFunc("GET /api/go/games/{id}/sgf", middleware.Auth(jwtSecret, h.GetGoSGF))
+	mux.HandleFunc("POST /api/go/games/{id}/rematch", middleware.Auth(jwtSecret, h.Rematch("go")))
+	mux.HandleFunc("GET /api/go/games/{id}/series", middleware.Auth(jwtSecret, h.GameSeries("go")))
+
+	// Yahtzee routes
+	mux.HandleFunc("GET /api/yahtzee/games", middleware.Auth(jwtSecret, h.GetYahtzeeGames))
+	mux.HandleFunc("GET /api/yahtzee/games/{id}", middleware.Auth(jwtSecret, h.GetYahtzeeGame))
+	mux.HandleFunc("POST /api/yahtzee/games/{id}/roll", middleware.Auth(jwtSecret, h.RollYahtzeeDice))
+	mux.HandleFunc("POST /api/yahtzee/games/{id}/score", middleware.Auth(jwtSecret, h.ScoreYahtzeeTurn))
+	mux.HandleFunc("POST /api/yahtzee/games/{id}/resign", middleware.Auth(jwtSecret, h.ResignYahtzeeGame))
+	mux.HandleFunc("GET /api/yahtzee/games/{id}/history", middleware.Auth(jwtSecret, h.GetYahtzeeHistory))
+	mux.HandleFunc("POST /api/yahtzee/games/{id}/rematch", middleware.Auth(jwtSecret, h.Rematch("yahtzee")))
+	mux.HandleFunc("GET /api/yahtzee/games/{id}/series", middleware.Auth(jwtSecret, h.GameSeries("yahtzee")))
 
 	// Health check
 	mux.HandleFunc("GET /api/health", func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,240 @@
+package battleship
+
+import (
+	"altech/internal/models"
+)
+
+// targetWeight is how much more likely a placement is once it passes
+// through a hit that no sunk ship accounts for
+const targetWeight = 20
+
+// AnalyzeShots replays one player's shots against the enemy fleet and
+// compares each shot with the best probability-density shot available at
+// that moment, using only what the shooter could see: hits, misses and the
+// outlines of ships already sunk.
+func AnalyzeShots(enemyShips []models.Ship, shots []models.Shot, rules Rules) models.PlayerShotAnalysis {
+	analysis := models.PlayerShotAnalysis{
+		TotalShots: len(shots),
+		Shots:      make([]models.ShotAnalysis, 0, len(shots)),
+	}
+
+	densitySum := 0.0
+	for i, shot := range shots {
+		earlier := shots[:i]
+		sunk := SunkShips(enemyShips, earlier)
+		density := ProbabilityDensity(rules, earlier, sunk)
+
+		mode := "hunt"
+		if len(unresolvedHits(earlier, sunk)) > 0 {
+			mode = "target"
+		}
+
+		bestRow, bestCol, best := 0, 0, -1.0
+		rank := 1
+		chosen := density[shot.Row][shot.Col]
+		for r := range density {
+			for c, d := range density[r] {
+				if d > best {
+					bestRow, bestCol, best = r, c, d
+				}
+				if d > chosen {
+					rank++
+				}
+			}
+		}
+
+		ratio := 1.0
+		if best > 0 {
+			ratio = chosen / best
+		}
+		densitySum += ratio
+		if rank == 1 {
+			analysis.OptimalShots++
+		}
+
+		if shot.Hit {
+			analysis.Hits++
+			if analysis.ShotsToFirstHit == 0 {
+				analysis.ShotsToFirstHit = i + 1
+			}
+		}
+		if mode == "hunt" {
+			analysis.HuntShots++
+			if shot.Hit {
+				analysis.HuntHits++
+			}
+		} else {
+			analysis.TargetShots++
+			if shot.Hit {
+				analysis.TargetHits++
+			}
+		}
+
+		analysis.Shots = append(analysis.Shots, models.ShotAnalysis{
+			Row:         shot.Row,
+			Col:         shot.Col,
+			Hit:         shot.Hit,
+			Mode:        mode,
+			Density:     chosen,
+			BestDensity: best,
+			BestRow:     bestRow,
+			BestCol:     bestCol,
+			Rank:        rank,
+		})
+	}
+
+	if analysis.HuntShots > 0 {
+		analysis.HuntEfficiency = float64(analysis.HuntHits) / float64(analysis.HuntShots)
+	}
+	if analysis.TargetShots > 0 {
+		analysis.TargetEfficiency = float64(analysis.TargetHits) / float64(analysis.TargetShots)
+	}
+	if len(shots) > 0 {
+		analysis.AverageDensityRatio = densitySum / float64(len(shots))
+	}
+
+	return analysis
+}
+
+// ProbabilityDensity scores every cell by how many legal placements of the
+// ships still afloat would cover it, scaled by the number of ship cells still
+// afloat. Placements through unresolved hits are weighted up, and cells
+// already shot score zero.
+func ProbabilityDensity(rules Rules, shots []models.Shot, sunk []models.Ship) [][]float64 {
+	size := rules.BoardSize
+	density := make([][]float64, size)
+	for i := range density {
+		density[i] = make([]float64, size)
+	}
+
+	// Cells no afloat ship can occupy
+	blocked := make(map[string]bool)
+	for _, shot := range shots {
+		if !shot.Hit {
+			blocked[cellKey(shot.Row, shot.Col)] = true
+		}
+	}
+	for _, ship := range sunk {
+		for _, cell := range GetShipCells(ship) {
+			if !rules.NoTouch {
+				blocked[cellKey(cell.Row, cell.Col)] = true
+				continue
+			}
+			for dr := -1; dr <= 1; dr++ {
+				for dc := -1; dc <= 1; dc++ {
+					blocked[cellKey(cell.Row+dr, cell.Col+dc)] = true
+				}
+			}
+		}
+	}
+
+	hits := make(map[string]bool)
+	for _, cell := range unresolvedHits(shots, sunk) {
+		hits[cellKey(cell.Row, cell.Col)] = true
+	}
+
+	// Remaining ships per type
+	afloat := make(map[string]int)
+	for shipType, count := range rules.Fleet {
+		afloat[shipType] = count
+	}
+	for _, ship := range sunk {
+		afloat[ship.Type]--
+	}
+
+	total := 0.0
+	unsunkCells := 0
+	for shipType, count := range afloat {
+		if count <= 0 {
+			continue
+		}
+		unsunkCells += count * ShipSizes[shipType]
+
+		ship := models.Ship{Type: shipType, Size: ShipSizes[shipType]}
+		for _, horizontal := range []bool{true, false} {
+			for row := 0; row < size; row++ {
+				for col := 0; col < size; col++ {
+					ship.StartRow, ship.StartCol, ship.Horizontal = row, col, horizontal
+					cells := GetShipCells(ship)
+
+					weight := float64(count)
+					for _, cell := range cells {
+						key := cellKey(cell.Row, cell.Col)
+						if !rules.InBounds(cell.Row, cell.Col) || blocked[key] {
+							weight = 0
+							break
+						}
+						if hits[key] {
+							weight *= targetWeight
+						}
+					}
+					if weight == 0 {
+						continue
+					}
+
+					for _, cell := range cells {
+						density[cell.Row][cell.Col] += weight
+					}
+					total += weight * float64(len(cells))
+				}
+			}
+		}
+	}
+
+	// Already-shot cells carry no information for the next shot
+	for _, shot := range shots {
+		density[shot.Row][shot.Col] = 0
+	}
+
+	if total > 0 {
+		scale := float64(unsunkCells) / total
+		for r := range density {
+			for c := range density[r] {
+				density[r][c] *= scale
+			}
+		}
+	}
+
+	return density
+}
+
+// PlacementHeatmap counts how often each cell held a ship across fleets
+func PlacementHeatmap(fleets [][]models.Ship, size int) [][]int {
+	heatmap := make([][]int, size)
+	for i := range heatmap {
+		heatmap[i] = make([]int, size)
+	}
+
+	for _, ships := range fleets {
+		for _, ship := range ships {
+			for _, cell := range GetShipCells(ship) {
+				if cell.Row >= 0 && cell.Row < size && cell.Col >= 0 && cell.Col < size {
+					heatmap[cell.Row][cell.Col]++
+				}
+			}
+		}
+	}
+
+	return heatmap
+}
+
+// unresolvedHits returns hit cells that are not part of a sunk ship
+func unresolvedHits(shots []models.Shot, sunk []models.Ship) []models.Cell {
+	var cells []models.Cell
+	for _, shot := range shots {
+		if !shot.Hit {
+			continue
+		}
+		resolved := false
+		for _, ship := range sunk {
+			if shipCovers(ship, shot.Row, shot.Col) {
+				resolved = true
+				break
+			}
+		}
+		if !resolved {
+			cells = append(cells, models.Cell{Row: shot.Row, Col: shot.Col})
+		}
+	}
+	return cells
+}
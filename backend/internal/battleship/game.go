@@ -3,12 +3,11 @@ package battleship
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"altech/internal/models"
 )
 
-const BoardSize = 10
-
 var ShipSizes = map[string]int{
 	"carrier":    5,
 	"battleship": 4,
@@ -19,11 +18,11 @@ var ShipSizes = map[string]int{
 
 var RequiredShips = []string{"carrier", "battleship", "cruiser", "submarine", "destroyer"}
 
-// CreateEmptyBoard creates a 10x10 empty board
-func CreateEmptyBoard() [][]string {
-	board := make([][]string, BoardSize)
+// CreateEmptyBoard creates an empty board of the given size
+func CreateEmptyBoard(size int) [][]string {
+	board := make([][]string, size)
 	for i := range board {
-		board[i] = make([]string, BoardSize)
+		board[i] = make([]string, size)
 		for j := range board[i] {
 			board[i][j] = "empty"
 		}
@@ -31,48 +30,63 @@ func CreateEmptyBoard() [][]string {
 	return board
 }
 
-// ValidateShipPlacement checks if ships are placed correctly
-func ValidateShipPlacement(ships []models.Ship) error {
-	if len(ships) != len(RequiredShips) {
-		return errors.New("must place exactly 5 ships")
+// ValidateShipPlacement checks if ships are placed correctly under the game's rules
+func ValidateShipPlacement(ships []models.Ship, rules Rules) error {
+	if len(ships) != rules.ShipCount() {
+		return fmt.Errorf("must place exactly %d ships", rules.ShipCount())
 	}
 
-	// Check all required ship types
-	placedTypes := make(map[string]bool)
+	// Check the placed ships match the fleet
+	placedTypes := make(map[string]int)
 	for _, ship := range ships {
-		if placedTypes[ship.Type] {
-			return errors.New("duplicate ship type: " + ship.Type)
-		}
-		placedTypes[ship.Type] = true
-
 		expectedSize, ok := ShipSizes[ship.Type]
-		if !ok {
+		if !ok || rules.Fleet[ship.Type] == 0 {
 			return errors.New("invalid ship type: " + ship.Type)
 		}
 		if ship.Size != expectedSize {
 			return errors.New("invalid ship size for " + ship.Type)
 		}
+		placedTypes[ship.Type]++
+		if placedTypes[ship.Type] > rules.Fleet[ship.Type] {
+			return errors.New("too many ships of type: " + ship.Type)
+		}
 	}
 
-	for _, requiredType := range RequiredShips {
-		if !placedTypes[requiredType] {
-			return errors.New("missing ship: " + requiredType)
+	for shipType, count := range rules.Fleet {
+		if placedTypes[shipType] < count {
+			return errors.New("missing ship: " + shipType)
 		}
 	}
 
 	// Check bounds and overlaps
-	occupied := make(map[string]bool)
-	for _, ship := range ships {
+	occupied := make(map[string]int)
+	for i, ship := range ships {
 		cells := GetShipCells(ship)
 		for _, cell := range cells {
-			if cell.Row < 0 || cell.Row >= BoardSize || cell.Col < 0 || cell.Col >= BoardSize {
+			if !rules.InBounds(cell.Row, cell.Col) {
 				return errors.New("ship out of bounds")
 			}
 			key := cellKey(cell.Row, cell.Col)
-			if occupied[key] {
+			if _, taken := occupied[key]; taken {
 				return errors.New("ships overlap")
 			}
-			occupied[key] = true
+			occupied[key] = i
+		}
+	}
+
+	// Check no ship borders another, diagonals included
+	if rules.NoTouch {
+		for i, ship := range ships {
+			for _, cell := range GetShipCells(ship) {
+				for dr := -1; dr <= 1; dr++ {
+					for dc := -1; dc <= 1; dc++ {
+						other, taken := occupied[cellKey(cell.Row+dr, cell.Col+dc)]
+						if taken && other != i {
+							return errors.New("ships may not touch")
+						}
+					}
+				}
+			}
 		}
 	}
 
@@ -92,9 +106,10 @@ func GetShipCells(ship models.Ship) []models.Cell {
 	return cells
 }
 
-// ProcessShot processes a shot and returns the result
-func ProcessShot(ships []models.Ship, shots []models.Shot, row, col int) (hit bool, sunk bool, shipType string, err error) {
-	if row < 0 || row >= BoardSize || col < 0 || col >= BoardSize {
+// ProcessShot works out the result of a shot against the given ships.
+// shots are the earlier shots fired at the same board; ships are not modified.
+func ProcessShot(ships []models.Ship, shots []models.Shot, row, col int, rules Rules) (hit bool, sunk bool, shipType string, err error) {
+	if !rules.InBounds(row, col) {
 		return false, false, "", errors.New("shot out of bounds")
 	}
 
@@ -106,23 +121,40 @@ func ProcessShot(ships []models.Ship, shots []models.Shot, row, col int) (hit bo
 	}
 
 	// Check if hit
-	for i, ship := range ships {
-		cells := GetShipCells(ship)
-		for _, cell := range cells {
-			if cell.Row == row && cell.Col == col {
-				ships[i].Hits++
-				sunk = ships[i].Hits >= ship.Size
-				return true, sunk, ship.Type, nil
+	for _, ship := range ships {
+		if !shipCovers(ship, row, col) {
+			continue
+		}
+		hits := 1
+		for _, shot := range shots {
+			if shipCovers(ship, shot.Row, shot.Col) {
+				hits++
 			}
 		}
+		return true, hits >= ship.Size, ship.Type, nil
 	}
 
 	return false, false, "", nil
 }
 
-// CheckAllShipsSunk returns true if all ships are sunk
-func CheckAllShipsSunk(ships []models.Ship) bool {
-	for _, ship := range ships {
+// ApplyDamage returns a copy of ships with Hits counted from the shots fired at them
+func ApplyDamage(ships []models.Ship, shots []models.Shot) []models.Ship {
+	damaged := make([]models.Ship, len(ships))
+	for i, ship := range ships {
+		ship.Hits = 0
+		for _, shot := range shots {
+			if shipCovers(ship, shot.Row, shot.Col) {
+				ship.Hits++
+			}
+		}
+		damaged[i] = ship
+	}
+	return damaged
+}
+
+// CheckAllShipsSunk returns true if the shots have sunk every ship
+func CheckAllShipsSunk(ships []models.Ship, shots []models.Shot) bool {
+	for _, ship := range ApplyDamage(ships, shots) {
 		if ship.Hits < ship.Size {
 			return false
 		}
@@ -131,8 +163,8 @@ func CheckAllShipsSunk(ships []models.Ship) bool {
 }
 
 // BuildMyBoard creates the full view of own board (shows ships)
-func BuildMyBoard(ships []models.Ship, shots []models.Shot) [][]string {
-	board := CreateEmptyBoard()
+func BuildMyBoard(ships []models.Ship, shots []models.Shot, size int) [][]string {
+	board := CreateEmptyBoard(size)
 
 	// Place ships
 	for _, ship := range ships {
@@ -143,21 +175,76 @@ func BuildMyBoard(ships []models.Ship, shots []models.Shot) [][]string {
 	}
 
 	// Apply shots
-	for _, shot := range shots {
-		if shot.Hit {
-			board[shot.Row][shot.Col] = "hit"
-		} else {
-			board[shot.Row][shot.Col] = "miss"
+	applyShots(board, shots)
+
+	// Mark sunk ships
+	for _, ship := range ApplyDamage(ships, shots) {
+		if ship.Hits >= ship.Size {
+			markShip(board, ship, "sunk")
+		}
+	}
+
+	return board
+}
+
+// BuildEnemyBoard creates the limited view of enemy board: hits, misses and
+// the outlines of sunk ships. Ships still afloat stay hidden unless revealAll
+// is set, which is used once the game is over.
+func BuildEnemyBoard(ships []models.Ship, shots []models.Shot, size int, revealAll bool) [][]string {
+	board := CreateEmptyBoard(size)
+
+	if revealAll {
+		for _, ship := range ships {
+			markShip(board, ship, "ship")
 		}
 	}
 
+	applyShots(board, shots)
+
+	for _, ship := range SunkShips(ships, shots) {
+		markShip(board, ship, "sunk")
+	}
+
 	return board
 }
 
-// BuildEnemyBoard creates the limited view of enemy board (only hits/misses)
-func BuildEnemyBoard(shots []models.Shot) [][]string {
-	board := CreateEmptyBoard()
+// SunkShips returns the ships the shots have sunk, with damage applied
+func SunkShips(ships []models.Ship, shots []models.Shot) []models.Ship {
+	sunk := []models.Ship{}
+	for _, ship := range ApplyDamage(ships, shots) {
+		if ship.Hits >= ship.Size {
+			sunk = append(sunk, ship)
+		}
+	}
+	return sunk
+}
 
+// BuildFleetStatus counts sunk and afloat ships per type. Ships may be empty
+// while the owner is still placing them, in which case the whole fleet is afloat.
+func BuildFleetStatus(ships []models.Ship, shots []models.Shot, rules Rules) []models.FleetStatus {
+	sunkByType := make(map[string]int)
+	for _, ship := range SunkShips(ships, shots) {
+		sunkByType[ship.Type]++
+	}
+
+	status := []models.FleetStatus{}
+	for _, shipType := range RequiredShips {
+		count := rules.Fleet[shipType]
+		if count == 0 {
+			continue
+		}
+		status = append(status, models.FleetStatus{
+			Type:   shipType,
+			Size:   ShipSizes[shipType],
+			Total:  count,
+			Sunk:   sunkByType[shipType],
+			Afloat: count - sunkByType[shipType],
+		})
+	}
+	return status
+}
+
+func applyShots(board [][]string, shots []models.Shot) {
 	for _, shot := range shots {
 		if shot.Hit {
 			board[shot.Row][shot.Col] = "hit"
@@ -165,8 +252,12 @@ func BuildEnemyBoard(shots []models.Shot) [][]string {
 			board[shot.Row][shot.Col] = "miss"
 		}
 	}
+}
 
-	return board
+func markShip(board [][]string, ship models.Ship, status string) {
+	for _, cell := range GetShipCells(ship) {
+		board[cell.Row][cell.Col] = status
+	}
 }
 
 // JSON helpers
@@ -184,18 +275,11 @@ func ShipsFromJSON(data string) ([]models.Ship, error) {
 	return ships, err
 }
 
-func ShotsToJSON(shots []models.Shot) (string, error) {
-	data, err := json.Marshal(shots)
-	return string(data), err
-}
-
-func ShotsFromJSON(data string) ([]models.Shot, error) {
-	var shots []models.Shot
-	if data == "" || data == "[]" {
-		return shots, nil
+func shipCovers(ship models.Ship, row, col int) bool {
+	if ship.Horizontal {
+		return row == ship.StartRow && col >= ship.StartCol && col < ship.StartCol+ship.Size
 	}
-	err := json.Unmarshal([]byte(data), &shots)
-	return shots, err
+	return col == ship.StartCol && row >= ship.StartRow && row < ship.StartRow+ship.Size
 }
 
 func cellKey(row, col int) string {
@@ -0,0 +1,178 @@
+package battleship
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+
+	"altech/internal/models"
+)
+
+// Placement strategies for GenerateFleet
+const (
+	StrategyRandom = "random" // any legal layout
+	StrategySpread = "spread" // keep ships as far apart as possible
+	StrategyEdge   = "edge"   // hug the edges of the board
+)
+
+const maxPlacementAttempts = 200
+
+var ErrFleetDoesNotFit = errors.New("fleet does not fit on the board")
+
+// ValidStrategy reports whether s is a known placement strategy
+func ValidStrategy(s string) bool {
+	return s == StrategyRandom || s == StrategySpread || s == StrategyEdge
+}
+
+// GenerateFleet builds a legal random fleet for the rules using the given
+// strategy. Ships are placed largest first; if a ship has nowhere to go the
+// whole layout is restarted.
+func GenerateFleet(rules Rules, strategy string) ([]models.Ship, error) {
+	if !ValidStrategy(strategy) {
+		return nil, errors.New("invalid strategy: must be random, spread, or edge")
+	}
+
+	var fleet []models.Ship
+	for shipType, count := range rules.Fleet {
+		for i := 0; i < count; i++ {
+			fleet = append(fleet, models.Ship{Type: shipType, Size: ShipSizes[shipType]})
+		}
+	}
+	sort.Slice(fleet, func(i, j int) bool {
+		if fleet[i].Size != fleet[j].Size {
+			return fleet[i].Size > fleet[j].Size
+		}
+		return fleet[i].Type < fleet[j].Type
+	})
+
+	for attempt := 0; attempt < maxPlacementAttempts; attempt++ {
+		placed, ok := placeFleet(fleet, rules, strategy)
+		if ok && ValidateShipPlacement(placed, rules) == nil {
+			return placed, nil
+		}
+	}
+
+	return nil, ErrFleetDoesNotFit
+}
+
+func placeFleet(fleet []models.Ship, rules Rules, strategy string) ([]models.Ship, bool) {
+	placed := make([]models.Ship, 0, len(fleet))
+	for _, ship := range fleet {
+		candidates := candidatePositions(ship, placed, rules)
+		if len(candidates) == 0 {
+			return nil, false
+		}
+
+		switch strategy {
+		case StrategySpread:
+			candidates = bestCandidates(candidates, func(c models.Ship) int {
+				return minDistance(c, placed, rules.BoardSize)
+			})
+		case StrategyEdge:
+			candidates = bestCandidates(candidates, func(c models.Ship) int {
+				return edgeCells(c, rules.BoardSize)
+			})
+		}
+
+		placed = append(placed, candidates[rand.Intn(len(candidates))])
+	}
+	return placed, true
+}
+
+// candidatePositions lists every legal position for ship given the ships
+// already placed
+func candidatePositions(ship models.Ship, placed []models.Ship, rules Rules) []models.Ship {
+	blocked := make(map[string]bool)
+	for _, p := range placed {
+		for _, cell := range GetShipCells(p) {
+			if !rules.NoTouch {
+				blocked[cellKey(cell.Row, cell.Col)] = true
+				continue
+			}
+			for dr := -1; dr <= 1; dr++ {
+				for dc := -1; dc <= 1; dc++ {
+					blocked[cellKey(cell.Row+dr, cell.Col+dc)] = true
+				}
+			}
+		}
+	}
+
+	var candidates []models.Ship
+	for _, horizontal := range []bool{true, false} {
+		for row := 0; row < rules.BoardSize; row++ {
+			for col := 0; col < rules.BoardSize; col++ {
+				candidate := ship
+				candidate.StartRow = row
+				candidate.StartCol = col
+				candidate.Horizontal = horizontal
+
+				fits := true
+				for _, cell := range GetShipCells(candidate) {
+					if !rules.InBounds(cell.Row, cell.Col) || blocked[cellKey(cell.Row, cell.Col)] {
+						fits = false
+						break
+					}
+				}
+				if fits {
+					candidates = append(candidates, candidate)
+				}
+			}
+		}
+	}
+	return candidates
+}
+
+// bestCandidates keeps the candidates with the highest score
+func bestCandidates(candidates []models.Ship, score func(models.Ship) int) []models.Ship {
+	best := []models.Ship{}
+	bestScore := -1
+	for _, c := range candidates {
+		s := score(c)
+		if s > bestScore {
+			best = best[:0]
+			bestScore = s
+		}
+		if s == bestScore {
+			best = append(best, c)
+		}
+	}
+	return best
+}
+
+// minDistance is the smallest Chebyshev distance between ship and any placed
+// ship; with nothing placed yet every position scores the same
+func minDistance(ship models.Ship, placed []models.Ship, boardSize int) int {
+	if len(placed) == 0 {
+		return boardSize
+	}
+	min := boardSize
+	for _, p := range placed {
+		for _, a := range GetShipCells(ship) {
+			for _, b := range GetShipCells(p) {
+				d := max(abs(a.Row-b.Row), abs(a.Col-b.Col))
+				if d < min {
+					min = d
+				}
+			}
+		}
+	}
+	return min
+}
+
+// edgeCells counts how many of the ship's cells sit on the outer ring
+func edgeCells(ship models.Ship, boardSize int) int {
+	count := 0
+	for _, cell := range GetShipCells(ship) {
+		if cell.Row == 0 || cell.Row == boardSize-1 || cell.Col == 0 || cell.Col == boardSize-1 {
+			count++
+		}
+	}
+	return count
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,158 @@
+package battleship
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"altech/internal/models"
+)
+
+const (
+	DefaultBoardSize = 10
+	MaxFleetShips    = 10
+)
+
+// BoardSizes are the grid sizes a game can be created with
+var BoardSizes = []int{8, 10, 12}
+
+// Rules holds the variant settings a game was created with
+type Rules struct {
+	BoardSize int
+	Fleet     map[string]int // ship type -> number of that ship
+	Salvo     bool           // one shot per surviving ship each turn
+	NoTouch   bool           // ships may not be adjacent, including diagonally
+}
+
+// DefaultFleet returns the classic five-ship fleet
+func DefaultFleet() map[string]int {
+	fleet := make(map[string]int)
+	for _, shipType := range RequiredShips {
+		fleet[shipType]++
+	}
+	return fleet
+}
+
+// DefaultRules returns the classic 10x10, one-shot-per-turn rules
+func DefaultRules() Rules {
+	return Rules{
+		BoardSize: DefaultBoardSize,
+		Fleet:     DefaultFleet(),
+	}
+}
+
+// NewRules validates variant settings and fills in defaults for anything unset
+func NewRules(boardSize int, fleet map[string]int, salvo, noTouch bool) (Rules, error) {
+	rules := Rules{
+		BoardSize: boardSize,
+		Fleet:     fleet,
+		Salvo:     salvo,
+		NoTouch:   noTouch,
+	}
+	if rules.BoardSize == 0 {
+		rules.BoardSize = DefaultBoardSize
+	}
+	if len(rules.Fleet) == 0 {
+		rules.Fleet = DefaultFleet()
+	}
+
+	validSize := false
+	for _, size := range BoardSizes {
+		if rules.BoardSize == size {
+			validSize = true
+			break
+		}
+	}
+	if !validSize {
+		return Rules{}, errors.New("invalid board size: must be 8, 10, or 12")
+	}
+
+	totalShips := 0
+	totalCells := 0
+	for shipType, count := range rules.Fleet {
+		size, ok := ShipSizes[shipType]
+		if !ok {
+			return Rules{}, errors.New("invalid ship type: " + shipType)
+		}
+		if count < 0 {
+			return Rules{}, errors.New("invalid ship count for " + shipType)
+		}
+		totalShips += count
+		totalCells += count * size
+	}
+	if totalShips == 0 {
+		return Rules{}, errors.New("fleet must contain at least one ship")
+	}
+	if totalShips > MaxFleetShips {
+		return Rules{}, fmt.Errorf("fleet cannot have more than %d ships", MaxFleetShips)
+	}
+	// Keep the fleet sparse, then make sure a legal layout really exists
+	if totalCells*3 > rules.BoardSize*rules.BoardSize {
+		return Rules{}, errors.New("fleet is too large for the board")
+	}
+	if _, err := GenerateFleet(rules, StrategyRandom); err != nil {
+		return Rules{}, err
+	}
+
+	return rules, nil
+}
+
+// RulesForGame returns the rules a stored game was created with
+func RulesForGame(game *models.BattleshipGame) Rules {
+	rules := DefaultRules()
+	if game.BoardSize > 0 {
+		rules.BoardSize = game.BoardSize
+	}
+	if fleet, err := FleetFromJSON(game.Fleet); err == nil && len(fleet) > 0 {
+		rules.Fleet = fleet
+	}
+	rules.Salvo = game.Salvo
+	rules.NoTouch = game.NoTouch
+	return rules
+}
+
+// ShipCount returns the total number of ships in the fleet
+func (r Rules) ShipCount() int {
+	count := 0
+	for _, n := range r.Fleet {
+		count += n
+	}
+	return count
+}
+
+// InBounds reports whether a coordinate lies on the board
+func (r Rules) InBounds(row, col int) bool {
+	return row >= 0 && row < r.BoardSize && col >= 0 && col < r.BoardSize
+}
+
+// ShotsPerTurn returns how many shots the owner of ships may fire this turn.
+// Classic games always get one; Salvo games get one per ship still afloat,
+// so ships must already have their damage applied.
+func (r Rules) ShotsPerTurn(ships []models.Ship) int {
+	if !r.Salvo {
+		return 1
+	}
+	afloat := 0
+	for _, ship := range ships {
+		if ship.Hits < ship.Size {
+			afloat++
+		}
+	}
+	return afloat
+}
+
+// FleetToJSON encodes a fleet for storage
+func FleetToJSON(fleet map[string]int) (string, error) {
+	data, err := json.Marshal(fleet)
+	return string(data), err
+}
+
+// FleetFromJSON decodes a stored fleet; an empty string means the default fleet
+func FleetFromJSON(data string) (map[string]int, error) {
+	if data == "" || data == "{}" {
+		return DefaultFleet(), nil
+	}
+	var fleet map[string]int
+	err := json.Unmarshal([]byte(data), &fleet)
+	return fleet, err
+}
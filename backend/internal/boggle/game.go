@@ -0,0 +1,295 @@
+package boggle
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	mrand "math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"altech/internal/models"
+	"altech/internal/scrabble"
+)
+
+const (
+	DefaultSize = 4
+
+	// Duration is each player's time window, in seconds
+	Duration = 180
+	// GraceSeconds allows for network delay on the last word
+	GraceSeconds = 2
+)
+
+// Standard dice; "Q" faces are played as "QU"
+var (
+	classicDice = []string{
+		"AAEEGN", "ABBJOO", "ACHOPS", "AFFKPS", "AOOTTW", "CIMOTU", "DEILRX", "DELRVY",
+		"DISTTY", "EEGHNW", "EEINSU", "EHRTVW", "EIOSST", "ELRTTY", "HIMNQU", "HLNNRZ",
+	}
+	bigDice = []string{
+		"AAAFRS", "AAEEEE", "AAFIRS", "ADENNN", "AEEEEM", "AEEGMU", "AEGMNN", "AFIRSY", "BJKQXZ",
+		"CCENST", "CEIILT", "CEILPT", "CEIPST", "DDHNOT", "DHHLOR", "DHLNOR", "DHLNOR", "EIIITT",
+		"EMOTTT", "ENSSSU", "FIPRSY", "GORRVW", "IPRRRY", "NOOTUW", "OOOTTU",
+	}
+)
+
+// ValidateSize returns a supported grid size, defaulting to 4x4
+func ValidateSize(size int) int {
+	if size == 5 {
+		return 5
+	}
+	return DefaultSize
+}
+
+// MinWordLength is 3 letters on a 4x4 grid and 4 on a 5x5 grid
+func MinWordLength(size int) int {
+	if size == 5 {
+		return 4
+	}
+	return 3
+}
+
+// NewSeed returns a random seed for a game's grid
+func NewSeed() (int64, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(b[:]) >> 1), nil
+}
+
+// GenerateGrid shakes the dice for size. The same seed always gives the
+// same grid.
+func GenerateGrid(size int, seed int64) [][]string {
+	dice := classicDice
+	if size == 5 {
+		dice = bigDice
+	}
+	rng := mrand.New(mrand.NewSource(seed))
+
+	order := rng.Perm(len(dice))
+	grid := make([][]string, size)
+	for r := range grid {
+		grid[r] = make([]string, size)
+		for c := range grid[r] {
+			die := dice[order[r*size+c]]
+			face := string(die[rng.Intn(len(die))])
+			if face == "Q" {
+				face = "QU"
+			}
+			grid[r][c] = face
+		}
+	}
+	return grid
+}
+
+// Score gives the standard points for a word of n letters
+func Score(word string) int {
+	switch n := len(word); {
+	case n <= 4:
+		return 1
+	case n == 5:
+		return 2
+	case n == 6:
+		return 3
+	case n == 7:
+		return 5
+	default:
+		return 11
+	}
+}
+
+// NormalizeWord upper-cases a word and trims surrounding space
+func NormalizeWord(word string) string {
+	return strings.ToUpper(strings.TrimSpace(word))
+}
+
+// ValidateWord checks a submitted word against the grid and the dictionary.
+// If path is given it must spell the word through adjacent cells without
+// reusing one; otherwise a path is found. The path used is returned.
+func ValidateWord(grid [][]string, word string, path []models.BoggleCell) ([]models.BoggleCell, error) {
+	if len(word) < MinWordLength(len(grid)) {
+		return nil, errors.New("word is too short")
+	}
+	for _, ch := range word {
+		if ch < 'A' || ch > 'Z' {
+			return nil, errors.New("word must only contain letters")
+		}
+	}
+
+	if len(path) > 0 {
+		if err := checkPath(grid, word, path); err != nil {
+			return nil, err
+		}
+	} else if path = FindPath(grid, word); path == nil {
+		return nil, errors.New("word cannot be traced on the grid")
+	}
+
+	if !scrabble.IsValidWord(word) {
+		return nil, errors.New("not a valid word")
+	}
+	return path, nil
+}
+
+func checkPath(grid [][]string, word string, path []models.BoggleCell) error {
+	size := len(grid)
+	used := make(map[models.BoggleCell]bool)
+	var spelled strings.Builder
+	for i, cell := range path {
+		if cell.Row < 0 || cell.Row >= size || cell.Col < 0 || cell.Col >= size {
+			return errors.New("path leaves the grid")
+		}
+		if used[cell] {
+			return errors.New("path uses a cell twice")
+		}
+		if i > 0 && !adjacent(path[i-1], cell) {
+			return errors.New("path cells must be adjacent")
+		}
+		used[cell] = true
+		spelled.WriteString(grid[cell.Row][cell.Col])
+	}
+	if spelled.String() != word {
+		return errors.New("path does not spell the word")
+	}
+	return nil
+}
+
+func adjacent(a, b models.BoggleCell) bool {
+	dr, dc := a.Row-b.Row, a.Col-b.Col
+	return (dr != 0 || dc != 0) && dr >= -1 && dr <= 1 && dc >= -1 && dc <= 1
+}
+
+// FindPath returns a path spelling word on the grid, or nil if there is none
+func FindPath(grid [][]string, word string) []models.BoggleCell {
+	size := len(grid)
+	used := make([][]bool, size)
+	for r := range used {
+		used[r] = make([]bool, size)
+	}
+
+	var path []models.BoggleCell
+	var search func(r, c int, rest string) bool
+	search = func(r, c int, rest string) bool {
+		if r < 0 || r >= size || c < 0 || c >= size || used[r][c] || !strings.HasPrefix(rest, grid[r][c]) {
+			return false
+		}
+		path = append(path, models.BoggleCell{Row: r, Col: c})
+		rest = rest[len(grid[r][c]):]
+		if rest == "" {
+			return true
+		}
+		used[r][c] = true
+		for dr := -1; dr <= 1; dr++ {
+			for dc := -1; dc <= 1; dc++ {
+				if (dr != 0 || dc != 0) && search(r+dr, c+dc, rest) {
+					return true
+				}
+			}
+		}
+		used[r][c] = false
+		path = path[:len(path)-1]
+		return false
+	}
+
+	for r := 0; r < size; r++ {
+		for c := 0; c < size; c++ {
+			if search(r, c, word) {
+				return path
+			}
+		}
+	}
+	return nil
+}
+
+// Solve lists every dictionary word that can be traced on the grid,
+// longest first
+func Solve(grid [][]string) []string {
+	minLen := MinWordLength(len(grid))
+	maxLen := 2 * len(grid) * len(grid) // Every cell used, all of them QU
+
+	var words []string
+	scrabble.EachWord(func(word string) {
+		if len(word) >= minLen && len(word) <= maxLen && FindPath(grid, word) != nil {
+			words = append(words, word)
+		}
+	})
+
+	sort.Slice(words, func(i, j int) bool {
+		if len(words[i]) != len(words[j]) {
+			return len(words[i]) > len(words[j])
+		}
+		return words[i] < words[j]
+	})
+	return words
+}
+
+// Deadline is when a window started at started closes, including the grace
+// period
+func Deadline(started time.Time, durationSeconds int) time.Time {
+	return started.Add(time.Duration(durationSeconds+GraceSeconds) * time.Second)
+}
+
+// SecondsLeft is the time remaining in a window, as shown to the player
+func SecondsLeft(started *time.Time, durationSeconds int, now time.Time) int {
+	if started == nil {
+		return durationSeconds
+	}
+	left := started.Add(time.Duration(durationSeconds) * time.Second).Sub(now).Seconds()
+	if left < 0 {
+		return 0
+	}
+	return int(left)
+}
+
+// Tally marks the words both players found as cancelled and returns each
+// player's score from the rest
+func Tally(words []models.BoggleWord, player1ID int64) (player1Score, player2Score int) {
+	finders := make(map[string]map[int64]bool)
+	for _, w := range words {
+		if finders[w.Word] == nil {
+			finders[w.Word] = make(map[int64]bool)
+		}
+		finders[w.Word][w.UserID] = true
+	}
+
+	for i := range words {
+		w := &words[i]
+		w.Cancelled = len(finders[w.Word]) > 1
+		if w.Cancelled {
+			continue
+		}
+		if w.UserID == player1ID {
+			player1Score += w.Points
+		} else {
+			player2Score += w.Points
+		}
+	}
+	return player1Score, player2Score
+}
+
+// JSON helpers
+
+func GridToJSON(grid [][]string) (string, error) {
+	data, err := json.Marshal(grid)
+	return string(data), err
+}
+
+func GridFromJSON(data string) ([][]string, error) {
+	var grid [][]string
+	err := json.Unmarshal([]byte(data), &grid)
+	return grid, err
+}
+
+func SolutionToJSON(words []string) (string, error) {
+	data, err := json.Marshal(words)
+	return string(data), err
+}
+
+func SolutionFromJSON(data string) ([]string, error) {
+	var words []string
+	err := json.Unmarshal([]byte(data), &words)
+	return words, err
+}
@@ -0,0 +1,96 @@
+package chat
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	MaxMessageLength = 500 // Characters
+	DefaultPageSize  = 50
+	MaxPageSize      = 100
+	MaxWaitSeconds   = 30 // Longest a client may wait for new messages
+)
+
+// ValidateMessage trims a message and checks its length
+func ValidateMessage(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", errors.New("message cannot be empty")
+	}
+	if utf8.RuneCountInString(body) > MaxMessageLength {
+		return "", fmt.Errorf("message must be at most %d characters", MaxMessageLength)
+	}
+	return body, nil
+}
+
+// ValidatePageSize defaults and caps a page size
+func ValidatePageSize(n int) int {
+	if n <= 0 {
+		return DefaultPageSize
+	}
+	if n > MaxPageSize {
+		return MaxPageSize
+	}
+	return n
+}
+
+// profanity holds the words the filter masks, with their inflections listed
+// rather than derived: stripping endings would also catch innocent words
+// like "assess", "cocker" and "pricked".
+var profanity = map[string]bool{
+	"arse": true, "arses": true, "arsehole": true, "arseholes": true,
+	"ass": true, "asses": true, "asshole": true, "assholes": true,
+	"bastard": true, "bastards": true, "bitch": true, "bitches": true,
+	"bitching": true, "bitchy": true, "bollocks": true, "bullshit": true,
+	"cock": true, "cocks": true, "crap": true, "crappy": true,
+	"cunt": true, "cunts": true, "damn": true, "damned": true,
+	"dick": true, "dicks": true, "dickhead": true, "dickheads": true,
+	"fuck": true, "fucked": true, "fucker": true, "fuckers": true,
+	"fucking": true, "fucks": true, "motherfucker": true, "motherfuckers": true,
+	"motherfucking": true, "piss": true, "pissed": true, "pissing": true,
+	"prick": true, "pricks": true, "pussies": true, "pussy": true,
+	"shit": true, "shits": true, "shitting": true, "shitty": true,
+	"slut": true, "sluts": true, "slutty": true, "twat": true, "twats": true,
+	"wanker": true, "wankers": true, "whore": true, "whores": true,
+}
+
+// leet undoes common letter substitutions before matching
+var leet = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s")
+
+func isProfane(word string) bool {
+	return profanity[leet.Replace(strings.ToLower(word))]
+}
+
+// Filter masks profane words with asterisks, keeping the message's length
+// and everything else in it
+func Filter(body string) string {
+	isWordRune := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '@' || r == '$'
+	}
+
+	var out strings.Builder
+	runes := []rune(body)
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			out.WriteRune(runes[i])
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && isWordRune(runes[j]) {
+			j++
+		}
+		word := string(runes[i:j])
+		if isProfane(word) {
+			out.WriteString(strings.Repeat("*", j-i))
+		} else {
+			out.WriteString(word)
+		}
+		i = j
+	}
+	return out.String()
+}
@@ -0,0 +1,69 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name, body, want string
+	}{
+		{"clean", "good game", "good game"},
+		{"word", "oh shit", "oh ****"},
+		{"capitals", "SHIT happens", "**** happens"},
+		{"listed inflection", "fucking hell", "******* hell"},
+		{"leet", "you @$$", "you ***"},
+		{"leet digits", "sh1t move", "**** move"},
+		{"punctuation kept", "shit, damn!", "****, ****!"},
+		{"inside a sentence", "(crap) again?", "(****) again?"},
+		{"assess", "let me assess", "let me assess"},
+		{"cocker", "a cocker spaniel", "a cocker spaniel"},
+		{"pricked", "pricked my finger", "pricked my finger"},
+		{"part of a longer word", "classic passing", "classic passing"},
+		{"multibyte text", "café shit ☕", "café **** ☕"},
+	}
+
+	for _, tt := range tests {
+		got := Filter(tt.body)
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+		if utf8.RuneCountInString(got) != utf8.RuneCountInString(tt.body) {
+			t.Errorf("%s: length changed from %d to %d", tt.name, utf8.RuneCountInString(tt.body), utf8.RuneCountInString(got))
+		}
+	}
+}
+
+func TestValidateMessage(t *testing.T) {
+	tests := []struct {
+		name, body, want string
+		wantErr          bool
+	}{
+		{"trimmed", "  hello \n", "hello", false},
+		{"empty", "   ", "", true},
+		{"at the limit", strings.Repeat("a", MaxMessageLength), strings.Repeat("a", MaxMessageLength), false},
+		{"over the limit", strings.Repeat("a", MaxMessageLength+1), "", true},
+		{"multibyte at the limit", strings.Repeat("é", MaxMessageLength), strings.Repeat("é", MaxMessageLength), false},
+		{"multibyte over the limit", strings.Repeat("é", MaxMessageLength+1), "", true},
+		{"spaces around the limit", " " + strings.Repeat("a", MaxMessageLength) + " ", strings.Repeat("a", MaxMessageLength), false},
+	}
+
+	for _, tt := range tests {
+		got, err := ValidateMessage(tt.body)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: message allowed", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package chat
+
+import "sync"
+
+// Hub wakes clients waiting on new messages. It only lives in this process,
+// so a client that is not waiting picks messages up on its next poll.
+type Hub struct {
+	mu      sync.Mutex
+	waiters map[int64]map[chan struct{}]bool
+}
+
+func NewHub() *Hub {
+	return &Hub{waiters: make(map[int64]map[chan struct{}]bool)}
+}
+
+// Subscribe returns a channel signalled when userID gets a message, and a
+// function to stop listening
+func (h *Hub) Subscribe(userID int64) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	h.mu.Lock()
+	if h.waiters[userID] == nil {
+		h.waiters[userID] = make(map[chan struct{}]bool)
+	}
+	h.waiters[userID][ch] = true
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		delete(h.waiters[userID], ch)
+		if len(h.waiters[userID]) == 0 {
+			delete(h.waiters, userID)
+		}
+		h.mu.Unlock()
+	}
+}
+
+// Notify signals every waiter of userID
+func (h *Hub) Notify(userID int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.waiters[userID] {
+		select {
+		case ch <- struct{}{}:
+		default: // Already signalled
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package checkers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"altech/internal/models"
+)
+
+const BoardSize = 8
+
+// Square contents
+const (
+	Empty     = 0
+	BlackMan  = 1
+	WhiteMan  = 2
+	BlackKing = 3
+	WhiteKing = 4
+)
+
+// Sides; Black is player 1 and moves first
+const (
+	Black = 1
+	White = 2
+)
+
+// QuietPlyLimit is the 40-move rule: 40 moves by each side with no capture
+// and no man moved ends the game as a draw
+const QuietPlyLimit = 80
+
+// Reasons a game ended
+const (
+	EndNoMoves    = "no_moves"
+	EndFortyMoves = "forty_move_rule"
+	EndRepetition = "threefold_repetition"
+	EndResigned   = "resigned"
+)
+
+// NewBoard sets up the opening position: Black on the dark squares of rows
+// 0-2 moving down, White on rows 5-7 moving up.
+func NewBoard() [][]int {
+	board := make([][]int, BoardSize)
+	for r := range board {
+		board[r] = make([]int, BoardSize)
+		for c := range board[r] {
+			if !IsDark(r, c) {
+				continue
+			}
+			switch {
+			case r < 3:
+				board[r][c] = BlackMan
+			case r > 4:
+				board[r][c] = WhiteMan
+			}
+		}
+	}
+	return board
+}
+
+// IsDark reports whether a square is one of the 32 playable squares
+func IsDark(row, col int) bool {
+	return (row+col)%2 == 1
+}
+
+// SideOf returns which side owns a piece, or 0 for an empty square
+func SideOf(piece int) int {
+	switch piece {
+	case BlackMan, BlackKing:
+		return Black
+	case WhiteMan, WhiteKing:
+		return White
+	}
+	return 0
+}
+
+func isKing(piece int) bool {
+	return piece == BlackKing || piece == WhiteKing
+}
+
+func inBounds(row, col int) bool {
+	return row >= 0 && row < BoardSize && col >= 0 && col < BoardSize
+}
+
+// directions a piece may move in, as row/col steps
+func directions(piece int) [][2]int {
+	switch piece {
+	case BlackMan:
+		return [][2]int{{1, -1}, {1, 1}}
+	case WhiteMan:
+		return [][2]int{{-1, -1}, {-1, 1}}
+	}
+	return [][2]int{{1, -1}, {1, 1}, {-1, -1}, {-1, 1}}
+}
+
+// promotes reports whether piece becomes a king on row
+func promotes(piece, row int) bool {
+	return (piece == BlackMan && row == BoardSize-1) || (piece == WhiteMan && row == 0)
+}
+
+// LegalMoves lists every legal move for side. Captures are compulsory, and
+// a capturing move must carry on jumping until no jump is left (or a man is
+// crowned, which ends the move).
+func LegalMoves(board [][]int, side int) []models.CheckersMove {
+	var captures, steps []models.CheckersMove
+
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			piece := board[r][c]
+			if SideOf(piece) != side {
+				continue
+			}
+
+			from := models.CheckersSquare{Row: r, Col: c}
+			captures = append(captures, jumpSequences(board, piece, []models.CheckersSquare{from}, nil)...)
+
+			for _, d := range directions(piece) {
+				to := models.CheckersSquare{Row: r + d[0], Col: c + d[1]}
+				if inBounds(to.Row, to.Col) && board[to.Row][to.Col] == Empty {
+					steps = append(steps, newMove([]models.CheckersSquare{from, to}, nil))
+				}
+			}
+		}
+	}
+
+	if len(captures) > 0 {
+		return captures
+	}
+	return steps
+}
+
+// jumpSequences extends a capture path from its last square. Captured
+// pieces stay on the board until the move ends, so none can be jumped twice.
+func jumpSequences(board [][]int, piece int, path []models.CheckersSquare, captured []models.CheckersSquare) []models.CheckersMove {
+	at := path[len(path)-1]
+	var moves []models.CheckersMove
+
+	for _, d := range directions(piece) {
+		over := models.CheckersSquare{Row: at.Row + d[0], Col: at.Col + d[1]}
+		to := models.CheckersSquare{Row: at.Row + 2*d[0], Col: at.Col + 2*d[1]}
+		if !inBounds(to.Row, to.Col) || board[to.Row][to.Col] != Empty && to != path[0] {
+			continue
+		}
+		if SideOf(board[over.Row][over.Col]) == 0 || SideOf(board[over.Row][over.Col]) == SideOf(piece) {
+			continue
+		}
+		if containsSquare(captured, over) {
+			continue
+		}
+
+		nextPath := append(append([]models.CheckersSquare{}, path...), to)
+		nextCaptured := append(append([]models.CheckersSquare{}, captured...), over)
+
+		// Being crowned ends the move
+		if promotes(piece, to.Row) {
+			moves = append(moves, newMove(nextPath, nextCaptured))
+			continue
+		}
+
+		more := jumpSequences(board, piece, nextPath, nextCaptured)
+		if len(more) == 0 {
+			moves = append(moves, newMove(nextPath, nextCaptured))
+		} else {
+			moves = append(moves, more...)
+		}
+	}
+
+	return moves
+}
+
+func newMove(path, captured []models.CheckersSquare) models.CheckersMove {
+	if captured == nil {
+		captured = []models.CheckersSquare{}
+	}
+	return models.CheckersMove{
+		Path:     path,
+		Captured: captured,
+		Notation: Notation(path, len(captured) > 0),
+	}
+}
+
+func containsSquare(squares []models.CheckersSquare, s models.CheckersSquare) bool {
+	for _, sq := range squares {
+		if sq == s {
+			return true
+		}
+	}
+	return false
+}
+
+// FindMove returns the legal move for side that follows path exactly
+func FindMove(board [][]int, side int, path []models.CheckersSquare) (models.CheckersMove, error) {
+	if len(path) < 2 {
+		return models.CheckersMove{}, errors.New("a move needs at least a start and an end square")
+	}
+
+	legal := LegalMoves(board, side)
+	for _, m := range legal {
+		if samePath(m.Path, path) {
+			return m, nil
+		}
+	}
+
+	// Explain the most common mistakes
+	if len(legal) > 0 && len(legal[0].Captured) > 0 {
+		for _, m := range legal {
+			if len(m.Path) > len(path) && samePath(m.Path[:len(path)], path) {
+				return models.CheckersMove{}, errors.New("jump sequence is not finished: more captures are available")
+			}
+		}
+		return models.CheckersMove{}, errors.New("a capture is available and must be taken")
+	}
+	return models.CheckersMove{}, errors.New("illegal move")
+}
+
+func samePath(a, b []models.CheckersSquare) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ApplyMove plays a legal move on board and reports whether it was quiet
+// (a king moving without capturing) and whether a man was crowned
+func ApplyMove(board [][]int, move models.CheckersMove) (quiet, crowned bool) {
+	from := move.Path[0]
+	to := move.Path[len(move.Path)-1]
+	piece := board[from.Row][from.Col]
+
+	board[from.Row][from.Col] = Empty
+	for _, sq := range move.Captured {
+		board[sq.Row][sq.Col] = Empty
+	}
+
+	quiet = isKing(piece) && len(move.Captured) == 0
+	if promotes(piece, to.Row) {
+		piece += 2 // man -> king of the same colour
+		crowned = true
+	}
+	board[to.Row][to.Col] = piece
+
+	return quiet, crowned
+}
+
+// PositionKey identifies a position for repetition: the board plus the side to move
+func PositionKey(board [][]int, toMove int) string {
+	var sb strings.Builder
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			if IsDark(r, c) {
+				sb.WriteByte(byte('0' + board[r][c]))
+			}
+		}
+	}
+	sb.WriteByte(byte('0' + toMove))
+	return sb.String()
+}
+
+// SquareNumber converts a dark square to standard 1-32 numbering, counted
+// from Black's back row
+func SquareNumber(s models.CheckersSquare) int {
+	return s.Row*4 + s.Col/2 + 1
+}
+
+// Notation writes a move the standard way, e.g. 9-14 or 22x15x6
+func Notation(path []models.CheckersSquare, capture bool) string {
+	sep := "-"
+	if capture {
+		sep = "x"
+	}
+	parts := make([]string, len(path))
+	for i, s := range path {
+		parts[i] = fmt.Sprint(SquareNumber(s))
+	}
+	return strings.Join(parts, sep)
+}
+
+// JSON helpers
+
+func BoardToJSON(board [][]int) (string, error) {
+	data, err := json.Marshal(board)
+	return string(data), err
+}
+
+func BoardFromJSON(data string) ([][]int, error) {
+	var board [][]int
+	err := json.Unmarshal([]byte(data), &board)
+	return board, err
+}
+
+func SquaresToJSON(squares []models.CheckersSquare) (string, error) {
+	data, err := json.Marshal(squares)
+	return string(data), err
+}
+
+func SquaresFromJSON(data string) ([]models.CheckersSquare, error) {
+	var squares []models.CheckersSquare
+	if data == "" || data == "[]" {
+		return []models.CheckersSquare{}, nil
+	}
+	err := json.Unmarshal([]byte(data), &squares)
+	return squares, err
+}
@@ -0,0 +1,274 @@
+package chess
+
+var (
+	knightSteps   = [][2]int{{1, 2}, {2, 1}, {2, -1}, {1, -2}, {-1, -2}, {-2, -1}, {-2, 1}, {-1, 2}}
+	kingSteps     = [][2]int{{1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}, {0, -1}, {1, -1}}
+	diagonalSteps = [][2]int{{1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+	straightSteps = [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+)
+
+// castlingMask clears the rights that a move touching a square removes
+var castlingMask = map[int]int{
+	0:  WhiteQueenside,
+	4:  WhiteKingside | WhiteQueenside,
+	7:  WhiteKingside,
+	56: BlackQueenside,
+	60: BlackKingside | BlackQueenside,
+	63: BlackKingside,
+}
+
+// offset returns the square dr ranks and df files from sq, or -1 off the board
+func offset(sq, dr, df int) int {
+	r, f := sq/8+dr, sq%8+df
+	if r < 0 || r > 7 || f < 0 || f > 7 {
+		return -1
+	}
+	return r*8 + f
+}
+
+func (p Position) kingSquare(color int) int {
+	for sq, pc := range p.Board {
+		if pc == King*color {
+			return sq
+		}
+	}
+	return -1
+}
+
+// attacked reports whether any piece of color attacks sq
+func (p Position) attacked(sq, color int) bool {
+	for _, df := range []int{-1, 1} {
+		if from := offset(sq, -color, df); from >= 0 && p.Board[from] == Pawn*color {
+			return true
+		}
+	}
+	for _, s := range knightSteps {
+		if from := offset(sq, s[0], s[1]); from >= 0 && p.Board[from] == Knight*color {
+			return true
+		}
+	}
+	for _, s := range kingSteps {
+		if from := offset(sq, s[0], s[1]); from >= 0 && p.Board[from] == King*color {
+			return true
+		}
+	}
+	if p.slidingAttack(sq, color, diagonalSteps, Bishop) || p.slidingAttack(sq, color, straightSteps, Rook) {
+		return true
+	}
+	return false
+}
+
+// slidingAttack looks along each step for the first piece and checks
+// whether it is a slider (or queen) of color
+func (p Position) slidingAttack(sq, color int, steps [][2]int, slider int) bool {
+	for _, s := range steps {
+		for to := offset(sq, s[0], s[1]); to >= 0; to = offset(to, s[0], s[1]) {
+			pc := p.Board[to]
+			if pc == Empty {
+				continue
+			}
+			if pc == slider*color || pc == Queen*color {
+				return true
+			}
+			break
+		}
+	}
+	return false
+}
+
+// InCheck reports whether the side to move is in check
+func (p Position) InCheck() bool {
+	return p.attacked(p.kingSquare(p.Turn), -p.Turn)
+}
+
+// LegalMoves lists every legal move for the side to move
+func (p Position) LegalMoves() []Move {
+	var legal []Move
+	for _, m := range p.pseudoMoves() {
+		next := p.Play(m)
+		if !next.attacked(next.kingSquare(p.Turn), next.Turn) {
+			legal = append(legal, m)
+		}
+	}
+	return legal
+}
+
+// pseudoMoves lists moves that follow the piece rules but may leave the
+// king in check. Castling is only generated when fully legal.
+func (p Position) pseudoMoves() []Move {
+	var moves []Move
+	us := p.Turn
+
+	for sq, pc := range p.Board {
+		if pc*us <= 0 {
+			continue
+		}
+
+		switch abs(pc) {
+		case Pawn:
+			moves = p.pawnMoves(moves, sq)
+		case Knight:
+			moves = p.stepMoves(moves, sq, knightSteps)
+		case Bishop:
+			moves = p.slideMoves(moves, sq, diagonalSteps)
+		case Rook:
+			moves = p.slideMoves(moves, sq, straightSteps)
+		case Queen:
+			moves = p.slideMoves(moves, sq, diagonalSteps)
+			moves = p.slideMoves(moves, sq, straightSteps)
+		case King:
+			moves = p.stepMoves(moves, sq, kingSteps)
+			moves = p.castlingMoves(moves, sq)
+		}
+	}
+	return moves
+}
+
+func (p Position) pawnMoves(moves []Move, sq int) []Move {
+	us := p.Turn
+	startRank, lastRank := 1, 7
+	if us == Black {
+		startRank, lastRank = 6, 0
+	}
+
+	add := func(to int) {
+		if to/8 == lastRank {
+			for _, promo := range []int{Queen, Rook, Bishop, Knight} {
+				moves = append(moves, Move{From: sq, To: to, Promotion: promo})
+			}
+			return
+		}
+		moves = append(moves, Move{From: sq, To: to})
+	}
+
+	if one := offset(sq, us, 0); one >= 0 && p.Board[one] == Empty {
+		add(one)
+		if two := offset(one, us, 0); sq/8 == startRank && p.Board[two] == Empty {
+			add(two)
+		}
+	}
+	for _, df := range []int{-1, 1} {
+		to := offset(sq, us, df)
+		if to < 0 {
+			continue
+		}
+		if p.Board[to]*us < 0 || to == p.EnPassant {
+			add(to)
+		}
+	}
+	return moves
+}
+
+func (p Position) stepMoves(moves []Move, sq int, steps [][2]int) []Move {
+	for _, s := range steps {
+		to := offset(sq, s[0], s[1])
+		if to >= 0 && p.Board[to]*p.Turn <= 0 {
+			moves = append(moves, Move{From: sq, To: to})
+		}
+	}
+	return moves
+}
+
+func (p Position) slideMoves(moves []Move, sq int, steps [][2]int) []Move {
+	for _, s := range steps {
+		for to := offset(sq, s[0], s[1]); to >= 0; to = offset(to, s[0], s[1]) {
+			pc := p.Board[to]
+			if pc*p.Turn > 0 {
+				break
+			}
+			moves = append(moves, Move{From: sq, To: to})
+			if pc != Empty {
+				break
+			}
+		}
+	}
+	return moves
+}
+
+// castlingMoves adds castling when the right is held, the squares between
+// king and rook are empty, and the king neither starts in, passes through
+// nor lands in check
+func (p Position) castlingMoves(moves []Move, sq int) []Move {
+	us := p.Turn
+	home, kingside, queenside := 4, WhiteKingside, WhiteQueenside
+	if us == Black {
+		home, kingside, queenside = 60, BlackKingside, BlackQueenside
+	}
+	if sq != home || p.attacked(home, -us) {
+		return moves
+	}
+
+	if p.Castling&kingside != 0 && p.Board[home+1] == Empty && p.Board[home+2] == Empty &&
+		!p.attacked(home+1, -us) && !p.attacked(home+2, -us) {
+		moves = append(moves, Move{From: home, To: home + 2})
+	}
+	if p.Castling&queenside != 0 && p.Board[home-1] == Empty && p.Board[home-2] == Empty && p.Board[home-3] == Empty &&
+		!p.attacked(home-1, -us) && !p.attacked(home-2, -us) {
+		moves = append(moves, Move{From: home, To: home - 2})
+	}
+	return moves
+}
+
+// Play returns the position after m. m must come from LegalMoves.
+func (p Position) Play(m Move) Position {
+	next := p
+	us := p.Turn
+	pc := p.Board[m.From]
+	captured := p.Board[m.To]
+
+	next.Board[m.From] = Empty
+	next.Board[m.To] = pc
+
+	switch abs(pc) {
+	case Pawn:
+		if m.To == p.EnPassant && captured == Empty {
+			next.Board[m.To-8*us] = Empty
+			captured = Pawn * -us
+		}
+		if m.Promotion != Empty {
+			next.Board[m.To] = m.Promotion * us
+		}
+	case King:
+		// Castling moves the rook over the king
+		if m.To-m.From == 2 {
+			next.Board[m.From+3], next.Board[m.From+1] = Empty, Rook*us
+		} else if m.From-m.To == 2 {
+			next.Board[m.From-4], next.Board[m.From-1] = Empty, Rook*us
+		}
+	}
+
+	next.EnPassant = -1
+	if abs(pc) == Pawn && abs(m.To-m.From) == 16 {
+		next.EnPassant = (m.From + m.To) / 2
+	}
+
+	next.Castling &^= castlingMask[m.From] | castlingMask[m.To]
+
+	if abs(pc) == Pawn || captured != Empty {
+		next.Halfmove = 0
+	} else {
+		next.Halfmove++
+	}
+	if us == Black {
+		next.Fullmove++
+	}
+	next.Turn = -us
+
+	return next
+}
+
+// Status reports whether the game is over in this position, why, and the
+// winning colour (0 for a draw). Repetition depends on the game's history
+// and is left to the caller.
+func (p Position) Status() (over bool, reason string, winner int) {
+	if len(p.LegalMoves()) == 0 {
+		if p.InCheck() {
+			return true, EndCheckmate, -p.Turn
+		}
+		return true, EndStalemate, 0
+	}
+	if p.Halfmove >= FiftyMoveLimit {
+		return true, EndFiftyMoves, 0
+	}
+	return false, "", 0
+}
@@ -0,0 +1,72 @@
+package chess
+
+import "testing"
+
+func perft(p Position, depth int) int {
+	if depth == 0 {
+		return 1
+	}
+	moves := p.LegalMoves()
+	if depth == 1 {
+		return len(moves)
+	}
+	nodes := 0
+	for _, m := range moves {
+		nodes += perft(p.Play(m), depth-1)
+	}
+	return nodes
+}
+
+// Reference counts from the Chess Programming Wiki perft results page
+func TestPerft(t *testing.T) {
+	tests := []struct {
+		name  string
+		fen   string
+		nodes []int // Expected count at depth 1, 2, ...
+	}{
+		{"start", StartFEN, []int{20, 400, 8902, 197281}},
+		{"kiwipete", "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", []int{48, 2039, 97862}},
+		{"position 3", "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", []int{14, 191, 2812, 43238}},
+		{"position 4", "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", []int{6, 264, 9467}},
+		{"position 5", "rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8", []int{44, 1486, 62379}},
+		{"position 6", "r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10", []int{46, 2079, 89890}},
+	}
+
+	for _, tt := range tests {
+		p, err := ParseFEN(tt.fen)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		for i, want := range tt.nodes {
+			if got := perft(p, i+1); got != want {
+				t.Errorf("%s depth %d: got %d nodes, want %d", tt.name, i+1, got, want)
+			}
+		}
+	}
+}
+
+func TestParseFENEnPassant(t *testing.T) {
+	tests := []struct {
+		name  string
+		fen   string
+		valid bool
+	}{
+		{"after e4", "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1", true},
+		{"after d5", "4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 1", true},
+		{"king on the pawn's square", "8/8/8/3Pk3/8/8/8/4K3 w - e6 0 1", false},
+		{"own piece on the pawn's square", "4k3/8/8/3PN3/8/8/8/4K3 w - e6 0 1", false},
+		{"no pawn", "4k3/8/8/3P4/8/8/8/4K3 w - e6 0 1", false},
+		{"square occupied", "4k3/8/4n3/3Pp3/8/8/8/4K3 w - e6 0 1", false},
+		{"pawn could not have come from behind", "4k3/4n3/8/3Pp3/8/8/8/4K3 w - e6 0 1", false},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseFEN(tt.fen)
+		if tt.valid && err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("%s: accepted %q", tt.name, tt.fen)
+		}
+	}
+}
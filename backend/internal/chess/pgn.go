@@ -0,0 +1,186 @@
+package chess
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// PGN results
+const (
+	ResultWhite      = "1-0"
+	ResultBlack      = "0-1"
+	ResultDraw       = "1/2-1/2"
+	ResultInProgress = "*"
+)
+
+// PGNTag is one header pair; tags are written in the order given
+type PGNTag struct {
+	Name  string
+	Value string
+}
+
+// PGNGame is a parsed PGN: its tags, start position and moves
+type PGNGame struct {
+	Tags  map[string]string
+	Start Position
+	Moves []Move
+}
+
+var (
+	tagPattern        = regexp.MustCompile(`^\[(\w+)\s+"((?:[^"\\]|\\.)*)"\]$`)
+	moveNumberPattern = regexp.MustCompile(`^\d+\.+`)
+)
+
+// WritePGN exports a game. A non-standard start position is recorded with
+// SetUp and FEN tags.
+func WritePGN(tags []PGNTag, start Position, moves []Move, result string) string {
+	var sb strings.Builder
+	for _, tag := range tags {
+		fmt.Fprintf(&sb, "[%s \"%s\"]\n", tag.Name, escapeTag(tag.Value))
+	}
+	fmt.Fprintf(&sb, "[Result \"%s\"]\n", result)
+	if fen := start.FEN(); fen != StartFEN {
+		sb.WriteString("[SetUp \"1\"]\n")
+		fmt.Fprintf(&sb, "[FEN \"%s\"]\n", fen)
+	}
+	sb.WriteString("\n")
+
+	var tokens []string
+	p := start
+	for i, m := range moves {
+		if p.Turn == White {
+			tokens = append(tokens, fmt.Sprintf("%d.", p.Fullmove))
+		} else if i == 0 {
+			tokens = append(tokens, fmt.Sprintf("%d...", p.Fullmove))
+		}
+		tokens = append(tokens, p.SAN(m))
+		p = p.Play(m)
+	}
+	tokens = append(tokens, result)
+
+	// Wrap movetext at 80 columns
+	line := 0
+	for i, tok := range tokens {
+		if i > 0 {
+			if line+1+len(tok) > 80 {
+				sb.WriteString("\n")
+				line = 0
+			} else {
+				sb.WriteString(" ")
+				line++
+			}
+		}
+		sb.WriteString(tok)
+		line += len(tok)
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+func escapeTag(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, `"`, `\"`)
+}
+
+// ParsePGN reads a single game. Comments, variations and annotation glyphs
+// are skipped; every move must be legal.
+func ParsePGN(text string) (PGNGame, error) {
+	game := PGNGame{Tags: map[string]string{}}
+
+	var movetext strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			match := tagPattern.FindStringSubmatch(line)
+			if match == nil {
+				return game, fmt.Errorf("invalid PGN tag: %s", line)
+			}
+			value := strings.ReplaceAll(match[2], `\"`, `"`)
+			game.Tags[match[1]] = strings.ReplaceAll(value, `\\`, `\`)
+			continue
+		}
+		if strings.HasPrefix(line, "%") {
+			continue // Escape line
+		}
+		movetext.WriteString(line)
+		movetext.WriteString("\n")
+	}
+
+	start := StartFEN
+	if fen, ok := game.Tags["FEN"]; ok {
+		start = fen
+	}
+	p, err := ParseFEN(start)
+	if err != nil {
+		return game, err
+	}
+	game.Start = p
+
+	tokens, err := movetextTokens(movetext.String())
+	if err != nil {
+		return game, err
+	}
+	for _, tok := range tokens {
+		m, err := p.ParseMove(tok)
+		if err != nil {
+			return game, fmt.Errorf("move %d: %v", p.Fullmove, err)
+		}
+		game.Moves = append(game.Moves, m)
+		p = p.Play(m)
+	}
+
+	return game, nil
+}
+
+// movetextTokens strips comments, variations, move numbers, NAGs and the
+// result, leaving the moves
+func movetextTokens(text string) ([]string, error) {
+	var cleaned strings.Builder
+	depth := 0
+	inComment, inLineComment := false, false
+	for _, ch := range text {
+		switch {
+		case inComment:
+			if ch == '}' {
+				inComment = false
+			}
+		case inLineComment:
+			if ch == '\n' {
+				inLineComment = false
+				cleaned.WriteRune(ch)
+			}
+		case ch == '{':
+			inComment = true
+		case ch == ';':
+			inLineComment = true
+		case ch == '(':
+			depth++
+		case ch == ')':
+			if depth == 0 {
+				return nil, errors.New("invalid PGN: unbalanced variation")
+			}
+			depth--
+		case depth == 0:
+			cleaned.WriteRune(ch)
+		}
+	}
+	if inComment || depth != 0 {
+		return nil, errors.New("invalid PGN: unterminated comment or variation")
+	}
+
+	var tokens []string
+	for _, tok := range strings.Fields(cleaned.String()) {
+		tok = moveNumberPattern.ReplaceAllString(tok, "")
+		switch {
+		case tok == "", strings.HasPrefix(tok, "$"):
+			continue
+		case tok == ResultWhite, tok == ResultBlack, tok == ResultDraw, tok == ResultInProgress:
+			continue
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens, nil
+}
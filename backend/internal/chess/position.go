@@ -0,0 +1,334 @@
+package chess
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StartFEN is the standard starting position
+const StartFEN = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+
+// Colours; a piece is its type times its colour, so black pieces are negative
+const (
+	White = 1
+	Black = -1
+)
+
+// Piece types
+const (
+	Empty  = 0
+	Pawn   = 1
+	Knight = 2
+	Bishop = 3
+	Rook   = 4
+	Queen  = 5
+	King   = 6
+)
+
+// Castling rights
+const (
+	WhiteKingside  = 1
+	WhiteQueenside = 2
+	BlackKingside  = 4
+	BlackQueenside = 8
+)
+
+// FiftyMoveLimit is the number of half-moves without a capture or pawn move
+// that ends the game as a draw
+const FiftyMoveLimit = 100
+
+// Reasons a game ended
+const (
+	EndCheckmate  = "checkmate"
+	EndStalemate  = "stalemate"
+	EndFiftyMoves = "fifty_move_rule"
+	EndRepetition = "threefold_repetition"
+	EndResigned   = "resigned"
+)
+
+const pieceLetters = " pnbrqk"
+
+// Position is a full game state. Squares are numbered rank*8+file, so a1 is
+// 0, h1 is 7 and h8 is 63.
+type Position struct {
+	Board     [64]int
+	Turn      int
+	Castling  int
+	EnPassant int // Square a pawn may capture onto en passant, or -1
+	Halfmove  int // Half-moves since the last capture or pawn move
+	Fullmove  int
+}
+
+// Move is a move in coordinate form; Promotion is a piece type or 0
+type Move struct {
+	From      int
+	To        int
+	Promotion int
+}
+
+// UCI writes a move in coordinate notation, e.g. e2e4 or e7e8q
+func (m Move) UCI() string {
+	s := SquareName(m.From) + SquareName(m.To)
+	if m.Promotion != Empty {
+		s += string(pieceLetters[m.Promotion])
+	}
+	return s
+}
+
+// SquareName converts a square number to algebraic form, e.g. 12 -> e2
+func SquareName(sq int) string {
+	return string(rune('a'+sq%8)) + string(rune('1'+sq/8))
+}
+
+func parseSquare(s string) (int, bool) {
+	if len(s) != 2 || s[0] < 'a' || s[0] > 'h' || s[1] < '1' || s[1] > '8' {
+		return 0, false
+	}
+	return int(s[1]-'1')*8 + int(s[0]-'a'), true
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// ParseFEN reads a position in Forsyth-Edwards Notation. The move counters
+// may be left off.
+func ParseFEN(fen string) (Position, error) {
+	var p Position
+	fields := strings.Fields(fen)
+	if len(fields) != 4 && len(fields) != 6 {
+		return p, errors.New("invalid FEN: expected 6 fields")
+	}
+
+	ranks := strings.Split(fields[0], "/")
+	if len(ranks) != 8 {
+		return p, errors.New("invalid FEN: expected 8 ranks")
+	}
+	for i, rank := range ranks {
+		r := 7 - i
+		f := 0
+		for _, ch := range rank {
+			if ch >= '1' && ch <= '8' {
+				f += int(ch - '0')
+				continue
+			}
+			t := strings.IndexRune(pieceLetters, ch|0x20)
+			if t <= 0 || f > 7 {
+				return p, fmt.Errorf("invalid FEN: bad rank %q", rank)
+			}
+			color := White
+			if ch >= 'a' {
+				color = Black
+			}
+			p.Board[r*8+f] = t * color
+			f++
+		}
+		if f != 8 {
+			return p, fmt.Errorf("invalid FEN: rank %q does not have 8 squares", rank)
+		}
+	}
+
+	switch fields[1] {
+	case "w":
+		p.Turn = White
+	case "b":
+		p.Turn = Black
+	default:
+		return p, errors.New("invalid FEN: side to move must be w or b")
+	}
+
+	if fields[2] != "-" {
+		for _, ch := range fields[2] {
+			switch ch {
+			case 'K':
+				p.Castling |= WhiteKingside
+			case 'Q':
+				p.Castling |= WhiteQueenside
+			case 'k':
+				p.Castling |= BlackKingside
+			case 'q':
+				p.Castling |= BlackQueenside
+			default:
+				return p, errors.New("invalid FEN: bad castling rights")
+			}
+		}
+	}
+
+	p.EnPassant = -1
+	if fields[3] != "-" {
+		sq, ok := parseSquare(fields[3])
+		if !ok || (p.Turn == White && sq/8 != 5) || (p.Turn == Black && sq/8 != 2) {
+			return p, errors.New("invalid FEN: bad en passant square")
+		}
+		p.EnPassant = sq
+	}
+
+	p.Fullmove = 1
+	if len(fields) == 6 {
+		var err error
+		if p.Halfmove, err = strconv.Atoi(fields[4]); err != nil || p.Halfmove < 0 {
+			return p, errors.New("invalid FEN: bad half-move clock")
+		}
+		if p.Fullmove, err = strconv.Atoi(fields[5]); err != nil || p.Fullmove < 1 {
+			return p, errors.New("invalid FEN: bad full-move number")
+		}
+	}
+
+	if err := p.validate(); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+// validate rejects positions that cannot arise in a game
+func (p Position) validate() error {
+	kings := map[int]int{}
+	for sq, pc := range p.Board {
+		if pc == King || pc == -King {
+			kings[pc]++
+		}
+		if abs(pc) == Pawn && (sq/8 == 0 || sq/8 == 7) {
+			return errors.New("invalid FEN: pawn on the first or last rank")
+		}
+	}
+	if kings[King] != 1 || kings[-King] != 1 {
+		return errors.New("invalid FEN: each side needs exactly one king")
+	}
+
+	rights := []struct{ right, king, rook int }{
+		{WhiteKingside, King, 7}, {WhiteQueenside, King, 0},
+		{BlackKingside, -King, 63}, {BlackQueenside, -King, 56},
+	}
+	for _, c := range rights {
+		if p.Castling&c.right == 0 {
+			continue
+		}
+		kingSq, rook := 4, Rook
+		if c.king < 0 {
+			kingSq, rook = 60, -Rook
+		}
+		if p.Board[kingSq] != c.king || p.Board[c.rook] != rook {
+			return errors.New("invalid FEN: castling rights do not match the position")
+		}
+	}
+
+	// An en passant square needs the pawn that just moved two squares past it,
+	// with it and the square the pawn came from empty
+	if p.EnPassant >= 0 {
+		pawnSq, fromSq := p.EnPassant-8*p.Turn, p.EnPassant+8*p.Turn
+		if p.Board[p.EnPassant] != Empty || p.Board[fromSq] != Empty || p.Board[pawnSq] != -p.Turn*Pawn {
+			return errors.New("invalid FEN: en passant square does not match the position")
+		}
+	}
+
+	if p.attacked(p.kingSquare(-p.Turn), p.Turn) {
+		return errors.New("invalid FEN: the side not to move is in check")
+	}
+	return nil
+}
+
+// FEN writes the position in Forsyth-Edwards Notation
+func (p Position) FEN() string {
+	var sb strings.Builder
+	sb.WriteString(p.placement())
+	sb.WriteString(" ")
+	sb.WriteString(p.stateFields(p.EnPassant))
+	fmt.Fprintf(&sb, " %d %d", p.Halfmove, p.Fullmove)
+	return sb.String()
+}
+
+func (p Position) placement() string {
+	var sb strings.Builder
+	for r := 7; r >= 0; r-- {
+		empty := 0
+		for f := 0; f < 8; f++ {
+			pc := p.Board[r*8+f]
+			if pc == Empty {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				sb.WriteByte(byte('0' + empty))
+				empty = 0
+			}
+			sb.WriteString(PieceLetter(pc))
+		}
+		if empty > 0 {
+			sb.WriteByte(byte('0' + empty))
+		}
+		if r > 0 {
+			sb.WriteByte('/')
+		}
+	}
+	return sb.String()
+}
+
+// stateFields writes the side to move, castling rights and en passant square
+func (p Position) stateFields(ep int) string {
+	turn := "w"
+	if p.Turn == Black {
+		turn = "b"
+	}
+
+	castling := ""
+	for i, ch := range "KQkq" {
+		if p.Castling&(1<<i) != 0 {
+			castling += string(ch)
+		}
+	}
+	if castling == "" {
+		castling = "-"
+	}
+
+	epName := "-"
+	if ep >= 0 {
+		epName = SquareName(ep)
+	}
+	return turn + " " + castling + " " + epName
+}
+
+// PieceLetter returns the FEN letter for a piece: upper case for White
+func PieceLetter(pc int) string {
+	if pc == Empty {
+		return ""
+	}
+	letter := string(pieceLetters[abs(pc)])
+	if pc > 0 {
+		return strings.ToUpper(letter)
+	}
+	return letter
+}
+
+// RepetitionKey identifies a position for threefold repetition: placement,
+// side to move, castling rights and en passant, with the en passant square
+// only counted when a capture onto it is actually legal
+func (p Position) RepetitionKey() string {
+	ep := -1
+	if p.EnPassant >= 0 {
+		for _, m := range p.LegalMoves() {
+			if m.To == p.EnPassant && abs(p.Board[m.From]) == Pawn {
+				ep = p.EnPassant
+				break
+			}
+		}
+	}
+	return p.placement() + " " + p.stateFields(ep)
+}
+
+// Grid returns the board as FEN letters, rank 8 first and file a first,
+// with "" for empty squares
+func (p Position) Grid() [][]string {
+	grid := make([][]string, 8)
+	for i := range grid {
+		grid[i] = make([]string, 8)
+		for f := 0; f < 8; f++ {
+			grid[i][f] = PieceLetter(p.Board[(7-i)*8+f])
+		}
+	}
+	return grid
+}
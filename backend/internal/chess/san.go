@@ -0,0 +1,111 @@
+package chess
+
+import (
+	"errors"
+	"strings"
+)
+
+// SAN writes a legal move in Standard Algebraic Notation, e.g. Nbd7, exd6,
+// e8=Q+ or O-O-O#
+func (p Position) SAN(m Move) string {
+	pc := abs(p.Board[m.From])
+	var sb strings.Builder
+
+	switch {
+	case pc == King && m.To-m.From == 2:
+		sb.WriteString("O-O")
+	case pc == King && m.From-m.To == 2:
+		sb.WriteString("O-O-O")
+	case pc == Pawn:
+		if m.From%8 != m.To%8 {
+			sb.WriteByte(byte('a' + m.From%8))
+			sb.WriteByte('x')
+		}
+		sb.WriteString(SquareName(m.To))
+		if m.Promotion != Empty {
+			sb.WriteByte('=')
+			sb.WriteString(strings.ToUpper(string(pieceLetters[m.Promotion])))
+		}
+	default:
+		sb.WriteString(strings.ToUpper(string(pieceLetters[pc])))
+		sb.WriteString(p.disambiguation(m))
+		if p.Board[m.To] != Empty {
+			sb.WriteByte('x')
+		}
+		sb.WriteString(SquareName(m.To))
+	}
+
+	next := p.Play(m)
+	if next.InCheck() {
+		if len(next.LegalMoves()) == 0 {
+			sb.WriteByte('#')
+		} else {
+			sb.WriteByte('+')
+		}
+	}
+	return sb.String()
+}
+
+// disambiguation returns the file, rank or square needed to tell m apart
+// from another piece of the same kind that can reach the same square
+func (p Position) disambiguation(m Move) string {
+	sameFile, sameRank, others := false, false, false
+	for _, o := range p.LegalMoves() {
+		if o.To != m.To || o.From == m.From || p.Board[o.From] != p.Board[m.From] {
+			continue
+		}
+		others = true
+		if o.From%8 == m.From%8 {
+			sameFile = true
+		}
+		if o.From/8 == m.From/8 {
+			sameRank = true
+		}
+	}
+
+	name := SquareName(m.From)
+	switch {
+	case !others:
+		return ""
+	case !sameFile:
+		return name[:1]
+	case !sameRank:
+		return name[1:]
+	default:
+		return name
+	}
+}
+
+// ParseMove finds the legal move written as s, in either coordinate
+// notation (e2e4, e7e8q) or SAN (e4, Nf3, exd5, e8=Q, O-O)
+func (p Position) ParseMove(s string) (Move, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Move{}, errors.New("move is required")
+	}
+
+	legal := p.LegalMoves()
+	lower := strings.ToLower(s)
+	for _, m := range legal {
+		if m.UCI() == lower {
+			return m, nil
+		}
+	}
+
+	want := normalizeSAN(s)
+	for _, m := range legal {
+		if normalizeSAN(p.SAN(m)) == want {
+			return m, nil
+		}
+	}
+	return Move{}, errors.New("illegal move: " + s)
+}
+
+// normalizeSAN drops check marks, annotations and the optional = before a
+// promotion piece, and accepts castling written with zeros
+func normalizeSAN(s string) string {
+	s = strings.TrimRight(s, "+#!?")
+	s = strings.ReplaceAll(s, "0", "O")
+	s = strings.ReplaceAll(s, "=", "")
+	return s
+}
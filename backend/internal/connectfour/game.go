@@ -0,0 +1,172 @@
+package connectfour
+
+import (
+	"encoding/json"
+	"errors"
+
+	"altech/internal/models"
+)
+
+const (
+	Empty   = 0
+	Player1 = 1
+	Player2 = 2
+
+	ConnectLength = 4
+
+	DefaultBoardSize = "7x6"
+)
+
+// Move actions
+const (
+	ActionDrop = "drop"
+	ActionPop  = "pop" // PopOut only: remove your own disc from the bottom row
+)
+
+// ParseBoardSize validates and returns cols, rows for a "CxR" board size.
+func ParseBoardSize(size string) (int, int, error) {
+	switch size {
+	case "7x6":
+		return 7, 6, nil
+	case "8x7":
+		return 8, 7, nil
+	default:
+		return 0, 0, errors.New("invalid board size: must be 7x6 or 8x7")
+	}
+}
+
+// NewBoard creates an empty board. Row 0 is the top; discs fall towards the
+// last row.
+func NewBoard(cols, rows int) [][]int {
+	board := make([][]int, rows)
+	for r := range board {
+		board[r] = make([]int, cols)
+	}
+	return board
+}
+
+// Drop lets a disc fall down col and returns the row it lands in
+func Drop(board [][]int, col, piece int) (int, error) {
+	if col < 0 || col >= len(board[0]) {
+		return 0, errors.New("column out of bounds")
+	}
+	for r := len(board) - 1; r >= 0; r-- {
+		if board[r][col] == Empty {
+			board[r][col] = piece
+			return r, nil
+		}
+	}
+	return 0, errors.New("column is full")
+}
+
+// Pop removes piece's disc from the bottom of col and lets the column fall
+func Pop(board [][]int, col, piece int) error {
+	if col < 0 || col >= len(board[0]) {
+		return errors.New("column out of bounds")
+	}
+	bottom := len(board) - 1
+	if board[bottom][col] != piece {
+		return errors.New("can only pop your own disc from the bottom row")
+	}
+	for r := bottom; r > 0; r-- {
+		board[r][col] = board[r-1][col]
+	}
+	board[0][col] = Empty
+	return nil
+}
+
+// LegalDrops lists the columns that still have space
+func LegalDrops(board [][]int) []int {
+	cols := []int{}
+	for c := range board[0] {
+		if board[0][c] == Empty {
+			cols = append(cols, c)
+		}
+	}
+	return cols
+}
+
+// LegalPops lists the columns where piece owns the bottom disc
+func LegalPops(board [][]int, piece int) []int {
+	cols := []int{}
+	bottom := len(board) - 1
+	for c := range board[bottom] {
+		if board[bottom][c] == piece {
+			cols = append(cols, c)
+		}
+	}
+	return cols
+}
+
+// WinningCells returns every cell that is part of a line of four or more for
+// piece, or nil if piece has no line
+func WinningCells(board [][]int, piece int) []models.ConnectFourCell {
+	rows, cols := len(board), len(board[0])
+	directions := [][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}} // row, column, both diagonals
+
+	onLine := make(map[models.ConnectFourCell]bool)
+	var cells []models.ConnectFourCell
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			for _, d := range directions {
+				line := make([]models.ConnectFourCell, 0, ConnectLength)
+				for i := 0; i < ConnectLength; i++ {
+					rr, cc := r+d[0]*i, c+d[1]*i
+					if rr < 0 || rr >= rows || cc < 0 || cc >= cols || board[rr][cc] != piece {
+						break
+					}
+					line = append(line, models.ConnectFourCell{Row: rr, Col: cc})
+				}
+				if len(line) < ConnectLength {
+					continue
+				}
+				for _, cell := range line {
+					if !onLine[cell] {
+						onLine[cell] = true
+						cells = append(cells, cell)
+					}
+				}
+			}
+		}
+	}
+	return cells
+}
+
+// CheckResult reports whether the game is over after mover's move and who
+// won (0 for a draw). A PopOut pop can complete lines for both players at
+// once; the player who popped wins.
+func CheckResult(board [][]int, mover int, popOut bool) (gameOver bool, winner int) {
+	other := Player1
+	if mover == Player1 {
+		other = Player2
+	}
+
+	if WinningCells(board, mover) != nil {
+		return true, mover
+	}
+	if WinningCells(board, other) != nil {
+		return true, other
+	}
+
+	// The next player must have something to do
+	if len(LegalDrops(board)) > 0 {
+		return false, 0
+	}
+	if popOut && len(LegalPops(board, other)) > 0 {
+		return false, 0
+	}
+	return true, 0
+}
+
+// JSON helpers
+
+func BoardToJSON(board [][]int) (string, error) {
+	data, err := json.Marshal(board)
+	return string(data), err
+}
+
+func BoardFromJSON(data string) ([][]int, error) {
+	var board [][]int
+	err := json.Unmarshal([]byte(data), &board)
+	return board, err
+}
@@ -7,11 +7,11 @@ import (
 	"altech/internal/models"
 )
 
-func CreateBattleshipGame(db *sql.DB, player1ID, player2ID int64) (*models.BattleshipGame, error) {
+func CreateBattleshipGame(db *sql.DB, player1ID, player2ID int64, boardSize int, fleet string, salvo, noTouch bool) (*models.BattleshipGame, error) {
 	result, err := db.Exec(`
-		INSERT INTO battleship_games (player1_id, player2_id, current_turn, status)
-		VALUES (?, ?, ?, 'setup')
-	`, player1ID, player2ID, player1ID)
+		INSERT INTO battleship_games (player1_id, player2_id, current_turn, status, board_size, fleet, salvo, no_touch)
+		VALUES (?, ?, ?, 'setup', ?, ?, ?, ?)
+	`, player1ID, player2ID, player1ID, boardSize, fleet, salvo, noTouch)
 	if err != nil {
 		return nil, err
 	}
@@ -23,8 +23,8 @@ func CreateBattleshipGame(db *sql.DB, player1ID, player2ID int64) (*models.Battl
 
 	// Create boards for both players
 	_, err = db.Exec(`
-		INSERT INTO battleship_boards (game_id, user_id, ships, shots, ships_ready)
-		VALUES (?, ?, '[]', '[]', 0), (?, ?, '[]', '[]', 0)
+		INSERT INTO battleship_boards (game_id, user_id, ships, ships_ready)
+		VALUES (?, ?, '[]', 0), (?, ?, '[]', 0)
 	`, id, player1ID, id, player2ID)
 	if err != nil {
 		return nil, err
@@ -38,11 +38,12 @@ func GetBattleshipGame(db *sql.DB, gameID int64) (*models.BattleshipGame, error)
 	var winnerID sql.NullInt64
 
 	err := db.QueryRow(`
-		SELECT id, player1_id, player2_id, current_turn, status, winner_id, created_at, updated_at
+		SELECT id, player1_id, player2_id, current_turn, status, winner_id, board_size, fleet, salvo, no_touch, move_count, created_at, updated_at
 		FROM battleship_games WHERE id = ?
 	`, gameID).Scan(
 		&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn,
-		&game.Status, &winnerID, &game.CreatedAt, &game.UpdatedAt,
+		&game.Status, &winnerID, &game.BoardSize, &game.Fleet, &game.Salvo, &game.NoTouch, &game.MoveCount,
+		&game.CreatedAt, &game.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
 		return nil, ErrGameNotFound
@@ -64,7 +65,7 @@ func GetBattleshipGame(db *sql.DB, gameID int64) (*models.BattleshipGame, error)
 
 func GetBattleshipGamesForUser(db *sql.DB, userID int64) ([]models.BattleshipGame, error) {
 	rows, err := db.Query(`
-		SELECT g.id, g.player1_id, g.player2_id, g.current_turn, g.status, g.winner_id, g.created_at, g.updated_at
+		SELECT g.id, g.player1_id, g.player2_id, g.current_turn, g.status, g.winner_id, g.board_size, g.fleet, g.salvo, g.no_touch, g.move_count, g.created_at, g.updated_at
 		FROM battleship_games g
 		WHERE g.player1_id = ? OR g.player2_id = ?
 		ORDER BY g.updated_at DESC
@@ -81,7 +82,8 @@ func GetBattleshipGamesForUser(db *sql.DB, userID int64) ([]models.BattleshipGam
 
 		err := rows.Scan(
 			&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn,
-			&game.Status, &winnerID, &game.CreatedAt, &game.UpdatedAt,
+			&game.Status, &winnerID, &game.BoardSize, &game.Fleet, &game.Salvo, &game.NoTouch, &game.MoveCount,
+			&game.CreatedAt, &game.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -100,23 +102,28 @@ func GetBattleshipGamesForUser(db *sql.DB, userID int64) ([]models.BattleshipGam
 	return games, nil
 }
 
+// UpdateBattleshipGame saves a game without firing, as on setup or resigning.
+// It fails with ErrGameChanged if a turn was fired since the game was loaded.
 func UpdateBattleshipGame(db *sql.DB, game *models.BattleshipGame) error {
-	_, err := db.Exec(`
+	return updateBattleshipGame(db, game, 0)
+}
+
+func updateBattleshipGame(db execer, game *models.BattleshipGame, fired int) error {
+	return checkGameUpdated(db.Exec(`
 		UPDATE battleship_games
-		SET current_turn = ?, status = ?, winner_id = ?, updated_at = ?
-		WHERE id = ?
-	`, game.CurrentTurn, game.Status, game.WinnerID, time.Now(), game.ID)
-	return err
+		SET current_turn = ?, status = ?, winner_id = ?, move_count = move_count + ?, updated_at = ?
+		WHERE id = ? AND move_count = ?
+	`, game.CurrentTurn, game.Status, game.WinnerID, fired, time.Now(), game.ID, game.MoveCount))
 }
 
 func GetBattleshipBoard(db *sql.DB, gameID, userID int64) (*models.BattleshipBoard, error) {
 	board := &models.BattleshipBoard{}
 	err := db.QueryRow(`
-		SELECT id, game_id, user_id, ships, shots, ships_ready
+		SELECT id, game_id, user_id, ships, ships_ready
 		FROM battleship_boards
 		WHERE game_id = ? AND user_id = ?
 	`, gameID, userID).Scan(
-		&board.ID, &board.GameID, &board.UserID, &board.Ships, &board.Shots, &board.ShipsReady,
+		&board.ID, &board.GameID, &board.UserID, &board.Ships, &board.ShipsReady,
 	)
 	if err == sql.ErrNoRows {
 		return nil, ErrNotInGame
@@ -127,9 +134,9 @@ func GetBattleshipBoard(db *sql.DB, gameID, userID int64) (*models.BattleshipBoa
 func UpdateBattleshipBoard(db *sql.DB, board *models.BattleshipBoard) error {
 	_, err := db.Exec(`
 		UPDATE battleship_boards
-		SET ships = ?, shots = ?, ships_ready = ?
+		SET ships = ?, ships_ready = ?
 		WHERE game_id = ? AND user_id = ?
-	`, board.Ships, board.Shots, board.ShipsReady, board.GameID, board.UserID)
+	`, board.Ships, board.ShipsReady, board.GameID, board.UserID)
 	return err
 }
 
@@ -141,3 +148,116 @@ func AreBothPlayersReady(db *sql.DB, gameID int64) (bool, error) {
 	`, gameID).Scan(&count)
 	return count == 2, err
 }
+
+// FireBattleshipShots records a turn's shots and saves the game in one
+// transaction, so a salvo is either logged in full or not at all. It fails
+// with ErrGameChanged if another turn was fired since the game was loaded.
+func FireBattleshipShots(db *sql.DB, game *models.BattleshipGame, shots []models.Shot) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := updateBattleshipGame(tx, game, 1); err != nil {
+		return err
+	}
+
+	for _, shot := range shots {
+		result := "miss"
+		if shot.Hit {
+			result = "hit"
+		}
+		var sunkShip sql.NullString
+		if shot.SunkShip != "" {
+			sunkShip = sql.NullString{String: shot.SunkShip, Valid: true}
+		}
+
+		_, err := tx.Exec(`
+			INSERT INTO battleship_shots (game_id, shooter_id, row, col, result, sunk_ship)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, shot.GameID, shot.ShooterID, shot.Row, shot.Col, result, sunkShip)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	game.MoveCount++
+	return nil
+}
+
+// GetBattleshipShots returns the shots one player has fired, oldest first
+func GetBattleshipShots(db *sql.DB, gameID, shooterID int64) ([]models.Shot, error) {
+	return queryBattleshipShots(db, `
+		SELECT id, game_id, shooter_id, row, col, result, sunk_ship, created_at
+		FROM battleship_shots
+		WHERE game_id = ? AND shooter_id = ?
+		ORDER BY id ASC
+	`, gameID, shooterID)
+}
+
+// GetBattleshipShotLog returns every shot in a game, oldest first
+func GetBattleshipShotLog(db *sql.DB, gameID int64) ([]models.Shot, error) {
+	return queryBattleshipShots(db, `
+		SELECT id, game_id, shooter_id, row, col, result, sunk_ship, created_at
+		FROM battleship_shots
+		WHERE game_id = ?
+		ORDER BY id ASC
+	`, gameID)
+}
+
+func queryBattleshipShots(db *sql.DB, query string, args ...interface{}) ([]models.Shot, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var shots []models.Shot
+	for rows.Next() {
+		var shot models.Shot
+		var result string
+		var sunkShip sql.NullString
+
+		err := rows.Scan(&shot.ID, &shot.GameID, &shot.ShooterID, &shot.Row, &shot.Col, &result, &sunkShip, &shot.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		shot.Hit = result == "hit"
+		shot.SunkShip = sunkShip.String
+		shots = append(shots, shot)
+	}
+
+	return shots, nil
+}
+
+// GetBattleshipPlacementsForUser returns the ship JSON of every fleet the user
+// has placed on a board of the given size
+func GetBattleshipPlacementsForUser(db *sql.DB, userID int64, boardSize int) ([]string, error) {
+	rows, err := db.Query(`
+		SELECT b.ships
+		FROM battleship_boards b
+		JOIN battleship_games g ON g.id = b.game_id
+		WHERE b.user_id = ? AND b.ships_ready = 1 AND g.board_size = ?
+	`, userID, boardSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var placements []string
+	for rows.Next() {
+		var ships string
+		if err := rows.Scan(&ships); err != nil {
+			return nil, err
+		}
+		placements = append(placements, ships)
+	}
+
+	return placements, nil
+}
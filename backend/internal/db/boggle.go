@@ -0,0 +1,179 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"altech/internal/models"
+)
+
+var ErrBoggleWordExists = errors.New("word already submitted")
+
+const boggleColumns = `id, player1_id, player2_id, status, winner_id, size, seed, grid, duration_seconds,
+	player1_score, player2_score, player1_started_at, player2_started_at, solution, created_at, updated_at`
+
+func CreateBoggleGame(db *sql.DB, player1ID, player2ID int64, size int, seed int64, gridJSON string, durationSeconds int) (*models.BoggleGame, error) {
+	result, err := db.Exec(`
+		INSERT INTO boggle_games (player1_id, player2_id, status, size, seed, grid, duration_seconds)
+		VALUES (?, ?, 'active', ?, ?, ?, ?)
+	`, player1ID, player2ID, size, seed, gridJSON, durationSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return GetBoggleGame(db, id)
+}
+
+func scanBoggleGame(db *sql.DB, row interface{ Scan(...any) error }) (*models.BoggleGame, error) {
+	game := &models.BoggleGame{}
+	var winnerID sql.NullInt64
+	var started1, started2 sql.NullTime
+
+	err := row.Scan(
+		&game.ID, &game.Player1ID, &game.Player2ID, &game.Status, &winnerID,
+		&game.Size, &game.Seed, &game.Grid, &game.DurationSeconds,
+		&game.Player1Score, &game.Player2Score, &started1, &started2, &game.Solution,
+		&game.CreatedAt, &game.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if winnerID.Valid {
+		game.WinnerID = &winnerID.Int64
+	}
+	if started1.Valid {
+		game.Player1StartedAt = &started1.Time
+	}
+	if started2.Valid {
+		game.Player2StartedAt = &started2.Time
+	}
+
+	// Load player info
+	game.Player1, _ = GetUserByID(db, game.Player1ID)
+	game.Player2, _ = GetUserByID(db, game.Player2ID)
+
+	return game, nil
+}
+
+func GetBoggleGame(db *sql.DB, gameID int64) (*models.BoggleGame, error) {
+	game, err := scanBoggleGame(db, db.QueryRow(`
+		SELECT `+boggleColumns+`
+		FROM boggle_games WHERE id = ?
+	`, gameID))
+	if err == sql.ErrNoRows {
+		return nil, ErrGameNotFound
+	}
+	return game, err
+}
+
+func GetBoggleGamesForUser(db *sql.DB, userID int64) ([]models.BoggleGame, error) {
+	rows, err := db.Query(`
+		SELECT `+boggleColumns+`
+		FROM boggle_games
+		WHERE player1_id = ? OR player2_id = ?
+		ORDER BY updated_at DESC
+	`, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var games []models.BoggleGame
+	for rows.Next() {
+		game, err := scanBoggleGame(db, rows)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, *game)
+	}
+
+	return games, nil
+}
+
+// StartBoggleWindow starts a player's timer; a window that has already
+// started is left alone
+func StartBoggleWindow(db *sql.DB, game *models.BoggleGame, userID int64) error {
+	column := "player1_started_at"
+	if userID == game.Player2ID {
+		column = "player2_started_at"
+	}
+
+	now := time.Now()
+	_, err := db.Exec(`
+		UPDATE boggle_games
+		SET `+column+` = ?, updated_at = ?
+		WHERE id = ? AND `+column+` IS NULL
+	`, now, now, game.ID)
+	return err
+}
+
+func UpdateBoggleGame(db *sql.DB, game *models.BoggleGame) error {
+	_, err := db.Exec(`
+		UPDATE boggle_games
+		SET status = ?, winner_id = ?, player1_score = ?, player2_score = ?, solution = ?, updated_at = ?
+		WHERE id = ?
+	`, game.Status, game.WinnerID, game.Player1Score, game.Player2Score, game.Solution, time.Now(), game.ID)
+	return err
+}
+
+func CreateBoggleWord(db *sql.DB, word *models.BoggleWord) error {
+	path, err := json.Marshal(word.Path)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.Exec(`
+		INSERT OR IGNORE INTO boggle_words (game_id, user_id, word, path, points)
+		VALUES (?, ?, ?, ?, ?)
+	`, word.GameID, word.UserID, word.Word, string(path), word.Points)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrBoggleWordExists
+	}
+
+	word.ID, err = result.LastInsertId()
+	return err
+}
+
+func GetBoggleWords(db *sql.DB, gameID int64) ([]models.BoggleWord, error) {
+	rows, err := db.Query(`
+		SELECT id, game_id, user_id, word, path, points, created_at
+		FROM boggle_words
+		WHERE game_id = ?
+		ORDER BY created_at ASC, id ASC
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var words []models.BoggleWord
+	for rows.Next() {
+		var w models.BoggleWord
+		var path string
+		if err := rows.Scan(&w.ID, &w.GameID, &w.UserID, &w.Word, &path, &w.Points, &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(path), &w.Path); err != nil {
+			return nil, err
+		}
+		words = append(words, w)
+	}
+
+	return words, nil
+}
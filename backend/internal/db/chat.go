@@ -0,0 +1,196 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"altech/internal/models"
+)
+
+func CreateChatMessage(db *sql.DB, msg *models.ChatMessage) error {
+	result, err := db.Exec(`
+		INSERT INTO chat_messages (game_type, game_id, sender_id, recipient_id, body)
+		VALUES (?, ?, ?, ?, ?)
+	`, msg.GameType, msg.GameID, msg.SenderID, msg.RecipientID, msg.Body)
+	if err != nil {
+		return err
+	}
+
+	msg.ID, err = result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	return db.QueryRow("SELECT created_at FROM chat_messages WHERE id = ?", msg.ID).Scan(&msg.CreatedAt)
+}
+
+// queryChatPage returns one page of the messages matching where, oldest
+// first. With after set it pages forward from that message, otherwise it
+// pages back from before, or from the newest message when before is 0.
+func queryChatPage(db *sql.DB, where string, args []any, before, after int64, limit int) ([]models.ChatMessage, bool, error) {
+	query := `
+		SELECT id, game_type, game_id, sender_id, recipient_id, body, read_at, created_at
+		FROM chat_messages
+		WHERE ` + where
+	order := " ORDER BY id DESC LIMIT ?"
+	switch {
+	case after > 0:
+		query += " AND id > ?"
+		args = append(args, after)
+		order = " ORDER BY id ASC LIMIT ?"
+	case before > 0:
+		query += " AND id < ?"
+		args = append(args, before)
+	}
+	args = append(args, limit+1)
+
+	rows, err := db.Query(query+order, args...)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	var messages []models.ChatMessage
+	for rows.Next() {
+		var msg models.ChatMessage
+		var readAt sql.NullTime
+		err := rows.Scan(&msg.ID, &msg.GameType, &msg.GameID, &msg.SenderID, &msg.RecipientID, &msg.Body, &readAt, &msg.CreatedAt)
+		if err != nil {
+			return nil, false, err
+		}
+		if readAt.Valid {
+			msg.ReadAt = &readAt.Time
+		}
+		messages = append(messages, msg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(messages) > limit
+	if hasMore {
+		messages = messages[:limit]
+	}
+	if after == 0 {
+		for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+			messages[i], messages[j] = messages[j], messages[i]
+		}
+	}
+	return messages, hasMore, nil
+}
+
+func GetGameChatMessages(db *sql.DB, gameType string, gameID, before, after int64, limit int) ([]models.ChatMessage, bool, error) {
+	return queryChatPage(db, "game_type = ? AND game_id = ?", []any{gameType, gameID}, before, after, limit)
+}
+
+func GetDirectChatMessages(db *sql.DB, userID, friendID, before, after int64, limit int) ([]models.ChatMessage, bool, error) {
+	return queryChatPage(db, `game_type = '' AND
+		((sender_id = ? AND recipient_id = ?) OR (sender_id = ? AND recipient_id = ?))`,
+		[]any{userID, friendID, friendID, userID}, before, after, limit)
+}
+
+// GetChatUpdates returns messages a user sent or received after a message,
+// across every thread
+func GetChatUpdates(db *sql.DB, userID, after int64, limit int) ([]models.ChatMessage, bool, error) {
+	return queryChatPage(db, "(sender_id = ? OR recipient_id = ?)", []any{userID, userID}, 0, after, limit)
+}
+
+func markChatRead(db *sql.DB, where string, args ...any) (int, error) {
+	result, err := db.Exec(`
+		UPDATE chat_messages SET read_at = ?
+		WHERE read_at IS NULL AND `+where, append([]any{time.Now()}, args...)...)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
+
+// MarkGameChatRead marks every message a user received in a game as read
+func MarkGameChatRead(db *sql.DB, gameType string, gameID, userID int64) (int, error) {
+	return markChatRead(db, "game_type = ? AND game_id = ? AND recipient_id = ?", gameType, gameID, userID)
+}
+
+// MarkDirectChatRead marks every direct message a user received from a
+// friend as read
+func MarkDirectChatRead(db *sql.DB, userID, friendID int64) (int, error) {
+	return markChatRead(db, "game_type = '' AND sender_id = ? AND recipient_id = ?", friendID, userID)
+}
+
+// GetUnreadChatCounts returns a user's unread message count per game of one
+// type. Games without unread messages are left out.
+func GetUnreadChatCounts(db *sql.DB, gameType string, userID int64) (map[int64]int, error) {
+	rows, err := db.Query(`
+		SELECT game_id, COUNT(*) FROM chat_messages
+		WHERE game_type = ? AND recipient_id = ? AND read_at IS NULL
+		GROUP BY game_id
+	`, gameType, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[int64]int)
+	for rows.Next() {
+		var gameID int64
+		var count int
+		if err := rows.Scan(&gameID, &count); err != nil {
+			return nil, err
+		}
+		counts[gameID] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// GetUnreadChatSummary returns a user's unread message counts for every game
+// thread and every friend
+func GetUnreadChatSummary(db *sql.DB, userID int64) ([]models.ChatGameUnread, []models.ChatDirectUnread, error) {
+	rows, err := db.Query(`
+		SELECT game_type, game_id, sender_id, COUNT(*) FROM chat_messages
+		WHERE recipient_id = ? AND read_at IS NULL
+		GROUP BY game_type, game_id, sender_id
+		ORDER BY MAX(id) DESC
+	`, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	games := []models.ChatGameUnread{}
+	direct := []models.ChatDirectUnread{}
+	for rows.Next() {
+		var gameType string
+		var gameID, senderID int64
+		var count int
+		if err := rows.Scan(&gameType, &gameID, &senderID, &count); err != nil {
+			return nil, nil, err
+		}
+		if gameType == "" {
+			direct = append(direct, models.ChatDirectUnread{UserID: senderID, Count: count})
+		} else {
+			games = append(games, models.ChatGameUnread{GameType: gameType, GameID: gameID, Count: count})
+		}
+	}
+
+	return games, direct, rows.Err()
+}
+
+func GetChatSettings(db *sql.DB, userID int64) (*models.ChatSettings, error) {
+	settings := &models.ChatSettings{FilterProfanity: true}
+	err := db.QueryRow(
+		"SELECT filter_profanity FROM chat_settings WHERE user_id = ?", userID,
+	).Scan(&settings.FilterProfanity)
+	if err == sql.ErrNoRows {
+		return settings, nil
+	}
+	return settings, err
+}
+
+func UpdateChatSettings(db *sql.DB, userID int64, settings *models.ChatSettings) error {
+	_, err := db.Exec(`
+		INSERT INTO chat_settings (user_id, filter_profanity) VALUES (?, ?)
+		ON CONFLICT(user_id) DO UPDATE SET filter_profanity = excluded.filter_profanity
+	`, userID, settings.FilterProfanity)
+	return err
+}
@@ -0,0 +1,188 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"altech/internal/models"
+)
+
+func CreateCheckersGame(db *sql.DB, player1ID, player2ID int64, boardJSON string) (*models.CheckersGame, error) {
+	result, err := db.Exec(`
+		INSERT INTO checkers_games (player1_id, player2_id, current_turn, status, board)
+		VALUES (?, ?, ?, 'active', ?)
+	`, player1ID, player2ID, player1ID, boardJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return GetCheckersGame(db, id)
+}
+
+func GetCheckersGame(db *sql.DB, gameID int64) (*models.CheckersGame, error) {
+	game := &models.CheckersGame{}
+	var winnerID sql.NullInt64
+
+	err := db.QueryRow(`
+		SELECT id, player1_id, player2_id, current_turn, status, winner_id, end_reason, board, quiet_plies, move_count, created_at, updated_at
+		FROM checkers_games WHERE id = ?
+	`, gameID).Scan(
+		&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn,
+		&game.Status, &winnerID, &game.EndReason, &game.Board, &game.QuietPlies, &game.MoveCount,
+		&game.CreatedAt, &game.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrGameNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if winnerID.Valid {
+		game.WinnerID = &winnerID.Int64
+	}
+
+	// Load player info
+	game.Player1, _ = GetUserByID(db, game.Player1ID)
+	game.Player2, _ = GetUserByID(db, game.Player2ID)
+
+	return game, nil
+}
+
+func GetCheckersGamesForUser(db *sql.DB, userID int64) ([]models.CheckersGame, error) {
+	rows, err := db.Query(`
+		SELECT g.id, g.player1_id, g.player2_id, g.current_turn, g.status, g.winner_id, g.end_reason, g.board, g.quiet_plies, g.move_count, g.created_at, g.updated_at
+		FROM checkers_games g
+		WHERE g.player1_id = ? OR g.player2_id = ?
+		ORDER BY g.updated_at DESC
+	`, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var games []models.CheckersGame
+	for rows.Next() {
+		var game models.CheckersGame
+		var winnerID sql.NullInt64
+
+		err := rows.Scan(
+			&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn,
+			&game.Status, &winnerID, &game.EndReason, &game.Board, &game.QuietPlies, &game.MoveCount,
+			&game.CreatedAt, &game.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if winnerID.Valid {
+			game.WinnerID = &winnerID.Int64
+		}
+
+		game.Player1, _ = GetUserByID(db, game.Player1ID)
+		game.Player2, _ = GetUserByID(db, game.Player2ID)
+
+		games = append(games, game)
+	}
+
+	return games, nil
+}
+
+// UpdateCheckersGame saves a game without a move, as on resigning. It
+// fails with ErrGameChanged if a move was played since the game was loaded.
+func UpdateCheckersGame(db *sql.DB, game *models.CheckersGame) error {
+	return updateCheckersGame(db, game, 0)
+}
+
+// PlayCheckersMove records a move, numbered from the game's move count, and
+// saves the game in one transaction. It fails with ErrGameChanged if another
+// move was played since the game was loaded.
+func PlayCheckersMove(db *sql.DB, game *models.CheckersGame, move *models.CheckersMoveRecord) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	move.MoveNumber = game.MoveCount + 1
+	if err := updateCheckersGame(tx, game, 1); err != nil {
+		return err
+	}
+	if err := createCheckersMove(tx, move); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	game.MoveCount++
+	return nil
+}
+
+func updateCheckersGame(db execer, game *models.CheckersGame, played int) error {
+	return checkGameUpdated(db.Exec(`
+		UPDATE checkers_games
+		SET current_turn = ?, status = ?, winner_id = ?, end_reason = ?, board = ?, quiet_plies = ?, move_count = move_count + ?, updated_at = ?
+		WHERE id = ? AND move_count = ?
+	`, game.CurrentTurn, game.Status, game.WinnerID, game.EndReason, game.Board, game.QuietPlies, played, time.Now(), game.ID, game.MoveCount))
+}
+
+func createCheckersMove(db execer, move *models.CheckersMoveRecord) error {
+	path, err := json.Marshal(move.Path)
+	if err != nil {
+		return err
+	}
+	captured, err := json.Marshal(move.Captured)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.Exec(`
+		INSERT INTO checkers_moves (game_id, user_id, move_number, path, captured, notation, crowned, position)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, move.GameID, move.UserID, move.MoveNumber, string(path), string(captured), move.Notation, move.Crowned, move.Position)
+	if err != nil {
+		return err
+	}
+
+	move.ID, err = result.LastInsertId()
+	return err
+}
+
+func GetCheckersMoves(db *sql.DB, gameID int64) ([]models.CheckersMoveRecord, error) {
+	rows, err := db.Query(`
+		SELECT id, game_id, user_id, move_number, path, captured, notation, crowned, position, created_at
+		FROM checkers_moves
+		WHERE game_id = ?
+		ORDER BY move_number ASC
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var moves []models.CheckersMoveRecord
+	for rows.Next() {
+		var m models.CheckersMoveRecord
+		var path, captured string
+		err := rows.Scan(&m.ID, &m.GameID, &m.UserID, &m.MoveNumber, &path, &captured, &m.Notation, &m.Crowned, &m.Position, &m.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(path), &m.Path); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(captured), &m.Captured); err != nil {
+			return nil, err
+		}
+		moves = append(moves, m)
+	}
+
+	return moves, nil
+}
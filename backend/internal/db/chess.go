@@ -0,0 +1,191 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"altech/internal/models"
+)
+
+// CreateChessGame stores a new game along with any moves imported from PGN,
+// which are numbered from 1
+func CreateChessGame(db *sql.DB, player1ID, player2ID, currentTurn int64, startFEN, fen string, moves []models.ChessMove) (*models.ChessGame, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		INSERT INTO chess_games (player1_id, player2_id, current_turn, status, start_fen, fen, move_count)
+		VALUES (?, ?, ?, 'active', ?, ?, ?)
+	`, player1ID, player2ID, currentTurn, startFEN, fen, len(moves))
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range moves {
+		moves[i].GameID = id
+		moves[i].MoveNumber = i + 1
+		if err := createChessMove(tx, &moves[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return GetChessGame(db, id)
+}
+
+func GetChessGame(db *sql.DB, gameID int64) (*models.ChessGame, error) {
+	game := &models.ChessGame{}
+	var winnerID sql.NullInt64
+
+	err := db.QueryRow(`
+		SELECT id, player1_id, player2_id, current_turn, status, winner_id, end_reason, start_fen, fen, move_count, created_at, updated_at
+		FROM chess_games WHERE id = ?
+	`, gameID).Scan(
+		&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn,
+		&game.Status, &winnerID, &game.EndReason, &game.StartFEN, &game.FEN, &game.MoveCount,
+		&game.CreatedAt, &game.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrGameNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if winnerID.Valid {
+		game.WinnerID = &winnerID.Int64
+	}
+
+	// Load player info
+	game.Player1, _ = GetUserByID(db, game.Player1ID)
+	game.Player2, _ = GetUserByID(db, game.Player2ID)
+
+	return game, nil
+}
+
+func GetChessGamesForUser(db *sql.DB, userID int64) ([]models.ChessGame, error) {
+	rows, err := db.Query(`
+		SELECT g.id, g.player1_id, g.player2_id, g.current_turn, g.status, g.winner_id, g.end_reason, g.start_fen, g.fen, g.move_count, g.created_at, g.updated_at
+		FROM chess_games g
+		WHERE g.player1_id = ? OR g.player2_id = ?
+		ORDER BY g.updated_at DESC
+	`, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var games []models.ChessGame
+	for rows.Next() {
+		var game models.ChessGame
+		var winnerID sql.NullInt64
+
+		err := rows.Scan(
+			&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn,
+			&game.Status, &winnerID, &game.EndReason, &game.StartFEN, &game.FEN, &game.MoveCount,
+			&game.CreatedAt, &game.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if winnerID.Valid {
+			game.WinnerID = &winnerID.Int64
+		}
+
+		game.Player1, _ = GetUserByID(db, game.Player1ID)
+		game.Player2, _ = GetUserByID(db, game.Player2ID)
+
+		games = append(games, game)
+	}
+
+	return games, nil
+}
+
+// UpdateChessGame saves a game without a move, as on resigning. It
+// fails with ErrGameChanged if a move was played since the game was loaded.
+func UpdateChessGame(db *sql.DB, game *models.ChessGame) error {
+	return updateChessGame(db, game, 0)
+}
+
+// PlayChessMove records a move, numbered from the game's move count, and
+// saves the game in one transaction. It fails with ErrGameChanged if another
+// move was played since the game was loaded.
+func PlayChessMove(db *sql.DB, game *models.ChessGame, move *models.ChessMove) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	move.MoveNumber = game.MoveCount + 1
+	if err := updateChessGame(tx, game, 1); err != nil {
+		return err
+	}
+	if err := createChessMove(tx, move); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	game.MoveCount++
+	return nil
+}
+
+func updateChessGame(db execer, game *models.ChessGame, played int) error {
+	return checkGameUpdated(db.Exec(`
+		UPDATE chess_games
+		SET current_turn = ?, status = ?, winner_id = ?, end_reason = ?, fen = ?, move_count = move_count + ?, updated_at = ?
+		WHERE id = ? AND move_count = ?
+	`, game.CurrentTurn, game.Status, game.WinnerID, game.EndReason, game.FEN, played, time.Now(), game.ID, game.MoveCount))
+}
+
+func createChessMove(db execer, move *models.ChessMove) error {
+	result, err := db.Exec(`
+		INSERT INTO chess_moves (game_id, user_id, move_number, uci, san, fen, repetition_key)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, move.GameID, move.UserID, move.MoveNumber, move.UCI, move.SAN, move.FEN, move.RepetitionKey)
+	if err != nil {
+		return err
+	}
+
+	move.ID, err = result.LastInsertId()
+	return err
+}
+
+func GetChessMoves(db *sql.DB, gameID int64) ([]models.ChessMove, error) {
+	rows, err := db.Query(`
+		SELECT id, game_id, user_id, move_number, uci, san, fen, repetition_key, created_at
+		FROM chess_moves
+		WHERE game_id = ?
+		ORDER BY move_number ASC
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var moves []models.ChessMove
+	for rows.Next() {
+		var m models.ChessMove
+		err := rows.Scan(&m.ID, &m.GameID, &m.UserID, &m.MoveNumber, &m.UCI, &m.SAN, &m.FEN, &m.RepetitionKey, &m.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		moves = append(moves, m)
+	}
+
+	return moves, nil
+}
@@ -0,0 +1,170 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"altech/internal/models"
+)
+
+func CreateConnectFourGame(db *sql.DB, player1ID, player2ID int64, boardSize string, popOut bool, boardJSON string) (*models.ConnectFourGame, error) {
+	result, err := db.Exec(`
+		INSERT INTO connectfour_games (player1_id, player2_id, current_turn, status, board_size, pop_out, board)
+		VALUES (?, ?, ?, 'active', ?, ?, ?)
+	`, player1ID, player2ID, player1ID, boardSize, popOut, boardJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return GetConnectFourGame(db, id)
+}
+
+func GetConnectFourGame(db *sql.DB, gameID int64) (*models.ConnectFourGame, error) {
+	game := &models.ConnectFourGame{}
+	var winnerID sql.NullInt64
+
+	err := db.QueryRow(`
+		SELECT id, player1_id, player2_id, current_turn, status, winner_id, board_size, pop_out, board, move_count, created_at, updated_at
+		FROM connectfour_games WHERE id = ?
+	`, gameID).Scan(
+		&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn,
+		&game.Status, &winnerID, &game.BoardSize, &game.PopOut, &game.Board, &game.MoveCount,
+		&game.CreatedAt, &game.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrGameNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if winnerID.Valid {
+		game.WinnerID = &winnerID.Int64
+	}
+
+	// Load player info
+	game.Player1, _ = GetUserByID(db, game.Player1ID)
+	game.Player2, _ = GetUserByID(db, game.Player2ID)
+
+	return game, nil
+}
+
+func GetConnectFourGamesForUser(db *sql.DB, userID int64) ([]models.ConnectFourGame, error) {
+	rows, err := db.Query(`
+		SELECT g.id, g.player1_id, g.player2_id, g.current_turn, g.status, g.winner_id, g.board_size, g.pop_out, g.board, g.move_count, g.created_at, g.updated_at
+		FROM connectfour_games g
+		WHERE g.player1_id = ? OR g.player2_id = ?
+		ORDER BY g.updated_at DESC
+	`, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var games []models.ConnectFourGame
+	for rows.Next() {
+		var game models.ConnectFourGame
+		var winnerID sql.NullInt64
+
+		err := rows.Scan(
+			&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn,
+			&game.Status, &winnerID, &game.BoardSize, &game.PopOut, &game.Board, &game.MoveCount,
+			&game.CreatedAt, &game.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if winnerID.Valid {
+			game.WinnerID = &winnerID.Int64
+		}
+
+		game.Player1, _ = GetUserByID(db, game.Player1ID)
+		game.Player2, _ = GetUserByID(db, game.Player2ID)
+
+		games = append(games, game)
+	}
+
+	return games, nil
+}
+
+// UpdateConnectFourGame saves a game without a move, as on resigning. It
+// fails with ErrGameChanged if a move was played since the game was loaded.
+func UpdateConnectFourGame(db *sql.DB, game *models.ConnectFourGame) error {
+	return updateConnectFourGame(db, game, 0)
+}
+
+// PlayConnectFourMove records a move, numbered from the game's move count,
+// and saves the game in one transaction. It fails with ErrGameChanged if
+// another move was played since the game was loaded.
+func PlayConnectFourMove(db *sql.DB, game *models.ConnectFourGame, move *models.ConnectFourMove) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	move.MoveNumber = game.MoveCount + 1
+	if err := updateConnectFourGame(tx, game, 1); err != nil {
+		return err
+	}
+	if err := createConnectFourMove(tx, move); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	game.MoveCount++
+	return nil
+}
+
+func updateConnectFourGame(db execer, game *models.ConnectFourGame, played int) error {
+	return checkGameUpdated(db.Exec(`
+		UPDATE connectfour_games
+		SET current_turn = ?, status = ?, winner_id = ?, board = ?, move_count = move_count + ?, updated_at = ?
+		WHERE id = ? AND move_count = ?
+	`, game.CurrentTurn, game.Status, game.WinnerID, game.Board, played, time.Now(), game.ID, game.MoveCount))
+}
+
+func createConnectFourMove(db execer, move *models.ConnectFourMove) error {
+	result, err := db.Exec(`
+		INSERT INTO connectfour_moves (game_id, user_id, move_number, action, col, row)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, move.GameID, move.UserID, move.MoveNumber, move.Action, move.Column, move.Row)
+	if err != nil {
+		return err
+	}
+
+	move.ID, err = result.LastInsertId()
+	return err
+}
+
+func GetConnectFourMoves(db *sql.DB, gameID int64) ([]models.ConnectFourMove, error) {
+	rows, err := db.Query(`
+		SELECT id, game_id, user_id, move_number, action, col, row, created_at
+		FROM connectfour_moves
+		WHERE game_id = ?
+		ORDER BY move_number ASC
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var moves []models.ConnectFourMove
+	for rows.Next() {
+		var m models.ConnectFourMove
+		if err := rows.Scan(&m.ID, &m.GameID, &m.UserID, &m.MoveNumber, &m.Action, &m.Column, &m.Row, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		moves = append(moves, m)
+	}
+
+	return moves, nil
+}
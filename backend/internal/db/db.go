@@ -122,6 +122,11 @@ func migrate(db *sql.DB) error {
 			current_turn INTEGER NOT NULL,
 			status TEXT NOT NULL DEFAULT 'setup',
 			winner_id INTEGER,
+			board_size INTEGER DEFAULT 10,
+			fleet TEXT NOT NULL DEFAULT '',
+			salvo INTEGER DEFAULT 0,
+			no_touch INTEGER DEFAULT 0,
+			move_count INTEGER NOT NULL DEFAULT 0,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (player1_id) REFERENCES users(id) ON DELETE CASCADE,
@@ -134,12 +139,25 @@ func migrate(db *sql.DB) error {
 			game_id INTEGER NOT NULL,
 			user_id INTEGER NOT NULL,
 			ships TEXT NOT NULL DEFAULT '[]',
-			shots TEXT NOT NULL DEFAULT '[]',
 			ships_ready INTEGER DEFAULT 0,
 			FOREIGN KEY (game_id) REFERENCES battleship_games(id) ON DELETE CASCADE,
 			UNIQUE(game_id, user_id)
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_battleship_boards_game ON battleship_boards(game_id)`,
+		`CREATE TABLE IF NOT EXISTS battleship_shots (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_id INTEGER NOT NULL,
+			shooter_id INTEGER NOT NULL,
+			row INTEGER NOT NULL,
+			col INTEGER NOT NULL,
+			result TEXT NOT NULL,
+			sunk_ship TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (game_id) REFERENCES battleship_games(id) ON DELETE CASCADE,
+			FOREIGN KEY (shooter_id) REFERENCES users(id) ON DELETE CASCADE,
+			UNIQUE(game_id, shooter_id, row, col)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_battleship_shots_game ON battleship_shots(game_id, shooter_id)`,
 		// Mastermind game tables
 		`CREATE TABLE IF NOT EXISTS mastermind_games (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -150,7 +168,9 @@ func migrate(db *sql.DB) error {
 			winner_id INTEGER,
 			max_guesses INTEGER DEFAULT 10,
 			num_colors INTEGER DEFAULT 6,
+			code_length INTEGER DEFAULT 4,
 			allow_repeats INTEGER DEFAULT 1,
+			assistant TEXT NOT NULL DEFAULT 'off',
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (player1_id) REFERENCES users(id) ON DELETE CASCADE,
@@ -182,6 +202,70 @@ func migrate(db *sql.DB) error {
 			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_mastermind_guesses_game ON mastermind_guesses(game_id)`,
+		`CREATE TABLE IF NOT EXISTS mastermind_daily (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER NOT NULL,
+			date TEXT NOT NULL,
+			status TEXT NOT NULL DEFAULT 'active',
+			guess_count INTEGER NOT NULL DEFAULT 0,
+			duration_ms INTEGER NOT NULL DEFAULT 0,
+			started_at DATETIME NOT NULL,
+			completed_at DATETIME,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			UNIQUE(user_id, date)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_mastermind_daily_date ON mastermind_daily(date)`,
+		`CREATE TABLE IF NOT EXISTS mastermind_daily_guesses (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			daily_id INTEGER NOT NULL,
+			guess TEXT NOT NULL,
+			correct INTEGER NOT NULL,
+			misplaced INTEGER NOT NULL,
+			guess_number INTEGER NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (daily_id) REFERENCES mastermind_daily(id) ON DELETE CASCADE,
+			UNIQUE(daily_id, guess_number)
+		)`,
+		// Word Mastermind tables
+		`CREATE TABLE IF NOT EXISTS wordmind_games (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			player1_id INTEGER NOT NULL,
+			player2_id INTEGER NOT NULL,
+			current_turn INTEGER NOT NULL,
+			status TEXT NOT NULL DEFAULT 'setup',
+			winner_id INTEGER,
+			max_guesses INTEGER DEFAULT 6,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (player1_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (player2_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_wordmind_games_player1 ON wordmind_games(player1_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_wordmind_games_player2 ON wordmind_games(player2_id)`,
+		`CREATE TABLE IF NOT EXISTS wordmind_secrets (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_id INTEGER NOT NULL,
+			user_id INTEGER NOT NULL,
+			word TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (game_id) REFERENCES wordmind_games(id) ON DELETE CASCADE,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			UNIQUE(game_id, user_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS wordmind_guesses (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_id INTEGER NOT NULL,
+			user_id INTEGER NOT NULL,
+			word TEXT NOT NULL,
+			feedback TEXT NOT NULL,
+			correct INTEGER NOT NULL,
+			guess_number INTEGER NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (game_id) REFERENCES wordmind_games(id) ON DELETE CASCADE,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			UNIQUE(game_id, user_id, guess_number)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_wordmind_guesses_game ON wordmind_guesses(game_id)`,
 		// Memory game tables
 		`CREATE TABLE IF NOT EXISTS memory_games (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -191,6 +275,8 @@ func migrate(db *sql.DB) error {
 			status TEXT NOT NULL DEFAULT 'active',
 			winner_id INTEGER,
 			board_size TEXT NOT NULL DEFAULT '4x5',
+			match_size INTEGER NOT NULL DEFAULT 2,
+			theme TEXT NOT NULL DEFAULT 'emoji',
 			board TEXT NOT NULL,
 			matched TEXT NOT NULL DEFAULT '[]',
 			player1_score INTEGER DEFAULT 0,
@@ -212,11 +298,442 @@ func migrate(db *sql.DB) error {
 			col2 INTEGER NOT NULL,
 			tile1 INTEGER NOT NULL,
 			tile2 INTEGER NOT NULL,
+			reveals TEXT NOT NULL DEFAULT '',
 			matched INTEGER NOT NULL DEFAULT 0,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (game_id) REFERENCES memory_games(id) ON DELETE CASCADE
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_memory_moves_game ON memory_moves(game_id)`,
+		`CREATE TABLE IF NOT EXISTS memory_solo_games (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER NOT NULL,
+			status TEXT NOT NULL DEFAULT 'active',
+			board_size TEXT NOT NULL,
+			match_size INTEGER NOT NULL DEFAULT 2,
+			theme TEXT NOT NULL DEFAULT 'emoji',
+			board TEXT NOT NULL,
+			matched TEXT NOT NULL,
+			flips INTEGER NOT NULL DEFAULT 0,
+			duration_ms INTEGER NOT NULL DEFAULT 0,
+			started_at DATETIME,
+			completed_at DATETIME,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_memory_solo_games_user ON memory_solo_games(user_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_memory_solo_games_board ON memory_solo_games(board_size, match_size, status)`,
+		`CREATE TABLE IF NOT EXISTS memory_solo_moves (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_id INTEGER NOT NULL,
+			reveals TEXT NOT NULL,
+			matched INTEGER NOT NULL DEFAULT 0,
+			elapsed_ms INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME NOT NULL,
+			FOREIGN KEY (game_id) REFERENCES memory_solo_games(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_memory_solo_moves_game ON memory_solo_moves(game_id)`,
+		// Connect Four tables
+		`CREATE TABLE IF NOT EXISTS connectfour_games (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			player1_id INTEGER NOT NULL,
+			player2_id INTEGER NOT NULL,
+			current_turn INTEGER NOT NULL,
+			status TEXT NOT NULL DEFAULT 'active',
+			winner_id INTEGER,
+			board_size TEXT NOT NULL DEFAULT '7x6',
+			pop_out INTEGER NOT NULL DEFAULT 0,
+			board TEXT NOT NULL,
+			move_count INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (player1_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (player2_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_connectfour_games_player1 ON connectfour_games(player1_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_connectfour_games_player2 ON connectfour_games(player2_id)`,
+		`CREATE TABLE IF NOT EXISTS connectfour_moves (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_id INTEGER NOT NULL,
+			user_id INTEGER NOT NULL,
+			move_number INTEGER NOT NULL,
+			action TEXT NOT NULL DEFAULT 'drop',
+			col INTEGER NOT NULL,
+			row INTEGER NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (game_id) REFERENCES connectfour_games(id) ON DELETE CASCADE,
+			UNIQUE(game_id, move_number)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_connectfour_moves_game ON connectfour_moves(game_id)`,
+		// Checkers tables
+		`CREATE TABLE IF NOT EXISTS checkers_games (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			player1_id INTEGER NOT NULL,
+			player2_id INTEGER NOT NULL,
+			current_turn INTEGER NOT NULL,
+			status TEXT NOT NULL DEFAULT 'active',
+			winner_id INTEGER,
+			end_reason TEXT NOT NULL DEFAULT '',
+			board TEXT NOT NULL,
+			quiet_plies INTEGER NOT NULL DEFAULT 0,
+			move_count INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (player1_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (player2_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_checkers_games_player1 ON checkers_games(player1_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_checkers_games_player2 ON checkers_games(player2_id)`,
+		`CREATE TABLE IF NOT EXISTS checkers_moves (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_id INTEGER NOT NULL,
+			user_id INTEGER NOT NULL,
+			move_number INTEGER NOT NULL,
+			path TEXT NOT NULL,
+			captured TEXT NOT NULL DEFAULT '[]',
+			notation TEXT NOT NULL,
+			crowned INTEGER NOT NULL DEFAULT 0,
+			position TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (game_id) REFERENCES checkers_games(id) ON DELETE CASCADE,
+			UNIQUE(game_id, move_number)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_checkers_moves_game ON checkers_moves(game_id)`,
+		// Chess tables
+		`CREATE TABLE IF NOT EXISTS chess_games (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			player1_id INTEGER NOT NULL,
+			player2_id INTEGER NOT NULL,
+			current_turn INTEGER NOT NULL,
+			status TEXT NOT NULL DEFAULT 'active',
+			winner_id INTEGER,
+			end_reason TEXT NOT NULL DEFAULT '',
+			start_fen TEXT NOT NULL,
+			fen TEXT NOT NULL,
+			move_count INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (player1_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (player2_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_chess_games_player1 ON chess_games(player1_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_chess_games_player2 ON chess_games(player2_id)`,
+		`CREATE TABLE IF NOT EXISTS chess_moves (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_id INTEGER NOT NULL,
+			user_id INTEGER NOT NULL,
+			move_number INTEGER NOT NULL,
+			uci TEXT NOT NULL,
+			san TEXT NOT NULL,
+			fen TEXT NOT NULL,
+			repetition_key TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (game_id) REFERENCES chess_games(id) ON DELETE CASCADE,
+			UNIQUE(game_id, move_number)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_chess_moves_game ON chess_moves(game_id)`,
+		// Boggle tables
+		`CREATE TABLE IF NOT EXISTS boggle_games (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			player1_id INTEGER NOT NULL,
+			player2_id INTEGER NOT NULL,
+			status TEXT NOT NULL DEFAULT 'active',
+			winner_id INTEGER,
+			size INTEGER NOT NULL DEFAULT 4,
+			seed INTEGER NOT NULL,
+			grid TEXT NOT NULL,
+			duration_seconds INTEGER NOT NULL,
+			player1_score INTEGER NOT NULL DEFAULT 0,
+			player2_score INTEGER NOT NULL DEFAULT 0,
+			player1_started_at DATETIME,
+			player2_started_at DATETIME,
+			solution TEXT NOT NULL DEFAULT '',
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (player1_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (player2_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_boggle_games_player1 ON boggle_games(player1_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_boggle_games_player2 ON boggle_games(player2_id)`,
+		`CREATE TABLE IF NOT EXISTS boggle_words (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_id INTEGER NOT NULL,
+			user_id INTEGER NOT NULL,
+			word TEXT NOT NULL,
+			path TEXT NOT NULL,
+			points INTEGER NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (game_id) REFERENCES boggle_games(id) ON DELETE CASCADE,
+			UNIQUE(game_id, user_id, word)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_boggle_words_game ON boggle_words(game_id)`,
+		// Dots and Boxes tables
+		`CREATE TABLE IF NOT EXISTS dotsandboxes_games (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			player1_id INTEGER NOT NULL,
+			player2_id INTEGER NOT NULL,
+			current_turn INTEGER NOT NULL,
+			status TEXT NOT NULL DEFAULT 'active',
+			winner_id INTEGER,
+			board_size TEXT NOT NULL DEFAULT '4x4',
+			board TEXT NOT NULL,
+			player1_score INTEGER NOT NULL DEFAULT 0,
+			player2_score INTEGER NOT NULL DEFAULT 0,
+			move_count INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (player1_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (player2_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_dotsandboxes_games_player1 ON dotsandboxes_games(player1_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_dotsandboxes_games_player2 ON dotsandboxes_games(player2_id)`,
+		`CREATE TABLE IF NOT EXISTS dotsandboxes_moves (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_id INTEGER NOT NULL,
+			user_id INTEGER NOT NULL,
+			move_number INTEGER NOT NULL,
+			orientation TEXT NOT NULL,
+			row INTEGER NOT NULL,
+			col INTEGER NOT NULL,
+			boxes_completed INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (game_id) REFERENCES dotsandboxes_games(id) ON DELETE CASCADE,
+			UNIQUE(game_id, move_number)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_dotsandboxes_moves_game ON dotsandboxes_moves(game_id)`,
+		// Othello tables
+		`CREATE TABLE IF NOT EXISTS othello_games (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			player1_id INTEGER NOT NULL,
+			player2_id INTEGER NOT NULL,
+			current_turn INTEGER NOT NULL,
+			status TEXT NOT NULL DEFAULT 'active',
+			winner_id INTEGER,
+			board TEXT NOT NULL,
+			player1_score INTEGER NOT NULL DEFAULT 2,
+			player2_score INTEGER NOT NULL DEFAULT 2,
+			move_count INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (player1_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (player2_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_othello_games_player1 ON othello_games(player1_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_othello_games_player2 ON othello_games(player2_id)`,
+		`CREATE TABLE IF NOT EXISTS othello_moves (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_id INTEGER NOT NULL,
+			user_id INTEGER NOT NULL,
+			move_number INTEGER NOT NULL,
+			passed INTEGER NOT NULL DEFAULT 0,
+			row INTEGER NOT NULL,
+			col INTEGER NOT NULL,
+			flipped INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (game_id) REFERENCES othello_games(id) ON DELETE CASCADE,
+			UNIQUE(game_id, move_number)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_othello_moves_game ON othello_moves(game_id)`,
+		// Go tables
+		`CREATE TABLE IF NOT EXISTS go_games (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			player1_id INTEGER NOT NULL,
+			player2_id INTEGER NOT NULL,
+			current_turn INTEGER NOT NULL,
+			status TEXT NOT NULL DEFAULT 'active',
+			winner_id INTEGER,
+			end_reason TEXT NOT NULL DEFAULT '',
+			size INTEGER NOT NULL DEFAULT 9,
+			rules TEXT NOT NULL DEFAULT 'japanese',
+			komi REAL NOT NULL DEFAULT 6.5,
+			board TEXT NOT NULL,
+			player1_captures INTEGER NOT NULL DEFAULT 0,
+			player2_captures INTEGER NOT NULL DEFAULT 0,
+			consecutive_passes INTEGER NOT NULL DEFAULT 0,
+			dead_stones TEXT NOT NULL DEFAULT '[]',
+			player1_accepted INTEGER NOT NULL DEFAULT 0,
+			player2_accepted INTEGER NOT NULL DEFAULT 0,
+			player1_score REAL NOT NULL DEFAULT 0,
+			player2_score REAL NOT NULL DEFAULT 0,
+			move_count INTEGER NOT NULL DEFAULT 0,
+			scoring_version INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (player1_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (player2_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_go_games_player1 ON go_games(player1_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_go_games_player2 ON go_games(player2_id)`,
+		`CREATE TABLE IF NOT EXISTS go_moves (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_id INTEGER NOT NULL,
+			user_id INTEGER NOT NULL,
+			move_number INTEGER NOT NULL,
+			color INTEGER NOT NULL,
+			passed INTEGER NOT NULL DEFAULT 0,
+			row INTEGER NOT NULL,
+			col INTEGER NOT NULL,
+			captured INTEGER NOT NULL DEFAULT 0,
+			position TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (game_id) REFERENCES go_games(id) ON DELETE CASCADE,
+			UNIQUE(game_id, move_number)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_go_moves_game ON go_moves(game_id)`,
+		// Yahtzee tables
+		`CREATE TABLE IF NOT EXISTS yahtzee_games (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			player1_id INTEGER NOT NULL,
+			player2_id INTEGER NOT NULL,
+			current_turn INTEGER NOT NULL,
+			status TEXT NOT NULL DEFAULT 'active',
+			winner_id INTEGER,
+			turn_number INTEGER NOT NULL DEFAULT 1,
+			dice TEXT NOT NULL DEFAULT '',
+			rolls_used INTEGER NOT NULL DEFAULT 0,
+			player1_scorecard TEXT NOT NULL,
+			player2_scorecard TEXT NOT NULL,
+			player1_score INTEGER NOT NULL DEFAULT 0,
+			player2_score INTEGER NOT NULL DEFAULT 0,
+			move_count INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (player1_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (player2_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_yahtzee_games_player1 ON yahtzee_games(player1_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_yahtzee_games_player2 ON yahtzee_games(player2_id)`,
+		`CREATE TABLE IF NOT EXISTS yahtzee_rolls (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_id INTEGER NOT NULL,
+			user_id INTEGER NOT NULL,
+			turn_number INTEGER NOT NULL,
+			roll_number INTEGER NOT NULL,
+			held TEXT NOT NULL,
+			dice TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (game_id) REFERENCES yahtzee_games(id) ON DELETE CASCADE,
+			UNIQUE(game_id, turn_number, roll_number)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_yahtzee_rolls_game ON yahtzee_rolls(game_id)`,
+		`CREATE TABLE IF NOT EXISTS yahtzee_entries (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_id INTEGER NOT NULL,
+			user_id INTEGER NOT NULL,
+			turn_number INTEGER NOT NULL,
+			category TEXT NOT NULL,
+			dice TEXT NOT NULL,
+			points INTEGER NOT NULL,
+			bonus INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (game_id) REFERENCES yahtzee_games(id) ON DELETE CASCADE,
+			UNIQUE(game_id, turn_number)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_yahtzee_entries_game ON yahtzee_entries(game_id)`,
+		// Game invitation tables
+		`CREATE TABLE IF NOT EXISTS game_invitations (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			sender_id INTEGER NOT NULL,
+			receiver_id INTEGER NOT NULL,
+			game_type TEXT NOT NULL,
+			settings TEXT NOT NULL DEFAULT '{}',
+			status TEXT NOT NULL DEFAULT 'pending',
+			game_id INTEGER,
+			expires_at DATETIME NOT NULL,
+			responded_at DATETIME,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (sender_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (receiver_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_game_invitations_receiver ON game_invitations(receiver_id, status)`,
+		`CREATE INDEX IF NOT EXISTS idx_game_invitations_sender ON game_invitations(sender_id)`,
+		// Rematch tables. game_id is NULL while the rematch is being created.
+		`CREATE TABLE IF NOT EXISTS rematches (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_type TEXT NOT NULL,
+			previous_game_id INTEGER NOT NULL,
+			game_id INTEGER,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE(game_type, previous_game_id)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_rematches_game ON rematches(game_type, game_id)`,
+		// Chat tables. Direct messages have an empty game_type and game_id 0.
+		`CREATE TABLE IF NOT EXISTS chat_messages (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_type TEXT NOT NULL DEFAULT '',
+			game_id INTEGER NOT NULL DEFAULT 0,
+			sender_id INTEGER NOT NULL,
+			recipient_id INTEGER NOT NULL,
+			body TEXT NOT NULL,
+			read_at DATETIME,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (sender_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (recipient_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_chat_messages_game ON chat_messages(game_type, game_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_chat_messages_sender ON chat_messages(sender_id, recipient_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_chat_messages_recipient ON chat_messages(recipient_id, read_at)`,
+		`CREATE TABLE IF NOT EXISTS chat_settings (
+			user_id INTEGER PRIMARY KEY,
+			filter_profanity INTEGER NOT NULL DEFAULT 1,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		// Spectator tables. Each player opens a game to their own friends.
+		`CREATE TABLE IF NOT EXISTS watchable_games (
+			game_type TEXT NOT NULL,
+			game_id INTEGER NOT NULL,
+			player1_id INTEGER NOT NULL,
+			player2_id INTEGER NOT NULL,
+			player1_watchable INTEGER NOT NULL DEFAULT 0,
+			player2_watchable INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (game_type, game_id)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_watchable_games_player1 ON watchable_games(player1_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_watchable_games_player2 ON watchable_games(player2_id)`,
+		// Tournament tables. Match players are NULL until decided.
+		`CREATE TABLE IF NOT EXISTS tournaments (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			organizer_id INTEGER NOT NULL,
+			name TEXT NOT NULL,
+			game_type TEXT NOT NULL,
+			format TEXT NOT NULL,
+			settings TEXT NOT NULL DEFAULT '{}',
+			status TEXT NOT NULL DEFAULT 'active',
+			current_round INTEGER NOT NULL DEFAULT 1,
+			total_rounds INTEGER NOT NULL,
+			winner_id INTEGER,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (organizer_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS tournament_participants (
+			tournament_id INTEGER NOT NULL,
+			user_id INTEGER NOT NULL,
+			seed INTEGER NOT NULL,
+			PRIMARY KEY (tournament_id, user_id),
+			FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_tournament_participants_user ON tournament_participants(user_id)`,
+		`CREATE TABLE IF NOT EXISTS tournament_matches (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			tournament_id INTEGER NOT NULL,
+			round INTEGER NOT NULL,
+			position INTEGER NOT NULL,
+			player1_id INTEGER,
+			player2_id INTEGER,
+			game_id INTEGER,
+			status TEXT NOT NULL DEFAULT 'pending',
+			winner_id INTEGER,
+			player1_score REAL,
+			player2_score REAL,
+			replays INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (tournament_id) REFERENCES tournaments(id) ON DELETE CASCADE,
+			UNIQUE(tournament_id, round, position)
+		)`,
 	}
 
 	for _, m := range migrations {
@@ -229,6 +746,28 @@ func migrate(db *sql.DB) error {
 	optionalMigrations := []string{
 		`ALTER TABLE mastermind_games ADD COLUMN num_colors INTEGER DEFAULT 6`,
 		`ALTER TABLE mastermind_games ADD COLUMN allow_repeats INTEGER DEFAULT 1`,
+		`ALTER TABLE mastermind_games ADD COLUMN code_length INTEGER DEFAULT 4`,
+		`ALTER TABLE mastermind_games ADD COLUMN assistant TEXT NOT NULL DEFAULT 'off'`,
+		`ALTER TABLE battleship_games ADD COLUMN board_size INTEGER DEFAULT 10`,
+		`ALTER TABLE battleship_games ADD COLUMN fleet TEXT NOT NULL DEFAULT ''`,
+		`ALTER TABLE battleship_games ADD COLUMN salvo INTEGER DEFAULT 0`,
+		`ALTER TABLE battleship_games ADD COLUMN no_touch INTEGER DEFAULT 0`,
+		`ALTER TABLE battleship_games ADD COLUMN move_count INTEGER NOT NULL DEFAULT 0`,
+		// Move shots from the old battleship_boards.shots JSON column into
+		// battleship_shots. Boards store shots received, so the shooter is
+		// the other player. Fails harmlessly on databases without the column.
+		`INSERT OR IGNORE INTO battleship_shots (game_id, shooter_id, row, col, result)
+			SELECT b.game_id,
+			       CASE WHEN g.player1_id = b.user_id THEN g.player2_id ELSE g.player1_id END,
+			       json_extract(s.value, '$.row'), json_extract(s.value, '$.col'),
+			       CASE WHEN json_extract(s.value, '$.hit') THEN 'hit' ELSE 'miss' END
+			FROM battleship_boards b
+			JOIN battleship_games g ON g.id = b.game_id, json_each(b.shots) s
+			WHERE b.shots != '[]'`,
+		`UPDATE battleship_boards SET shots = '[]' WHERE shots != '[]'`,
+		`ALTER TABLE memory_games ADD COLUMN match_size INTEGER NOT NULL DEFAULT 2`,
+		`ALTER TABLE memory_games ADD COLUMN theme TEXT NOT NULL DEFAULT 'emoji'`,
+		`ALTER TABLE memory_moves ADD COLUMN reveals TEXT NOT NULL DEFAULT ''`,
 	}
 	for _, m := range optionalMigrations {
 		db.Exec(m) // Ignore errors - column may already exist
@@ -236,3 +775,26 @@ func migrate(db *sql.DB) error {
 
 	return nil
 }
+
+// execer is satisfied by both *sql.DB and *sql.Tx
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// checkGameUpdated turns an update guarded by a game's move count into
+// ErrGameChanged when another request got there first. Games are updated
+// before their move is inserted, so a racing request fails here rather than
+// on the move's unique number.
+func checkGameUpdated(result sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrGameChanged
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"altech/internal/models"
+)
+
+func CreateDotsAndBoxesGame(db *sql.DB, player1ID, player2ID int64, boardSize, boardJSON string) (*models.DotsAndBoxesGame, error) {
+	result, err := db.Exec(`
+		INSERT INTO dotsandboxes_games (player1_id, player2_id, current_turn, status, board_size, board)
+		VALUES (?, ?, ?, 'active', ?, ?)
+	`, player1ID, player2ID, player1ID, boardSize, boardJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return GetDotsAndBoxesGame(db, id)
+}
+
+func GetDotsAndBoxesGame(db *sql.DB, gameID int64) (*models.DotsAndBoxesGame, error) {
+	game := &models.DotsAndBoxesGame{}
+	var winnerID sql.NullInt64
+
+	err := db.QueryRow(`
+		SELECT id, player1_id, player2_id, current_turn, status, winner_id, board_size, board, player1_score, player2_score, move_count, created_at, updated_at
+		FROM dotsandboxes_games WHERE id = ?
+	`, gameID).Scan(
+		&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn,
+		&game.Status, &winnerID, &game.BoardSize, &game.Board,
+		&game.Player1Score, &game.Player2Score, &game.MoveCount, &game.CreatedAt, &game.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrGameNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if winnerID.Valid {
+		game.WinnerID = &winnerID.Int64
+	}
+
+	// Load player info
+	game.Player1, _ = GetUserByID(db, game.Player1ID)
+	game.Player2, _ = GetUserByID(db, game.Player2ID)
+
+	return game, nil
+}
+
+func GetDotsAndBoxesGamesForUser(db *sql.DB, userID int64) ([]models.DotsAndBoxesGame, error) {
+	rows, err := db.Query(`
+		SELECT g.id, g.player1_id, g.player2_id, g.current_turn, g.status, g.winner_id, g.board_size, g.board, g.player1_score, g.player2_score, g.move_count, g.created_at, g.updated_at
+		FROM dotsandboxes_games g
+		WHERE g.player1_id = ? OR g.player2_id = ?
+		ORDER BY g.updated_at DESC
+	`, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var games []models.DotsAndBoxesGame
+	for rows.Next() {
+		var game models.DotsAndBoxesGame
+		var winnerID sql.NullInt64
+
+		err := rows.Scan(
+			&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn,
+			&game.Status, &winnerID, &game.BoardSize, &game.Board,
+			&game.Player1Score, &game.Player2Score, &game.MoveCount, &game.CreatedAt, &game.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if winnerID.Valid {
+			game.WinnerID = &winnerID.Int64
+		}
+
+		game.Player1, _ = GetUserByID(db, game.Player1ID)
+		game.Player2, _ = GetUserByID(db, game.Player2ID)
+
+		games = append(games, game)
+	}
+
+	return games, nil
+}
+
+// UpdateDotsAndBoxesGame saves a game without a move, as on resigning. It
+// fails with ErrGameChanged if a move was played since the game was loaded.
+func UpdateDotsAndBoxesGame(db *sql.DB, game *models.DotsAndBoxesGame) error {
+	return updateDotsAndBoxesGame(db, game, 0)
+}
+
+// PlayDotsAndBoxesMove records a move, numbered from the game's move count, and
+// saves the game in one transaction. It fails with ErrGameChanged if another
+// move was played since the game was loaded.
+func PlayDotsAndBoxesMove(db *sql.DB, game *models.DotsAndBoxesGame, move *models.DotsAndBoxesMove) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	move.MoveNumber = game.MoveCount + 1
+	if err := updateDotsAndBoxesGame(tx, game, 1); err != nil {
+		return err
+	}
+	if err := createDotsAndBoxesMove(tx, move); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	game.MoveCount++
+	return nil
+}
+
+func updateDotsAndBoxesGame(db execer, game *models.DotsAndBoxesGame, played int) error {
+	return checkGameUpdated(db.Exec(`
+		UPDATE dotsandboxes_games
+		SET current_turn = ?, status = ?, winner_id = ?, board = ?, player1_score = ?, player2_score = ?, move_count = move_count + ?, updated_at = ?
+		WHERE id = ? AND move_count = ?
+	`, game.CurrentTurn, game.Status, game.WinnerID, game.Board, game.Player1Score, game.Player2Score, played, time.Now(), game.ID, game.MoveCount))
+}
+
+func createDotsAndBoxesMove(db execer, move *models.DotsAndBoxesMove) error {
+	result, err := db.Exec(`
+		INSERT INTO dotsandboxes_moves (game_id, user_id, move_number, orientation, row, col, boxes_completed)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, move.GameID, move.UserID, move.MoveNumber, move.Orientation, move.Row, move.Col, move.BoxesCompleted)
+	if err != nil {
+		return err
+	}
+
+	move.ID, err = result.LastInsertId()
+	return err
+}
+
+func GetDotsAndBoxesMoves(db *sql.DB, gameID int64) ([]models.DotsAndBoxesMove, error) {
+	rows, err := db.Query(`
+		SELECT id, game_id, user_id, move_number, orientation, row, col, boxes_completed, created_at
+		FROM dotsandboxes_moves
+		WHERE game_id = ?
+		ORDER BY move_number ASC
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var moves []models.DotsAndBoxesMove
+	for rows.Next() {
+		var m models.DotsAndBoxesMove
+		err := rows.Scan(&m.ID, &m.GameID, &m.UserID, &m.MoveNumber, &m.Orientation, &m.Row, &m.Col, &m.BoxesCompleted, &m.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		moves = append(moves, m)
+	}
+
+	return moves, nil
+}
@@ -0,0 +1,187 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"altech/internal/models"
+)
+
+const goColumns = `id, player1_id, player2_id, current_turn, status, winner_id, end_reason, size, rules, komi,
+	board, player1_captures, player2_captures, consecutive_passes, dead_stones,
+	player1_accepted, player2_accepted, player1_score, player2_score, move_count, scoring_version, created_at, updated_at`
+
+func CreateGoGame(db *sql.DB, player1ID, player2ID int64, size int, rules string, komi float64, boardJSON string) (*models.GoGame, error) {
+	result, err := db.Exec(`
+		INSERT INTO go_games (player1_id, player2_id, current_turn, status, size, rules, komi, board)
+		VALUES (?, ?, ?, 'active', ?, ?, ?, ?)
+	`, player1ID, player2ID, player1ID, size, rules, komi, boardJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return GetGoGame(db, id)
+}
+
+func scanGoGame(db *sql.DB, row interface{ Scan(...any) error }) (*models.GoGame, error) {
+	game := &models.GoGame{}
+	var winnerID sql.NullInt64
+
+	err := row.Scan(
+		&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn, &game.Status, &winnerID,
+		&game.EndReason, &game.Size, &game.Rules, &game.Komi,
+		&game.Board, &game.Player1Captures, &game.Player2Captures, &game.ConsecutivePasses, &game.DeadStones,
+		&game.Player1Accepted, &game.Player2Accepted, &game.Player1Score, &game.Player2Score, &game.MoveCount, &game.ScoringVersion,
+		&game.CreatedAt, &game.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if winnerID.Valid {
+		game.WinnerID = &winnerID.Int64
+	}
+
+	// Load player info
+	game.Player1, _ = GetUserByID(db, game.Player1ID)
+	game.Player2, _ = GetUserByID(db, game.Player2ID)
+
+	return game, nil
+}
+
+func GetGoGame(db *sql.DB, gameID int64) (*models.GoGame, error) {
+	game, err := scanGoGame(db, db.QueryRow(`
+		SELECT `+goColumns+`
+		FROM go_games WHERE id = ?
+	`, gameID))
+	if err == sql.ErrNoRows {
+		return nil, ErrGameNotFound
+	}
+	return game, err
+}
+
+func GetGoGamesForUser(db *sql.DB, userID int64) ([]models.GoGame, error) {
+	rows, err := db.Query(`
+		SELECT `+goColumns+`
+		FROM go_games
+		WHERE player1_id = ? OR player2_id = ?
+		ORDER BY updated_at DESC
+	`, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var games []models.GoGame
+	for rows.Next() {
+		game, err := scanGoGame(db, rows)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, *game)
+	}
+
+	return games, nil
+}
+
+// UpdateGoGame saves a game without a move, as on resigning. It
+// fails with ErrGameChanged if a move was played or the scoring changed
+// since the game was loaded.
+func UpdateGoGame(db *sql.DB, game *models.GoGame) error {
+	return updateGoGame(db, game, 0, 0)
+}
+
+// UpdateGoScoring saves a change made while scoring, such as marking dead
+// stones or accepting them. It fails with ErrGameChanged if a move was
+// played or the scoring changed since the game was loaded, so nobody accepts
+// dead stones their opponent has since changed.
+func UpdateGoScoring(db *sql.DB, game *models.GoGame) error {
+	if err := updateGoGame(db, game, 0, 1); err != nil {
+		return err
+	}
+
+	game.ScoringVersion++
+	return nil
+}
+
+// PlayGoMove records a move, numbered from the game's move count, and
+// saves the game in one transaction. It fails with ErrGameChanged if another
+// move was played since the game was loaded.
+func PlayGoMove(db *sql.DB, game *models.GoGame, move *models.GoMove) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	move.MoveNumber = game.MoveCount + 1
+	if err := updateGoGame(tx, game, 1, 0); err != nil {
+		return err
+	}
+	if err := createGoMove(tx, move); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	game.MoveCount++
+	return nil
+}
+
+func updateGoGame(db execer, game *models.GoGame, played, scored int) error {
+	return checkGameUpdated(db.Exec(`
+		UPDATE go_games
+		SET current_turn = ?, status = ?, winner_id = ?, end_reason = ?, board = ?,
+			player1_captures = ?, player2_captures = ?, consecutive_passes = ?, dead_stones = ?,
+			player1_accepted = ?, player2_accepted = ?, player1_score = ?, player2_score = ?,
+			move_count = move_count + ?, scoring_version = scoring_version + ?, updated_at = ?
+		WHERE id = ? AND move_count = ? AND scoring_version = ?
+	`, game.CurrentTurn, game.Status, game.WinnerID, game.EndReason, game.Board,
+		game.Player1Captures, game.Player2Captures, game.ConsecutivePasses, game.DeadStones,
+		game.Player1Accepted, game.Player2Accepted, game.Player1Score, game.Player2Score,
+		played, scored, time.Now(), game.ID, game.MoveCount, game.ScoringVersion))
+}
+
+func createGoMove(db execer, move *models.GoMove) error {
+	result, err := db.Exec(`
+		INSERT INTO go_moves (game_id, user_id, move_number, color, passed, row, col, captured, position)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, move.GameID, move.UserID, move.MoveNumber, move.Color, move.Passed, move.Row, move.Col, move.Captured, move.Position)
+	if err != nil {
+		return err
+	}
+
+	move.ID, err = result.LastInsertId()
+	return err
+}
+
+func GetGoMoves(db *sql.DB, gameID int64) ([]models.GoMove, error) {
+	rows, err := db.Query(`
+		SELECT id, game_id, user_id, move_number, color, passed, row, col, captured, position, created_at
+		FROM go_moves
+		WHERE game_id = ?
+		ORDER BY move_number ASC
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var moves []models.GoMove
+	for rows.Next() {
+		var m models.GoMove
+		err := rows.Scan(&m.ID, &m.GameID, &m.UserID, &m.MoveNumber, &m.Color, &m.Passed, &m.Row, &m.Col, &m.Captured, &m.Position, &m.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		moves = append(moves, m)
+	}
+
+	return moves, nil
+}
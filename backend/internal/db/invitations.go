@@ -0,0 +1,177 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"altech/internal/models"
+)
+
+var (
+	ErrInvitationNotFound   = errors.New("invitation not found")
+	ErrInvitationNotPending = errors.New("invitation is not pending")
+	ErrInvitationExpired    = errors.New("invitation has expired")
+)
+
+const invitationColumns = `id, sender_id, receiver_id, game_type, settings, status, game_id,
+	expires_at, responded_at, created_at`
+
+func CreateGameInvitation(db *sql.DB, senderID, receiverID int64, gameType, settings string, expiresAt time.Time) (*models.GameInvitation, error) {
+	result, err := db.Exec(`
+		INSERT INTO game_invitations (sender_id, receiver_id, game_type, settings, status, expires_at)
+		VALUES (?, ?, ?, ?, 'pending', ?)
+	`, senderID, receiverID, gameType, settings, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return GetGameInvitation(db, id)
+}
+
+// scanGameInvitation reads an invitation row. A pending invitation past its
+// expiry is reported as expired; nothing needs to sweep the table.
+func scanGameInvitation(db *sql.DB, row interface{ Scan(...any) error }) (*models.GameInvitation, error) {
+	inv := &models.GameInvitation{}
+	var settings string
+	var gameID sql.NullInt64
+	var respondedAt sql.NullTime
+
+	err := row.Scan(
+		&inv.ID, &inv.SenderID, &inv.ReceiverID, &inv.GameType, &settings, &inv.Status, &gameID,
+		&inv.ExpiresAt, &respondedAt, &inv.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	inv.Settings = []byte(settings)
+	if gameID.Valid {
+		inv.GameID = &gameID.Int64
+	}
+	if respondedAt.Valid {
+		inv.RespondedAt = &respondedAt.Time
+	}
+	if inv.Status == "pending" && time.Now().After(inv.ExpiresAt) {
+		inv.Status = "expired"
+	}
+
+	// Load player info
+	inv.Sender, _ = GetUserByID(db, inv.SenderID)
+	inv.Receiver, _ = GetUserByID(db, inv.ReceiverID)
+
+	return inv, nil
+}
+
+func GetGameInvitation(db *sql.DB, id int64) (*models.GameInvitation, error) {
+	inv, err := scanGameInvitation(db, db.QueryRow(`
+		SELECT `+invitationColumns+`
+		FROM game_invitations WHERE id = ?
+	`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrInvitationNotFound
+	}
+	return inv, err
+}
+
+func queryGameInvitations(db *sql.DB, query string, args ...any) ([]models.GameInvitation, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations []models.GameInvitation
+	for rows.Next() {
+		inv, err := scanGameInvitation(db, rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, *inv)
+	}
+
+	return invitations, nil
+}
+
+// GetIncomingGameInvitations returns the invitations a user can still answer
+func GetIncomingGameInvitations(db *sql.DB, userID int64) ([]models.GameInvitation, error) {
+	invitations, err := queryGameInvitations(db, `
+		SELECT `+invitationColumns+`
+		FROM game_invitations
+		WHERE receiver_id = ? AND status = 'pending'
+		ORDER BY created_at DESC, id DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []models.GameInvitation
+	for _, inv := range invitations {
+		if inv.Status == "pending" {
+			pending = append(pending, inv)
+		}
+	}
+	return pending, nil
+}
+
+// GetOutgoingGameInvitations returns every invitation a user has sent, so
+// declined and expired ones show up as well as pending ones
+func GetOutgoingGameInvitations(db *sql.DB, userID int64) ([]models.GameInvitation, error) {
+	return queryGameInvitations(db, `
+		SELECT `+invitationColumns+`
+		FROM game_invitations
+		WHERE sender_id = ?
+		ORDER BY created_at DESC, id DESC
+	`, userID)
+}
+
+// RespondToGameInvitation moves a pending invitation to status. Only one
+// response can win, so a second racing this one gets ErrInvitationNotPending,
+// and one arriving after the deadline gets ErrInvitationExpired.
+func RespondToGameInvitation(db *sql.DB, id int64, status string) error {
+	now := time.Now()
+	result, err := db.Exec(`
+		UPDATE game_invitations SET status = ?, responded_at = ?
+		WHERE id = ? AND status = 'pending' AND expires_at > ?
+	`, status, now, id, now)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+
+	inv, err := GetGameInvitation(db, id)
+	if err != nil {
+		return err
+	}
+	if inv.Status == "expired" {
+		return ErrInvitationExpired
+	}
+	return ErrInvitationNotPending
+}
+
+// ReopenGameInvitation puts an accepted invitation back to pending when its
+// game could not be created
+func ReopenGameInvitation(db *sql.DB, id int64) error {
+	_, err := db.Exec(`
+		UPDATE game_invitations SET status = 'pending', responded_at = NULL
+		WHERE id = ? AND status = 'accepted' AND game_id IS NULL
+	`, id)
+	return err
+}
+
+func SetGameInvitationGame(db *sql.DB, id, gameID int64) error {
+	_, err := db.Exec("UPDATE game_invitations SET game_id = ? WHERE id = ?", gameID, id)
+	return err
+}
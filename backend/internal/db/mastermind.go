@@ -2,16 +2,22 @@ package db
 
 import (
 	"database/sql"
+	"errors"
 	"time"
 
 	"altech/internal/models"
 )
 
-func CreateMastermindGame(db *sql.DB, player1ID, player2ID int64, numColors int, allowRepeats bool) (*models.MastermindGame, error) {
+var (
+	ErrDailyAlreadyPlayed = errors.New("daily puzzle already played")
+	ErrDailyNotFound      = errors.New("daily puzzle not started")
+)
+
+func CreateMastermindGame(db *sql.DB, player1ID, player2ID int64, numColors, codeLength, maxGuesses int, allowRepeats bool, assistant string) (*models.MastermindGame, error) {
 	result, err := db.Exec(`
-		INSERT INTO mastermind_games (player1_id, player2_id, current_turn, status, max_guesses, num_colors, allow_repeats)
-		VALUES (?, ?, ?, 'setup', 10, ?, ?)
-	`, player1ID, player2ID, player1ID, numColors, allowRepeats)
+		INSERT INTO mastermind_games (player1_id, player2_id, current_turn, status, max_guesses, num_colors, code_length, allow_repeats, assistant)
+		VALUES (?, ?, ?, 'setup', ?, ?, ?, ?, ?)
+	`, player1ID, player2ID, player1ID, maxGuesses, numColors, codeLength, allowRepeats, assistant)
 	if err != nil {
 		return nil, err
 	}
@@ -29,11 +35,11 @@ func GetMastermindGame(db *sql.DB, gameID int64) (*models.MastermindGame, error)
 	var winnerID sql.NullInt64
 
 	err := db.QueryRow(`
-		SELECT id, player1_id, player2_id, current_turn, status, winner_id, max_guesses, num_colors, allow_repeats, created_at, updated_at
+		SELECT id, player1_id, player2_id, current_turn, status, winner_id, max_guesses, num_colors, code_length, allow_repeats, assistant, created_at, updated_at
 		FROM mastermind_games WHERE id = ?
 	`, gameID).Scan(
 		&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn,
-		&game.Status, &winnerID, &game.MaxGuesses, &game.NumColors, &game.AllowRepeats,
+		&game.Status, &winnerID, &game.MaxGuesses, &game.NumColors, &game.CodeLength, &game.AllowRepeats, &game.Assistant,
 		&game.CreatedAt, &game.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
@@ -56,7 +62,7 @@ func GetMastermindGame(db *sql.DB, gameID int64) (*models.MastermindGame, error)
 
 func GetMastermindGamesForUser(db *sql.DB, userID int64) ([]models.MastermindGame, error) {
 	rows, err := db.Query(`
-		SELECT g.id, g.player1_id, g.player2_id, g.current_turn, g.status, g.winner_id, g.max_guesses, g.num_colors, g.allow_repeats, g.created_at, g.updated_at
+		SELECT g.id, g.player1_id, g.player2_id, g.current_turn, g.status, g.winner_id, g.max_guesses, g.num_colors, g.code_length, g.allow_repeats, g.assistant, g.created_at, g.updated_at
 		FROM mastermind_games g
 		WHERE g.player1_id = ? OR g.player2_id = ?
 		ORDER BY g.updated_at DESC
@@ -73,7 +79,7 @@ func GetMastermindGamesForUser(db *sql.DB, userID int64) ([]models.MastermindGam
 
 		err := rows.Scan(
 			&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn,
-			&game.Status, &winnerID, &game.MaxGuesses, &game.NumColors, &game.AllowRepeats,
+			&game.Status, &winnerID, &game.MaxGuesses, &game.NumColors, &game.CodeLength, &game.AllowRepeats, &game.Assistant,
 			&game.CreatedAt, &game.UpdatedAt,
 		)
 		if err != nil {
@@ -208,3 +214,164 @@ func HasUserSetSecret(db *sql.DB, gameID, userID int64) (bool, error) {
 	`, gameID, userID).Scan(&count)
 	return count > 0, err
 }
+
+// CreateMastermindDaily starts a user's attempt at a day's puzzle. Each user
+// gets one attempt per date.
+func CreateMastermindDaily(db *sql.DB, userID int64, date string) (*models.MastermindDaily, error) {
+	result, err := db.Exec(`
+		INSERT OR IGNORE INTO mastermind_daily (user_id, date, status, started_at)
+		VALUES (?, ?, 'active', ?)
+	`, userID, date, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, ErrDailyAlreadyPlayed
+	}
+
+	return GetMastermindDaily(db, userID, date)
+}
+
+func GetMastermindDaily(db *sql.DB, userID int64, date string) (*models.MastermindDaily, error) {
+	daily := &models.MastermindDaily{}
+	var completedAt sql.NullTime
+
+	err := db.QueryRow(`
+		SELECT id, user_id, date, status, guess_count, duration_ms, started_at, completed_at
+		FROM mastermind_daily WHERE user_id = ? AND date = ?
+	`, userID, date).Scan(
+		&daily.ID, &daily.UserID, &daily.Date, &daily.Status, &daily.GuessCount,
+		&daily.DurationMs, &daily.StartedAt, &completedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrDailyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if completedAt.Valid {
+		daily.CompletedAt = &completedAt.Time
+	}
+
+	return daily, nil
+}
+
+// GetActiveMastermindDaily returns a user's most recent unfinished attempt
+func GetActiveMastermindDaily(db *sql.DB, userID int64) (*models.MastermindDaily, error) {
+	daily := &models.MastermindDaily{}
+
+	err := db.QueryRow(`
+		SELECT id, user_id, date, status, guess_count, duration_ms, started_at
+		FROM mastermind_daily WHERE user_id = ? AND status = 'active'
+		ORDER BY date DESC LIMIT 1
+	`, userID).Scan(
+		&daily.ID, &daily.UserID, &daily.Date, &daily.Status, &daily.GuessCount,
+		&daily.DurationMs, &daily.StartedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrDailyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return daily, nil
+}
+
+// FailStaleMastermindDailies closes every unfinished attempt at a puzzle
+// dated before the given date as failed
+func FailStaleMastermindDailies(db *sql.DB, before string) error {
+	_, err := db.Exec(`
+		UPDATE mastermind_daily SET status = 'failed', completed_at = ?
+		WHERE status = 'active' AND date < ?
+	`, time.Now(), before)
+	return err
+}
+
+func UpdateMastermindDaily(db *sql.DB, daily *models.MastermindDaily) error {
+	_, err := db.Exec(`
+		UPDATE mastermind_daily
+		SET status = ?, guess_count = ?, duration_ms = ?, completed_at = ?
+		WHERE id = ?
+	`, daily.Status, daily.GuessCount, daily.DurationMs, daily.CompletedAt, daily.ID)
+	return err
+}
+
+func CreateMastermindDailyGuess(db *sql.DB, dailyID int64, guess string, correct, misplaced, guessNumber int) error {
+	_, err := db.Exec(`
+		INSERT INTO mastermind_daily_guesses (daily_id, guess, correct, misplaced, guess_number)
+		VALUES (?, ?, ?, ?, ?)
+	`, dailyID, guess, correct, misplaced, guessNumber)
+	return err
+}
+
+func GetMastermindDailyGuesses(db *sql.DB, dailyID int64) ([]models.MastermindDailyGuess, error) {
+	rows, err := db.Query(`
+		SELECT id, daily_id, guess, correct, misplaced, guess_number, created_at
+		FROM mastermind_daily_guesses
+		WHERE daily_id = ?
+		ORDER BY guess_number ASC
+	`, dailyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var guesses []models.MastermindDailyGuess
+	for rows.Next() {
+		var g models.MastermindDailyGuess
+		err := rows.Scan(&g.ID, &g.DailyID, &g.Guess, &g.Correct, &g.Misplaced, &g.GuessNumber, &g.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		guesses = append(guesses, g)
+	}
+
+	return guesses, nil
+}
+
+// GetMastermindDailyLeaderboard returns the finished attempts for a date by
+// the user and their friends, solved first, then fewest guesses, then fastest
+func GetMastermindDailyLeaderboard(db *sql.DB, userID int64, date string) ([]models.MastermindDaily, error) {
+	rows, err := db.Query(`
+		SELECT d.id, d.user_id, d.date, d.status, d.guess_count, d.duration_ms, d.started_at, d.completed_at,
+		       u.id, u.username, u.friend_code, u.created_at, u.updated_at
+		FROM mastermind_daily d
+		JOIN users u ON u.id = d.user_id
+		WHERE d.date = ? AND d.status != 'active'
+		  AND (d.user_id = ? OR d.user_id IN (SELECT friend_id FROM friendships WHERE user_id = ?))
+		ORDER BY d.status = 'solved' DESC, d.guess_count ASC, d.duration_ms ASC, d.completed_at ASC
+	`, date, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.MastermindDaily
+	for rows.Next() {
+		var daily models.MastermindDaily
+		var user models.User
+		var completedAt sql.NullTime
+		err := rows.Scan(
+			&daily.ID, &daily.UserID, &daily.Date, &daily.Status, &daily.GuessCount,
+			&daily.DurationMs, &daily.StartedAt, &completedAt,
+			&user.ID, &user.Username, &user.FriendCode, &user.CreatedAt, &user.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if completedAt.Valid {
+			daily.CompletedAt = &completedAt.Time
+		}
+		daily.User = &user
+		entries = append(entries, daily)
+	}
+
+	return entries, nil
+}
@@ -2,16 +2,17 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"altech/internal/models"
 )
 
-func CreateMemoryGame(db *sql.DB, player1ID, player2ID int64, boardSize, boardJSON, matchedJSON string) (*models.MemoryGame, error) {
+func CreateMemoryGame(db *sql.DB, player1ID, player2ID int64, boardSize string, matchSize int, theme, boardJSON, matchedJSON string) (*models.MemoryGame, error) {
 	result, err := db.Exec(`
-		INSERT INTO memory_games (player1_id, player2_id, current_turn, status, board_size, board, matched)
-		VALUES (?, ?, ?, 'active', ?, ?, ?)
-	`, player1ID, player2ID, player1ID, boardSize, boardJSON, matchedJSON)
+		INSERT INTO memory_games (player1_id, player2_id, current_turn, status, board_size, match_size, theme, board, matched)
+		VALUES (?, ?, ?, 'active', ?, ?, ?, ?, ?)
+	`, player1ID, player2ID, player1ID, boardSize, matchSize, theme, boardJSON, matchedJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -29,11 +30,11 @@ func GetMemoryGame(db *sql.DB, gameID int64) (*models.MemoryGame, error) {
 	var winnerID sql.NullInt64
 
 	err := db.QueryRow(`
-		SELECT id, player1_id, player2_id, current_turn, status, winner_id, board_size, board, matched, player1_score, player2_score, created_at, updated_at
+		SELECT id, player1_id, player2_id, current_turn, status, winner_id, board_size, match_size, theme, board, matched, player1_score, player2_score, created_at, updated_at
 		FROM memory_games WHERE id = ?
 	`, gameID).Scan(
 		&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn,
-		&game.Status, &winnerID, &game.BoardSize, &game.Board, &game.Matched,
+		&game.Status, &winnerID, &game.BoardSize, &game.MatchSize, &game.Theme, &game.Board, &game.Matched,
 		&game.Player1Score, &game.Player2Score,
 		&game.CreatedAt, &game.UpdatedAt,
 	)
@@ -57,7 +58,7 @@ func GetMemoryGame(db *sql.DB, gameID int64) (*models.MemoryGame, error) {
 
 func GetMemoryGamesForUser(db *sql.DB, userID int64) ([]models.MemoryGame, error) {
 	rows, err := db.Query(`
-		SELECT g.id, g.player1_id, g.player2_id, g.current_turn, g.status, g.winner_id, g.board_size, g.board, g.matched, g.player1_score, g.player2_score, g.created_at, g.updated_at
+		SELECT g.id, g.player1_id, g.player2_id, g.current_turn, g.status, g.winner_id, g.board_size, g.match_size, g.theme, g.board, g.matched, g.player1_score, g.player2_score, g.created_at, g.updated_at
 		FROM memory_games g
 		WHERE g.player1_id = ? OR g.player2_id = ?
 		ORDER BY g.updated_at DESC
@@ -74,7 +75,7 @@ func GetMemoryGamesForUser(db *sql.DB, userID int64) ([]models.MemoryGame, error
 
 		err := rows.Scan(
 			&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn,
-			&game.Status, &winnerID, &game.BoardSize, &game.Board, &game.Matched,
+			&game.Status, &winnerID, &game.BoardSize, &game.MatchSize, &game.Theme, &game.Board, &game.Matched,
 			&game.Player1Score, &game.Player2Score,
 			&game.CreatedAt, &game.UpdatedAt,
 		)
@@ -105,10 +106,15 @@ func UpdateMemoryGame(db *sql.DB, game *models.MemoryGame) error {
 }
 
 func CreateMemoryMove(db *sql.DB, move *models.MemoryMove) (*models.MemoryMove, error) {
+	reveals, err := json.Marshal(move.Reveals)
+	if err != nil {
+		return nil, err
+	}
+
 	result, err := db.Exec(`
-		INSERT INTO memory_moves (game_id, user_id, row1, col1, row2, col2, tile1, tile2, matched)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`, move.GameID, move.UserID, move.Row1, move.Col1, move.Row2, move.Col2, move.Tile1, move.Tile2, move.Matched)
+		INSERT INTO memory_moves (game_id, user_id, row1, col1, row2, col2, tile1, tile2, reveals, matched)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, move.GameID, move.UserID, move.Row1, move.Col1, move.Row2, move.Col2, move.Tile1, move.Tile2, string(reveals), move.Matched)
 	if err != nil {
 		return nil, err
 	}
@@ -124,7 +130,7 @@ func CreateMemoryMove(db *sql.DB, move *models.MemoryMove) (*models.MemoryMove,
 
 func GetMemoryMoves(db *sql.DB, gameID int64) ([]models.MemoryMove, error) {
 	rows, err := db.Query(`
-		SELECT id, game_id, user_id, row1, col1, row2, col2, tile1, tile2, matched, created_at
+		SELECT id, game_id, user_id, row1, col1, row2, col2, tile1, tile2, reveals, matched, created_at
 		FROM memory_moves
 		WHERE game_id = ?
 		ORDER BY id DESC
@@ -137,12 +143,220 @@ func GetMemoryMoves(db *sql.DB, gameID int64) ([]models.MemoryMove, error) {
 	var moves []models.MemoryMove
 	for rows.Next() {
 		var m models.MemoryMove
-		err := rows.Scan(&m.ID, &m.GameID, &m.UserID, &m.Row1, &m.Col1, &m.Row2, &m.Col2, &m.Tile1, &m.Tile2, &m.Matched, &m.CreatedAt)
+		var reveals string
+		err := rows.Scan(&m.ID, &m.GameID, &m.UserID, &m.Row1, &m.Col1, &m.Row2, &m.Col2, &m.Tile1, &m.Tile2, &reveals, &m.Matched, &m.CreatedAt)
 		if err != nil {
 			return nil, err
 		}
+
+		// Moves from before reveals were stored only have the two tiles
+		if reveals == "" || json.Unmarshal([]byte(reveals), &m.Reveals) != nil {
+			m.Reveals = []models.MemoryReveal{
+				{Row: m.Row1, Col: m.Col1, Tile: m.Tile1},
+				{Row: m.Row2, Col: m.Col2, Tile: m.Tile2},
+			}
+		}
 		moves = append(moves, m)
 	}
 
 	return moves, nil
 }
+
+func CreateMemorySoloGame(db *sql.DB, userID int64, boardSize string, matchSize int, theme, boardJSON, matchedJSON string) (*models.MemorySoloGame, error) {
+	result, err := db.Exec(`
+		INSERT INTO memory_solo_games (user_id, status, board_size, match_size, theme, board, matched)
+		VALUES (?, 'active', ?, ?, ?, ?, ?)
+	`, userID, boardSize, matchSize, theme, boardJSON, matchedJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return GetMemorySoloGame(db, id)
+}
+
+const memorySoloColumns = `id, user_id, status, board_size, match_size, theme, board, matched, flips, duration_ms, started_at, completed_at, created_at, updated_at`
+
+func scanMemorySoloGame(row interface{ Scan(...any) error }) (*models.MemorySoloGame, error) {
+	game := &models.MemorySoloGame{}
+	var startedAt, completedAt sql.NullTime
+
+	err := row.Scan(
+		&game.ID, &game.UserID, &game.Status, &game.BoardSize, &game.MatchSize, &game.Theme,
+		&game.Board, &game.Matched, &game.Flips, &game.DurationMs,
+		&startedAt, &completedAt, &game.CreatedAt, &game.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if startedAt.Valid {
+		game.StartedAt = &startedAt.Time
+	}
+	if completedAt.Valid {
+		game.CompletedAt = &completedAt.Time
+	}
+	return game, nil
+}
+
+func GetMemorySoloGame(db *sql.DB, gameID int64) (*models.MemorySoloGame, error) {
+	game, err := scanMemorySoloGame(db.QueryRow(`
+		SELECT `+memorySoloColumns+`
+		FROM memory_solo_games WHERE id = ?
+	`, gameID))
+	if err == sql.ErrNoRows {
+		return nil, ErrGameNotFound
+	}
+	return game, err
+}
+
+func GetMemorySoloGamesForUser(db *sql.DB, userID int64) ([]models.MemorySoloGame, error) {
+	rows, err := db.Query(`
+		SELECT `+memorySoloColumns+`
+		FROM memory_solo_games
+		WHERE user_id = ?
+		ORDER BY updated_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var games []models.MemorySoloGame
+	for rows.Next() {
+		game, err := scanMemorySoloGame(rows)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, *game)
+	}
+
+	return games, nil
+}
+
+func UpdateMemorySoloGame(db *sql.DB, game *models.MemorySoloGame) error {
+	_, err := db.Exec(`
+		UPDATE memory_solo_games
+		SET status = ?, matched = ?, flips = ?, duration_ms = ?, started_at = ?, completed_at = ?, updated_at = ?
+		WHERE id = ?
+	`, game.Status, game.Matched, game.Flips, game.DurationMs, game.StartedAt, game.CompletedAt, time.Now(), game.ID)
+	return err
+}
+
+func CreateMemorySoloMove(db *sql.DB, move *models.MemorySoloMove) error {
+	reveals, err := json.Marshal(move.Reveals)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.Exec(`
+		INSERT INTO memory_solo_moves (game_id, reveals, matched, elapsed_ms, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, move.GameID, string(reveals), move.Matched, move.ElapsedMs, move.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	move.ID, err = result.LastInsertId()
+	return err
+}
+
+func GetMemorySoloMoves(db *sql.DB, gameID int64) ([]models.MemorySoloMove, error) {
+	rows, err := db.Query(`
+		SELECT id, game_id, reveals, matched, elapsed_ms, created_at
+		FROM memory_solo_moves
+		WHERE game_id = ?
+		ORDER BY id ASC
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var moves []models.MemorySoloMove
+	for rows.Next() {
+		var m models.MemorySoloMove
+		var reveals string
+		if err := rows.Scan(&m.ID, &m.GameID, &reveals, &m.Matched, &m.ElapsedMs, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(reveals), &m.Reveals); err != nil {
+			return nil, err
+		}
+		moves = append(moves, m)
+	}
+
+	return moves, nil
+}
+
+// GetMemorySoloBests returns the user's fastest time and fewest flips for
+// every board configuration they have completed
+func GetMemorySoloBests(db *sql.DB, userID int64) ([]models.MemorySoloBest, error) {
+	rows, err := db.Query(`
+		SELECT board_size, match_size, MIN(duration_ms), MIN(flips), COUNT(*)
+		FROM memory_solo_games
+		WHERE user_id = ? AND status = 'completed'
+		GROUP BY board_size, match_size
+		ORDER BY board_size, match_size
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bests []models.MemorySoloBest
+	for rows.Next() {
+		var b models.MemorySoloBest
+		if err := rows.Scan(&b.BoardSize, &b.MatchSize, &b.BestTimeMs, &b.BestFlips, &b.Completed); err != nil {
+			return nil, err
+		}
+		bests = append(bests, b)
+	}
+
+	return bests, nil
+}
+
+// GetMemorySoloLeaderboard ranks the best completed run of the user and each
+// of their friends on one board configuration, fastest first, then fewest flips
+func GetMemorySoloLeaderboard(db *sql.DB, userID int64, boardSize string, matchSize int) ([]models.MemorySoloLeaderboardEntry, error) {
+	rows, err := db.Query(`
+		SELECT b.id, b.duration_ms, b.flips, b.completed_at,
+		       u.id, u.username, u.friend_code, u.created_at, u.updated_at
+		FROM (
+			SELECT id, user_id, duration_ms, flips, completed_at,
+			       ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY duration_ms, flips, completed_at) AS n
+			FROM memory_solo_games
+			WHERE status = 'completed' AND board_size = ? AND match_size = ?
+			  AND (user_id = ? OR user_id IN (SELECT friend_id FROM friendships WHERE user_id = ?))
+		) b
+		JOIN users u ON u.id = b.user_id
+		WHERE b.n = 1
+		ORDER BY b.duration_ms, b.flips, b.completed_at
+	`, boardSize, matchSize, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.MemorySoloLeaderboardEntry
+	for rows.Next() {
+		var e models.MemorySoloLeaderboardEntry
+		var user models.User
+		err := rows.Scan(
+			&e.GameID, &e.DurationMs, &e.Flips, &e.CompletedAt,
+			&user.ID, &user.Username, &user.FriendCode, &user.CreatedAt, &user.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		e.User = &user
+		e.Rank = len(entries) + 1
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
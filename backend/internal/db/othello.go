@@ -0,0 +1,174 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"altech/internal/models"
+)
+
+func CreateOthelloGame(db *sql.DB, player1ID, player2ID int64, boardJSON string) (*models.OthelloGame, error) {
+	result, err := db.Exec(`
+		INSERT INTO othello_games (player1_id, player2_id, current_turn, status, board)
+		VALUES (?, ?, ?, 'active', ?)
+	`, player1ID, player2ID, player1ID, boardJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return GetOthelloGame(db, id)
+}
+
+func GetOthelloGame(db *sql.DB, gameID int64) (*models.OthelloGame, error) {
+	game := &models.OthelloGame{}
+	var winnerID sql.NullInt64
+
+	err := db.QueryRow(`
+		SELECT id, player1_id, player2_id, current_turn, status, winner_id, board, player1_score, player2_score, move_count, created_at, updated_at
+		FROM othello_games WHERE id = ?
+	`, gameID).Scan(
+		&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn,
+		&game.Status, &winnerID, &game.Board,
+		&game.Player1Score, &game.Player2Score, &game.MoveCount, &game.CreatedAt, &game.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrGameNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if winnerID.Valid {
+		game.WinnerID = &winnerID.Int64
+	}
+
+	// Load player info
+	game.Player1, _ = GetUserByID(db, game.Player1ID)
+	game.Player2, _ = GetUserByID(db, game.Player2ID)
+
+	return game, nil
+}
+
+func GetOthelloGamesForUser(db *sql.DB, userID int64) ([]models.OthelloGame, error) {
+	rows, err := db.Query(`
+		SELECT g.id, g.player1_id, g.player2_id, g.current_turn, g.status, g.winner_id, g.board, g.player1_score, g.player2_score, g.move_count, g.created_at, g.updated_at
+		FROM othello_games g
+		WHERE g.player1_id = ? OR g.player2_id = ?
+		ORDER BY g.updated_at DESC
+	`, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var games []models.OthelloGame
+	for rows.Next() {
+		var game models.OthelloGame
+		var winnerID sql.NullInt64
+
+		err := rows.Scan(
+			&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn,
+			&game.Status, &winnerID, &game.Board,
+			&game.Player1Score, &game.Player2Score, &game.MoveCount, &game.CreatedAt, &game.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if winnerID.Valid {
+			game.WinnerID = &winnerID.Int64
+		}
+
+		game.Player1, _ = GetUserByID(db, game.Player1ID)
+		game.Player2, _ = GetUserByID(db, game.Player2ID)
+
+		games = append(games, game)
+	}
+
+	return games, nil
+}
+
+// UpdateOthelloGame saves a game without a move, as on resigning. It
+// fails with ErrGameChanged if a move was played since the game was loaded.
+func UpdateOthelloGame(db *sql.DB, game *models.OthelloGame) error {
+	return updateOthelloGame(db, game, 0)
+}
+
+// PlayOthelloMove records a move, along with the opponent's pass when they
+// are left without one, and saves the game in one transaction. Moves are
+// numbered from the game's move count. It fails with ErrGameChanged if
+// another move was played since the game was loaded.
+func PlayOthelloMove(db *sql.DB, game *models.OthelloGame, moves ...*models.OthelloMove) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := updateOthelloGame(tx, game, len(moves)); err != nil {
+		return err
+	}
+	for i, move := range moves {
+		move.MoveNumber = game.MoveCount + i + 1
+		if err := createOthelloMove(tx, move); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	game.MoveCount += len(moves)
+	return nil
+}
+
+func updateOthelloGame(db execer, game *models.OthelloGame, played int) error {
+	return checkGameUpdated(db.Exec(`
+		UPDATE othello_games
+		SET current_turn = ?, status = ?, winner_id = ?, board = ?, player1_score = ?, player2_score = ?, move_count = move_count + ?, updated_at = ?
+		WHERE id = ? AND move_count = ?
+	`, game.CurrentTurn, game.Status, game.WinnerID, game.Board, game.Player1Score, game.Player2Score, played, time.Now(), game.ID, game.MoveCount))
+}
+
+func createOthelloMove(db execer, move *models.OthelloMove) error {
+	result, err := db.Exec(`
+		INSERT INTO othello_moves (game_id, user_id, move_number, passed, row, col, flipped)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, move.GameID, move.UserID, move.MoveNumber, move.Passed, move.Row, move.Col, move.Flipped)
+	if err != nil {
+		return err
+	}
+
+	move.ID, err = result.LastInsertId()
+	return err
+}
+
+func GetOthelloMoves(db *sql.DB, gameID int64) ([]models.OthelloMove, error) {
+	rows, err := db.Query(`
+		SELECT id, game_id, user_id, move_number, passed, row, col, flipped, created_at
+		FROM othello_moves
+		WHERE game_id = ?
+		ORDER BY move_number ASC
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var moves []models.OthelloMove
+	for rows.Next() {
+		var m models.OthelloMove
+		err := rows.Scan(&m.ID, &m.GameID, &m.UserID, &m.MoveNumber, &m.Passed, &m.Row, &m.Col, &m.Flipped, &m.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		moves = append(moves, m)
+	}
+
+	return moves, nil
+}
@@ -0,0 +1,73 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+)
+
+var ErrRematchExists = errors.New("rematch already exists")
+
+// ClaimRematch reserves the rematch of a game before it is created, so two
+// players asking at once cannot both start one
+func ClaimRematch(db *sql.DB, gameType string, previousGameID int64) error {
+	result, err := db.Exec(`
+		INSERT OR IGNORE INTO rematches (game_type, previous_game_id)
+		VALUES (?, ?)
+	`, gameType, previousGameID)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrRematchExists
+	}
+	return nil
+}
+
+func SetRematchGame(db *sql.DB, gameType string, previousGameID, gameID int64) error {
+	_, err := db.Exec(`
+		UPDATE rematches SET game_id = ?
+		WHERE game_type = ? AND previous_game_id = ?
+	`, gameID, gameType, previousGameID)
+	return err
+}
+
+// ReleaseRematch drops a claim whose game could not be created
+func ReleaseRematch(db *sql.DB, gameType string, previousGameID int64) error {
+	_, err := db.Exec(`
+		DELETE FROM rematches
+		WHERE game_type = ? AND previous_game_id = ? AND game_id IS NULL
+	`, gameType, previousGameID)
+	return err
+}
+
+// GetRematchOf returns the ID of the rematch of a game. The ID is 0 when no
+// rematch has been asked for, or while one is still being created.
+func GetRematchOf(db *sql.DB, gameType string, gameID int64) (int64, error) {
+	var rematchID sql.NullInt64
+	err := db.QueryRow(`
+		SELECT game_id FROM rematches
+		WHERE game_type = ? AND previous_game_id = ?
+	`, gameType, gameID).Scan(&rematchID)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return rematchID.Int64, err
+}
+
+// GetPreviousGame returns the ID of the game a rematch was made from, or 0
+func GetPreviousGame(db *sql.DB, gameType string, gameID int64) (int64, error) {
+	var previousID int64
+	err := db.QueryRow(`
+		SELECT previous_game_id FROM rematches
+		WHERE game_type = ? AND game_id = ?
+	`, gameType, gameID).Scan(&previousID)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return previousID, err
+}
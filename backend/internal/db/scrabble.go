@@ -11,6 +11,7 @@ import (
 var (
 	ErrGameNotFound = errors.New("game not found")
 	ErrNotInGame    = errors.New("not a player in this game")
+	ErrGameChanged  = errors.New("game has changed since it was loaded")
 )
 
 func CreateScrabbleGame(db *sql.DB, player1ID, player2ID int64, tileBag, boardState string) (*models.ScrabbleGame, error) {
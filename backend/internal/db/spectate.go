@@ -0,0 +1,93 @@
+package db
+
+import (
+	"database/sql"
+
+	"altech/internal/models"
+)
+
+// SetGameWatchable opens a game to one player's friends, or closes it to
+// them. Each player only decides for their own friends. The row goes once
+// neither player has the game open.
+func SetGameWatchable(db *sql.DB, gameType string, gameID, player1ID, player2ID, userID int64, watchable bool) error {
+	column := "player1_watchable"
+	if userID == player2ID {
+		column = "player2_watchable"
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT OR IGNORE INTO watchable_games (game_type, game_id, player1_id, player2_id)
+		VALUES (?, ?, ?, ?)
+	`, gameType, gameID, player1ID, player2ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE watchable_games SET `+column+` = ?
+		WHERE game_type = ? AND game_id = ?
+	`, watchable, gameType, gameID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM watchable_games
+		WHERE game_type = ? AND game_id = ? AND player1_watchable = 0 AND player2_watchable = 0
+	`, gameType, gameID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetGameWatchable reports whether each player has opened a game to their
+// friends
+func GetGameWatchable(db *sql.DB, gameType string, gameID int64) (player1, player2 bool, err error) {
+	err = db.QueryRow(`
+		SELECT player1_watchable, player2_watchable FROM watchable_games
+		WHERE game_type = ? AND game_id = ?
+	`, gameType, gameID).Scan(&player1, &player2)
+	if err == sql.ErrNoRows {
+		return false, false, nil
+	}
+	return player1, player2, err
+}
+
+// GetWatchableGamesForUser returns the games friends have opened to the user,
+// newest first, leaving out games the user is playing in
+func GetWatchableGamesForUser(db *sql.DB, userID int64) ([]models.WatchableGame, error) {
+	rows, err := db.Query(`
+		SELECT game_type, game_id, player1_id, player2_id, player1_watchable, player2_watchable, created_at
+		FROM watchable_games
+		WHERE player1_id != ? AND player2_id != ?
+		  AND ((player1_watchable = 1 AND player1_id IN (SELECT friend_id FROM friendships WHERE user_id = ?))
+		    OR (player2_watchable = 1 AND player2_id IN (SELECT friend_id FROM friendships WHERE user_id = ?)))
+		ORDER BY created_at DESC, game_id DESC
+	`, userID, userID, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var games []models.WatchableGame
+	for rows.Next() {
+		var g models.WatchableGame
+		if err := rows.Scan(&g.GameType, &g.GameID, &g.Player1ID, &g.Player2ID, &g.Player1Watchable, &g.Player2Watchable, &g.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		g.Player1, _ = GetUserByID(db, g.Player1ID)
+		g.Player2, _ = GetUserByID(db, g.Player2ID)
+
+		games = append(games, g)
+	}
+	return games, rows.Err()
+}
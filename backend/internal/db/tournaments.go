@@ -0,0 +1,316 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+
+	"altech/internal/models"
+)
+
+var ErrTournamentNotFound = errors.New("tournament not found")
+
+const tournamentColumns = `id, organizer_id, name, game_type, format, settings, status,
+	current_round, total_rounds, winner_id, created_at, updated_at`
+
+const tournamentMatchColumns = `id, tournament_id, round, position, player1_id, player2_id, game_id,
+	status, winner_id, player1_score, player2_score, replays, created_at, updated_at`
+
+// CreateTournament stores a tournament with its participants, in seed order,
+// and every match of its schedule in one go
+func CreateTournament(db *sql.DB, t *models.Tournament, participantIDs []int64, matches []models.TournamentMatch) (*models.Tournament, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		INSERT INTO tournaments (organizer_id, name, game_type, format, settings, total_rounds)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, t.OrganizerID, t.Name, t.GameType, t.Format, string(t.Settings), t.TotalRounds)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, userID := range participantIDs {
+		_, err := tx.Exec(`
+			INSERT INTO tournament_participants (tournament_id, user_id, seed)
+			VALUES (?, ?, ?)
+		`, id, userID, i+1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, m := range matches {
+		_, err := tx.Exec(`
+			INSERT INTO tournament_matches (tournament_id, round, position, player1_id, player2_id, status, winner_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, id, m.Round, m.Position, m.Player1ID, m.Player2ID, m.Status, m.WinnerID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return GetTournament(db, id)
+}
+
+func scanTournament(db *sql.DB, row interface{ Scan(...any) error }) (*models.Tournament, error) {
+	t := &models.Tournament{}
+	var settings string
+	var winnerID sql.NullInt64
+
+	err := row.Scan(
+		&t.ID, &t.OrganizerID, &t.Name, &t.GameType, &t.Format, &settings, &t.Status,
+		&t.CurrentRound, &t.TotalRounds, &winnerID, &t.CreatedAt, &t.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Settings = []byte(settings)
+	if winnerID.Valid {
+		t.WinnerID = &winnerID.Int64
+	}
+
+	t.Organizer, _ = GetUserByID(db, t.OrganizerID)
+
+	return t, nil
+}
+
+func GetTournament(db *sql.DB, id int64) (*models.Tournament, error) {
+	t, err := scanTournament(db, db.QueryRow(`
+		SELECT `+tournamentColumns+`
+		FROM tournaments WHERE id = ?
+	`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrTournamentNotFound
+	}
+	return t, err
+}
+
+// GetTournamentsForUser returns the tournaments a user organises or plays
+// in, newest first
+func GetTournamentsForUser(db *sql.DB, userID int64) ([]models.Tournament, error) {
+	rows, err := db.Query(`
+		SELECT `+tournamentColumns+`
+		FROM tournaments
+		WHERE organizer_id = ?
+		   OR id IN (SELECT tournament_id FROM tournament_participants WHERE user_id = ?)
+		ORDER BY created_at DESC, id DESC
+	`, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tournaments []models.Tournament
+	for rows.Next() {
+		t, err := scanTournament(db, rows)
+		if err != nil {
+			return nil, err
+		}
+		tournaments = append(tournaments, *t)
+	}
+	return tournaments, rows.Err()
+}
+
+// GetTournamentParticipants returns participants in seed order
+func GetTournamentParticipants(db *sql.DB, tournamentID int64) ([]models.TournamentParticipant, error) {
+	rows, err := db.Query(`
+		SELECT user_id, seed FROM tournament_participants
+		WHERE tournament_id = ?
+		ORDER BY seed
+	`, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var participants []models.TournamentParticipant
+	for rows.Next() {
+		var p models.TournamentParticipant
+		if err := rows.Scan(&p.UserID, &p.Seed); err != nil {
+			return nil, err
+		}
+		participants = append(participants, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range participants {
+		participants[i].User, _ = GetUserByID(db, participants[i].UserID)
+	}
+	return participants, nil
+}
+
+// InTournament reports whether a user organises or plays in a tournament
+func InTournament(db *sql.DB, tournamentID, userID int64) (bool, error) {
+	var count int
+	err := db.QueryRow(`
+		SELECT COUNT(*) FROM tournaments t
+		WHERE t.id = ?
+		  AND (t.organizer_id = ?
+		    OR EXISTS (SELECT 1 FROM tournament_participants p WHERE p.tournament_id = t.id AND p.user_id = ?))
+	`, tournamentID, userID, userID).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetTournamentMatches returns every match, by round then position
+func GetTournamentMatches(db *sql.DB, tournamentID int64) ([]models.TournamentMatch, error) {
+	rows, err := db.Query(`
+		SELECT `+tournamentMatchColumns+`
+		FROM tournament_matches
+		WHERE tournament_id = ?
+		ORDER BY round, position
+	`, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matches []models.TournamentMatch
+	for rows.Next() {
+		var m models.TournamentMatch
+		var player1ID, player2ID, gameID, winnerID sql.NullInt64
+		var player1Score, player2Score sql.NullFloat64
+
+		err := rows.Scan(
+			&m.ID, &m.TournamentID, &m.Round, &m.Position, &player1ID, &player2ID, &gameID,
+			&m.Status, &winnerID, &player1Score, &player2Score, &m.Replays, &m.CreatedAt, &m.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if player1ID.Valid {
+			m.Player1ID = &player1ID.Int64
+		}
+		if player2ID.Valid {
+			m.Player2ID = &player2ID.Int64
+		}
+		if gameID.Valid {
+			m.GameID = &gameID.Int64
+		}
+		if winnerID.Valid {
+			m.WinnerID = &winnerID.Int64
+		}
+		if player1Score.Valid && player2Score.Valid {
+			m.Scores = []float64{player1Score.Float64, player2Score.Float64}
+		}
+
+		matches = append(matches, m)
+	}
+	return matches, rows.Err()
+}
+
+// ClaimTournamentMatch marks a pending match as having its game created, so
+// two requests advancing the tournament at once cannot both create one. It
+// reports whether this caller got the claim. A claim left behind for over a
+// minute, say by a server that stopped midway, can be taken again.
+func ClaimTournamentMatch(db *sql.DB, matchID int64) (bool, error) {
+	result, err := db.Exec(`
+		UPDATE tournament_matches SET status = 'creating', updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND (status = 'pending' OR (status = 'creating' AND updated_at < datetime('now', '-1 minute')))
+	`, matchID)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+func SetTournamentMatchGame(db *sql.DB, matchID, gameID int64) error {
+	_, err := db.Exec(`
+		UPDATE tournament_matches SET game_id = ?, status = 'active', updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`, gameID, matchID)
+	return err
+}
+
+// ReleaseTournamentMatch returns a claimed match whose game could not be
+// created to pending, so the next request tries again
+func ReleaseTournamentMatch(db *sql.DB, matchID int64) error {
+	_, err := db.Exec(`
+		UPDATE tournament_matches SET status = 'pending', updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND status = 'creating'
+	`, matchID)
+	return err
+}
+
+// CompleteTournamentMatch records the result of a match's game. It reports
+// whether the match was still active, so a result is only applied once.
+func CompleteTournamentMatch(db *sql.DB, matchID int64, winnerID *int64, scores []float64) (bool, error) {
+	var player1Score, player2Score sql.NullFloat64
+	if len(scores) == 2 {
+		player1Score = sql.NullFloat64{Float64: scores[0], Valid: true}
+		player2Score = sql.NullFloat64{Float64: scores[1], Valid: true}
+	}
+
+	result, err := db.Exec(`
+		UPDATE tournament_matches
+		SET status = 'completed', winner_id = ?, player1_score = ?, player2_score = ?,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND status = 'active'
+	`, winnerID, player1Score, player2Score, matchID)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// ReplayTournamentMatch sends a drawn elimination match back to pending so a
+// new game is created for it
+func ReplayTournamentMatch(db *sql.DB, matchID, gameID int64) error {
+	_, err := db.Exec(`
+		UPDATE tournament_matches
+		SET status = 'pending', game_id = NULL, replays = replays + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND status = 'active' AND game_id = ?
+	`, matchID, gameID)
+	return err
+}
+
+// SetTournamentMatchPlayer seats a player in a match as player 1 or 2
+func SetTournamentMatchPlayer(db *sql.DB, tournamentID int64, round, position, slot int, userID int64) error {
+	column := "player1_id"
+	if slot == 2 {
+		column = "player2_id"
+	}
+	_, err := db.Exec(`
+		UPDATE tournament_matches SET `+column+` = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE tournament_id = ? AND round = ? AND position = ?
+	`, userID, tournamentID, round, position)
+	return err
+}
+
+func SetTournamentRound(db *sql.DB, tournamentID int64, round int) error {
+	_, err := db.Exec(`
+		UPDATE tournaments SET current_round = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`, round, tournamentID)
+	return err
+}
+
+func CompleteTournament(db *sql.DB, tournamentID int64, winnerID *int64) error {
+	_, err := db.Exec(`
+		UPDATE tournaments SET status = 'completed', winner_id = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`, winnerID, tournamentID)
+	return err
+}
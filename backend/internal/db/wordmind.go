@@ -0,0 +1,183 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"altech/internal/models"
+)
+
+var ErrWordmindGuessExists = errors.New("guess already made")
+
+func CreateWordmindGame(db *sql.DB, player1ID, player2ID int64, maxGuesses int) (*models.WordmindGame, error) {
+	result, err := db.Exec(`
+		INSERT INTO wordmind_games (player1_id, player2_id, current_turn, status, max_guesses)
+		VALUES (?, ?, ?, 'setup', ?)
+	`, player1ID, player2ID, player1ID, maxGuesses)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return GetWordmindGame(db, id)
+}
+
+func GetWordmindGame(db *sql.DB, gameID int64) (*models.WordmindGame, error) {
+	game := &models.WordmindGame{}
+	var winnerID sql.NullInt64
+
+	err := db.QueryRow(`
+		SELECT id, player1_id, player2_id, current_turn, status, winner_id, max_guesses, created_at, updated_at
+		FROM wordmind_games WHERE id = ?
+	`, gameID).Scan(
+		&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn,
+		&game.Status, &winnerID, &game.MaxGuesses,
+		&game.CreatedAt, &game.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrGameNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if winnerID.Valid {
+		game.WinnerID = &winnerID.Int64
+	}
+
+	// Load player info
+	game.Player1, _ = GetUserByID(db, game.Player1ID)
+	game.Player2, _ = GetUserByID(db, game.Player2ID)
+
+	return game, nil
+}
+
+func GetWordmindGamesForUser(db *sql.DB, userID int64) ([]models.WordmindGame, error) {
+	rows, err := db.Query(`
+		SELECT g.id, g.player1_id, g.player2_id, g.current_turn, g.status, g.winner_id, g.max_guesses, g.created_at, g.updated_at
+		FROM wordmind_games g
+		WHERE g.player1_id = ? OR g.player2_id = ?
+		ORDER BY g.updated_at DESC
+	`, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var games []models.WordmindGame
+	for rows.Next() {
+		var game models.WordmindGame
+		var winnerID sql.NullInt64
+
+		err := rows.Scan(
+			&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn,
+			&game.Status, &winnerID, &game.MaxGuesses,
+			&game.CreatedAt, &game.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if winnerID.Valid {
+			game.WinnerID = &winnerID.Int64
+		}
+
+		game.Player1, _ = GetUserByID(db, game.Player1ID)
+		game.Player2, _ = GetUserByID(db, game.Player2ID)
+
+		games = append(games, game)
+	}
+
+	return games, nil
+}
+
+func UpdateWordmindGame(db *sql.DB, game *models.WordmindGame) error {
+	_, err := db.Exec(`
+		UPDATE wordmind_games
+		SET current_turn = ?, status = ?, winner_id = ?, updated_at = ?
+		WHERE id = ?
+	`, game.CurrentTurn, game.Status, game.WinnerID, time.Now(), game.ID)
+	return err
+}
+
+func SetWordmindSecret(db *sql.DB, gameID, userID int64, word string) error {
+	_, err := db.Exec(`
+		INSERT INTO wordmind_secrets (game_id, user_id, word)
+		VALUES (?, ?, ?)
+		ON CONFLICT(game_id, user_id) DO UPDATE SET word = excluded.word
+	`, gameID, userID, word)
+	return err
+}
+
+// GetWordmindSecret returns the user's secret word, or "" if not set yet
+func GetWordmindSecret(db *sql.DB, gameID, userID int64) (string, error) {
+	var word string
+	err := db.QueryRow(`
+		SELECT word FROM wordmind_secrets
+		WHERE game_id = ? AND user_id = ?
+	`, gameID, userID).Scan(&word)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return word, err
+}
+
+func BothWordmindSecretsSet(db *sql.DB, gameID int64) (bool, error) {
+	var count int
+	err := db.QueryRow(`
+		SELECT COUNT(*) FROM wordmind_secrets
+		WHERE game_id = ?
+	`, gameID).Scan(&count)
+	return count == 2, err
+}
+
+// CreateWordmindGuess records a player's guess. A second guess racing this
+// one for the same number gets ErrWordmindGuessExists.
+func CreateWordmindGuess(db *sql.DB, gameID, userID int64, word, feedback string, correct, guessNumber int) error {
+	result, err := db.Exec(`
+		INSERT OR IGNORE INTO wordmind_guesses (game_id, user_id, word, feedback, correct, guess_number)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, gameID, userID, word, feedback, correct, guessNumber)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrWordmindGuessExists
+	}
+	return nil
+}
+
+func GetWordmindGuesses(db *sql.DB, gameID, userID int64) ([]models.WordmindGuess, error) {
+	rows, err := db.Query(`
+		SELECT id, game_id, user_id, word, feedback, correct, guess_number, created_at
+		FROM wordmind_guesses
+		WHERE game_id = ? AND user_id = ?
+		ORDER BY guess_number ASC
+	`, gameID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var guesses []models.WordmindGuess
+	for rows.Next() {
+		var g models.WordmindGuess
+		err := rows.Scan(&g.ID, &g.GameID, &g.UserID, &g.Word, &g.Feedback, &g.Correct, &g.GuessNumber, &g.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		guesses = append(guesses, g)
+	}
+
+	return guesses, nil
+}
@@ -0,0 +1,253 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"altech/internal/models"
+)
+
+const yahtzeeColumns = `id, player1_id, player2_id, current_turn, status, winner_id, turn_number,
+	dice, rolls_used, player1_scorecard, player2_scorecard, player1_score, player2_score, move_count, created_at, updated_at`
+
+func CreateYahtzeeGame(db *sql.DB, player1ID, player2ID int64, scorecardJSON string) (*models.YahtzeeGame, error) {
+	result, err := db.Exec(`
+		INSERT INTO yahtzee_games (player1_id, player2_id, current_turn, status, player1_scorecard, player2_scorecard)
+		VALUES (?, ?, ?, 'active', ?, ?)
+	`, player1ID, player2ID, player1ID, scorecardJSON, scorecardJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return GetYahtzeeGame(db, id)
+}
+
+func scanYahtzeeGame(db *sql.DB, row interface{ Scan(...any) error }) (*models.YahtzeeGame, error) {
+	game := &models.YahtzeeGame{}
+	var winnerID sql.NullInt64
+
+	err := row.Scan(
+		&game.ID, &game.Player1ID, &game.Player2ID, &game.CurrentTurn, &game.Status, &winnerID,
+		&game.TurnNumber, &game.Dice, &game.RollsUsed,
+		&game.Player1Scorecard, &game.Player2Scorecard, &game.Player1Score, &game.Player2Score, &game.MoveCount,
+		&game.CreatedAt, &game.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if winnerID.Valid {
+		game.WinnerID = &winnerID.Int64
+	}
+
+	// Load player info
+	game.Player1, _ = GetUserByID(db, game.Player1ID)
+	game.Player2, _ = GetUserByID(db, game.Player2ID)
+
+	return game, nil
+}
+
+func GetYahtzeeGame(db *sql.DB, gameID int64) (*models.YahtzeeGame, error) {
+	game, err := scanYahtzeeGame(db, db.QueryRow(`
+		SELECT `+yahtzeeColumns+`
+		FROM yahtzee_games WHERE id = ?
+	`, gameID))
+	if err == sql.ErrNoRows {
+		return nil, ErrGameNotFound
+	}
+	return game, err
+}
+
+func GetYahtzeeGamesForUser(db *sql.DB, userID int64) ([]models.YahtzeeGame, error) {
+	rows, err := db.Query(`
+		SELECT `+yahtzeeColumns+`
+		FROM yahtzee_games
+		WHERE player1_id = ? OR player2_id = ?
+		ORDER BY updated_at DESC
+	`, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var games []models.YahtzeeGame
+	for rows.Next() {
+		game, err := scanYahtzeeGame(db, rows)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, *game)
+	}
+
+	return games, nil
+}
+
+// UpdateYahtzeeGame saves a game without a roll or entry, as on resigning.
+// It fails with ErrGameChanged if the dice were rolled or scored since the
+// game was loaded.
+func UpdateYahtzeeGame(db *sql.DB, game *models.YahtzeeGame) error {
+	return updateYahtzeeGame(db, game, 0)
+}
+
+// SaveYahtzeeRoll records a roll and saves the game in one transaction. It
+// fails with ErrGameChanged if the dice were rolled or scored since the game
+// was loaded.
+func SaveYahtzeeRoll(db *sql.DB, game *models.YahtzeeGame, roll *models.YahtzeeRoll) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := updateYahtzeeGame(tx, game, 1); err != nil {
+		return err
+	}
+	if err := createYahtzeeRoll(tx, roll); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	game.MoveCount++
+	return nil
+}
+
+// SaveYahtzeeEntry records a scored box and saves the game in one
+// transaction. It fails with ErrGameChanged if the dice were rolled or
+// scored since the game was loaded.
+func SaveYahtzeeEntry(db *sql.DB, game *models.YahtzeeGame, entry *models.YahtzeeEntry) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := updateYahtzeeGame(tx, game, 1); err != nil {
+		return err
+	}
+	if err := createYahtzeeEntry(tx, entry); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	game.MoveCount++
+	return nil
+}
+
+func updateYahtzeeGame(db execer, game *models.YahtzeeGame, played int) error {
+	return checkGameUpdated(db.Exec(`
+		UPDATE yahtzee_games
+		SET current_turn = ?, status = ?, winner_id = ?, turn_number = ?, dice = ?, rolls_used = ?,
+			player1_scorecard = ?, player2_scorecard = ?, player1_score = ?, player2_score = ?, move_count = move_count + ?, updated_at = ?
+		WHERE id = ? AND move_count = ?
+	`, game.CurrentTurn, game.Status, game.WinnerID, game.TurnNumber, game.Dice, game.RollsUsed,
+		game.Player1Scorecard, game.Player2Scorecard, game.Player1Score, game.Player2Score, played, time.Now(), game.ID, game.MoveCount))
+}
+
+func createYahtzeeRoll(db execer, roll *models.YahtzeeRoll) error {
+	held, err := json.Marshal(roll.Held)
+	if err != nil {
+		return err
+	}
+	dice, err := json.Marshal(roll.Dice)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.Exec(`
+		INSERT INTO yahtzee_rolls (game_id, user_id, turn_number, roll_number, held, dice)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, roll.GameID, roll.UserID, roll.TurnNumber, roll.RollNumber, string(held), string(dice))
+	if err != nil {
+		return err
+	}
+
+	roll.ID, err = result.LastInsertId()
+	return err
+}
+
+func GetYahtzeeRolls(db *sql.DB, gameID int64) ([]models.YahtzeeRoll, error) {
+	rows, err := db.Query(`
+		SELECT id, game_id, user_id, turn_number, roll_number, held, dice, created_at
+		FROM yahtzee_rolls
+		WHERE game_id = ?
+		ORDER BY turn_number ASC, roll_number ASC
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rolls []models.YahtzeeRoll
+	for rows.Next() {
+		var r models.YahtzeeRoll
+		var held, dice string
+		if err := rows.Scan(&r.ID, &r.GameID, &r.UserID, &r.TurnNumber, &r.RollNumber, &held, &dice, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(held), &r.Held); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(dice), &r.Dice); err != nil {
+			return nil, err
+		}
+		rolls = append(rolls, r)
+	}
+
+	return rolls, nil
+}
+
+func createYahtzeeEntry(db execer, entry *models.YahtzeeEntry) error {
+	dice, err := json.Marshal(entry.Dice)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.Exec(`
+		INSERT INTO yahtzee_entries (game_id, user_id, turn_number, category, dice, points, bonus)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, entry.GameID, entry.UserID, entry.TurnNumber, entry.Category, string(dice), entry.Points, entry.Bonus)
+	if err != nil {
+		return err
+	}
+
+	entry.ID, err = result.LastInsertId()
+	return err
+}
+
+func GetYahtzeeEntries(db *sql.DB, gameID int64) ([]models.YahtzeeEntry, error) {
+	rows, err := db.Query(`
+		SELECT id, game_id, user_id, turn_number, category, dice, points, bonus, created_at
+		FROM yahtzee_entries
+		WHERE game_id = ?
+		ORDER BY turn_number ASC
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.YahtzeeEntry
+	for rows.Next() {
+		var e models.YahtzeeEntry
+		var dice string
+		if err := rows.Scan(&e.ID, &e.GameID, &e.UserID, &e.TurnNumber, &e.Category, &dice, &e.Points, &e.Bonus, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(dice), &e.Dice); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
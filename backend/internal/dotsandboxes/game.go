@@ -0,0 +1,147 @@
+package dotsandboxes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"altech/internal/models"
+)
+
+const (
+	MinBoxes         = 2
+	MaxBoxes         = 9
+	DefaultBoardSize = "4x4"
+)
+
+// Players; an edge or box holds the number of the player who took it
+const (
+	Nobody  = 0
+	Player1 = 1
+	Player2 = 2
+)
+
+// Edge orientations
+const (
+	Horizontal = "h"
+	Vertical   = "v"
+)
+
+// ParseBoardSize validates and returns rows, cols of boxes for a "RxC"
+// board size string, each between 2 and 9.
+func ParseBoardSize(size string) (int, int, error) {
+	parts := strings.Split(size, "x")
+	if len(parts) != 2 {
+		return 0, 0, errors.New("invalid board size: must look like 4x4")
+	}
+	rows, err1 := strconv.Atoi(parts[0])
+	cols, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil {
+		return 0, 0, errors.New("invalid board size: must look like 4x4")
+	}
+	if rows < MinBoxes || rows > MaxBoxes || cols < MinBoxes || cols > MaxBoxes {
+		return 0, 0, fmt.Errorf("invalid board size: rows and columns must be between %d and %d boxes", MinBoxes, MaxBoxes)
+	}
+	return rows, cols, nil
+}
+
+// NewBoard creates an empty board of rows x cols boxes. There are rows+1
+// rows of horizontal edges with cols each, and rows rows of vertical edges
+// with cols+1 each.
+func NewBoard(rows, cols int) *models.DotsAndBoxesBoard {
+	board := &models.DotsAndBoxesBoard{
+		Horizontal: make([][]int, rows+1),
+		Vertical:   make([][]int, rows),
+		Boxes:      make([][]int, rows),
+	}
+	for r := range board.Horizontal {
+		board.Horizontal[r] = make([]int, cols)
+	}
+	for r := 0; r < rows; r++ {
+		board.Vertical[r] = make([]int, cols+1)
+		board.Boxes[r] = make([]int, cols)
+	}
+	return board
+}
+
+// DrawEdge draws an edge for player and returns the boxes it completed
+func DrawEdge(board *models.DotsAndBoxesBoard, orientation string, row, col, player int) ([]models.DotsAndBoxesBox, error) {
+	var edges [][]int
+	switch orientation {
+	case Horizontal:
+		edges = board.Horizontal
+	case Vertical:
+		edges = board.Vertical
+	default:
+		return nil, errors.New("invalid orientation: must be h or v")
+	}
+
+	if row < 0 || row >= len(edges) || col < 0 || col >= len(edges[row]) {
+		return nil, errors.New("edge out of bounds")
+	}
+	if edges[row][col] != Nobody {
+		return nil, errors.New("edge is already drawn")
+	}
+	edges[row][col] = player
+
+	// The boxes on either side of the edge
+	var sides []models.DotsAndBoxesBox
+	if orientation == Horizontal {
+		sides = []models.DotsAndBoxesBox{{Row: row - 1, Col: col}, {Row: row, Col: col}}
+	} else {
+		sides = []models.DotsAndBoxesBox{{Row: row, Col: col - 1}, {Row: row, Col: col}}
+	}
+
+	var completed []models.DotsAndBoxesBox
+	for _, b := range sides {
+		if b.Row < 0 || b.Row >= len(board.Boxes) || b.Col < 0 || b.Col >= len(board.Boxes[0]) {
+			continue
+		}
+		if boxClosed(board, b.Row, b.Col) {
+			board.Boxes[b.Row][b.Col] = player
+			completed = append(completed, b)
+		}
+	}
+	return completed, nil
+}
+
+func boxClosed(board *models.DotsAndBoxesBoard, row, col int) bool {
+	return board.Horizontal[row][col] != Nobody && board.Horizontal[row+1][col] != Nobody &&
+		board.Vertical[row][col] != Nobody && board.Vertical[row][col+1] != Nobody
+}
+
+// CountBoxes returns how many boxes each player owns
+func CountBoxes(board *models.DotsAndBoxesBoard) (player1, player2 int) {
+	for _, row := range board.Boxes {
+		for _, owner := range row {
+			switch owner {
+			case Player1:
+				player1++
+			case Player2:
+				player2++
+			}
+		}
+	}
+	return player1, player2
+}
+
+// IsComplete reports whether every box has been taken
+func IsComplete(board *models.DotsAndBoxesBoard) bool {
+	p1, p2 := CountBoxes(board)
+	return p1+p2 == len(board.Boxes)*len(board.Boxes[0])
+}
+
+// JSON helpers
+
+func BoardToJSON(board *models.DotsAndBoxesBoard) (string, error) {
+	data, err := json.Marshal(board)
+	return string(data), err
+}
+
+func BoardFromJSON(data string) (*models.DotsAndBoxesBoard, error) {
+	var board models.DotsAndBoxesBoard
+	err := json.Unmarshal([]byte(data), &board)
+	return &board, err
+}
@@ -0,0 +1,326 @@
+package gogame
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+
+	"altech/internal/models"
+)
+
+const DefaultSize = 9
+
+// Stone colours; Black is player 1 and moves first
+const (
+	Empty = 0
+	Black = 1
+	White = 2
+)
+
+// Scoring rules
+const (
+	RulesJapanese = "japanese" // Territory plus prisoners
+	RulesChinese  = "chinese"  // Territory plus stones on the board
+)
+
+// Move actions
+const (
+	ActionPlay = "play"
+	ActionPass = "pass"
+)
+
+// Reasons a game ended
+const (
+	EndScored   = "scored"
+	EndResigned = "resigned"
+)
+
+// ValidateSize checks a board size, defaulting to 9x9
+func ValidateSize(size int) (int, error) {
+	switch size {
+	case 0:
+		return DefaultSize, nil
+	case 9, 13, 19:
+		return size, nil
+	}
+	return 0, errors.New("invalid board size: must be 9, 13 or 19")
+}
+
+// ValidateRules checks a ruleset name, defaulting to Japanese
+func ValidateRules(rules string) (string, error) {
+	switch rules {
+	case "":
+		return RulesJapanese, nil
+	case RulesJapanese, RulesChinese:
+		return rules, nil
+	}
+	return "", errors.New("invalid rules: must be japanese or chinese")
+}
+
+// ValidateKomi checks the komi, defaulting to 6.5 for Japanese rules and
+// 7.5 for Chinese rules
+func ValidateKomi(komi *float64, rules string) (float64, error) {
+	if komi == nil {
+		if rules == RulesChinese {
+			return 7.5, nil
+		}
+		return 6.5, nil
+	}
+	if *komi < -50 || *komi > 50 || *komi*2 != math.Trunc(*komi*2) {
+		return 0, errors.New("invalid komi: must be a multiple of 0.5 between -50 and 50")
+	}
+	return *komi, nil
+}
+
+// Opponent returns the other colour
+func Opponent(color int) int {
+	if color == Black {
+		return White
+	}
+	return Black
+}
+
+// NewBoard creates an empty board
+func NewBoard(size int) [][]int {
+	board := make([][]int, size)
+	for r := range board {
+		board[r] = make([]int, size)
+	}
+	return board
+}
+
+func copyBoard(board [][]int) [][]int {
+	next := make([][]int, len(board))
+	for r := range board {
+		next[r] = append([]int(nil), board[r]...)
+	}
+	return next
+}
+
+func neighbours(board [][]int, p models.GoPoint) []models.GoPoint {
+	size := len(board)
+	var out []models.GoPoint
+	for _, d := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+		r, c := p.Row+d[0], p.Col+d[1]
+		if r >= 0 && r < size && c >= 0 && c < size {
+			out = append(out, models.GoPoint{Row: r, Col: c})
+		}
+	}
+	return out
+}
+
+// Group returns the stones connected to p and how many liberties they have
+func Group(board [][]int, p models.GoPoint) (stones []models.GoPoint, liberties int) {
+	color := board[p.Row][p.Col]
+	seen := map[models.GoPoint]bool{p: true}
+	libs := map[models.GoPoint]bool{}
+	stack := []models.GoPoint{p}
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		stones = append(stones, cur)
+		for _, n := range neighbours(board, cur) {
+			switch board[n.Row][n.Col] {
+			case Empty:
+				libs[n] = true
+			case color:
+				if !seen[n] {
+					seen[n] = true
+					stack = append(stack, n)
+				}
+			}
+		}
+	}
+	return stones, len(libs)
+}
+
+// Play places a stone and removes any opponent groups left without
+// liberties. It returns the new board and the number of stones captured;
+// the board passed in is not changed. Suicide is not allowed.
+func Play(board [][]int, p models.GoPoint, color int) ([][]int, int, error) {
+	size := len(board)
+	if p.Row < 0 || p.Row >= size || p.Col < 0 || p.Col >= size {
+		return nil, 0, errors.New("point out of bounds")
+	}
+	if board[p.Row][p.Col] != Empty {
+		return nil, 0, errors.New("point is already occupied")
+	}
+
+	next := copyBoard(board)
+	next[p.Row][p.Col] = color
+
+	captured := 0
+	for _, n := range neighbours(next, p) {
+		if next[n.Row][n.Col] != Opponent(color) {
+			continue
+		}
+		stones, libs := Group(next, n)
+		if libs == 0 {
+			for _, s := range stones {
+				next[s.Row][s.Col] = Empty
+			}
+			captured += len(stones)
+		}
+	}
+
+	if _, libs := Group(next, p); libs == 0 {
+		return nil, 0, errors.New("suicide is not allowed")
+	}
+	return next, captured, nil
+}
+
+// PositionKey identifies a whole-board position for superko
+func PositionKey(board [][]int) string {
+	var sb strings.Builder
+	for _, row := range board {
+		for _, v := range row {
+			sb.WriteByte(byte('0' + v))
+		}
+	}
+	return sb.String()
+}
+
+// ToggleDead marks the group at p dead, or alive again if it was marked
+func ToggleDead(board [][]int, dead []models.GoPoint, p models.GoPoint) ([]models.GoPoint, error) {
+	size := len(board)
+	if p.Row < 0 || p.Row >= size || p.Col < 0 || p.Col >= size {
+		return nil, errors.New("point out of bounds")
+	}
+	if board[p.Row][p.Col] == Empty {
+		return nil, errors.New("no stone at that point")
+	}
+
+	stones, _ := Group(board, p)
+	inGroup := make(map[models.GoPoint]bool, len(stones))
+	for _, s := range stones {
+		inGroup[s] = true
+	}
+
+	wasDead := false
+	kept := []models.GoPoint{}
+	for _, d := range dead {
+		if inGroup[d] {
+			wasDead = true
+			continue
+		}
+		kept = append(kept, d)
+	}
+	if wasDead {
+		return kept, nil
+	}
+	return append(kept, stones...), nil
+}
+
+// Score counts the game with dead stones removed. Empty regions bordered by
+// only one colour are that colour's territory. Japanese rules add prisoners
+// (captures and dead stones); Chinese rules add living stones. White gets
+// the komi.
+func Score(board [][]int, dead []models.GoPoint, rules string, komi float64, blackCaptures, whiteCaptures int) models.GoScore {
+	size := len(board)
+	score := models.GoScore{Ownership: NewBoard(size)}
+
+	// Take the dead stones off, counting them as prisoners
+	live := copyBoard(board)
+	for _, d := range dead {
+		switch live[d.Row][d.Col] {
+		case Black:
+			score.WhitePrisoners++
+		case White:
+			score.BlackPrisoners++
+		}
+		live[d.Row][d.Col] = Empty
+	}
+	score.BlackPrisoners += blackCaptures
+	score.WhitePrisoners += whiteCaptures
+
+	seen := make(map[models.GoPoint]bool)
+	for r := 0; r < size; r++ {
+		for c := 0; c < size; c++ {
+			p := models.GoPoint{Row: r, Col: c}
+			switch live[r][c] {
+			case Black:
+				score.BlackStones++
+				score.Ownership[r][c] = Black
+				continue
+			case White:
+				score.WhiteStones++
+				score.Ownership[r][c] = White
+				continue
+			}
+			if seen[p] {
+				continue
+			}
+
+			// Flood the empty region and see who borders it
+			region := []models.GoPoint{}
+			borders := map[int]bool{}
+			stack := []models.GoPoint{p}
+			seen[p] = true
+			for len(stack) > 0 {
+				cur := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				region = append(region, cur)
+				for _, n := range neighbours(live, cur) {
+					if v := live[n.Row][n.Col]; v != Empty {
+						borders[v] = true
+					} else if !seen[n] {
+						seen[n] = true
+						stack = append(stack, n)
+					}
+				}
+			}
+
+			owner := Empty
+			if borders[Black] && !borders[White] {
+				owner = Black
+				score.BlackTerritory += len(region)
+			} else if borders[White] && !borders[Black] {
+				owner = White
+				score.WhiteTerritory += len(region)
+			}
+			for _, q := range region {
+				score.Ownership[q.Row][q.Col] = owner
+			}
+		}
+	}
+
+	if rules == RulesChinese {
+		score.Black = float64(score.BlackTerritory + score.BlackStones)
+		score.White = float64(score.WhiteTerritory+score.WhiteStones) + komi
+	} else {
+		score.Black = float64(score.BlackTerritory + score.BlackPrisoners)
+		score.White = float64(score.WhiteTerritory+score.WhitePrisoners) + komi
+	}
+	return score
+}
+
+// JSON helpers
+
+func BoardToJSON(board [][]int) (string, error) {
+	data, err := json.Marshal(board)
+	return string(data), err
+}
+
+func BoardFromJSON(data string) ([][]int, error) {
+	var board [][]int
+	err := json.Unmarshal([]byte(data), &board)
+	return board, err
+}
+
+func PointsToJSON(points []models.GoPoint) (string, error) {
+	if points == nil {
+		points = []models.GoPoint{}
+	}
+	data, err := json.Marshal(points)
+	return string(data), err
+}
+
+func PointsFromJSON(data string) ([]models.GoPoint, error) {
+	points := []models.GoPoint{}
+	if data == "" {
+		return points, nil
+	}
+	err := json.Unmarshal([]byte(data), &points)
+	return points, err
+}
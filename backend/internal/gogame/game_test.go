@@ -0,0 +1,188 @@
+package gogame
+
+import (
+	"reflect"
+	"testing"
+
+	"altech/internal/models"
+)
+
+// board builds a position from rows of X (Black), O (White) and . (empty)
+func board(rows ...string) [][]int {
+	b := NewBoard(len(rows))
+	for r, row := range rows {
+		for c, ch := range row {
+			switch ch {
+			case 'X':
+				b[r][c] = Black
+			case 'O':
+				b[r][c] = White
+			}
+		}
+	}
+	return b
+}
+
+func TestPlay(t *testing.T) {
+	tests := []struct {
+		name     string
+		board    [][]int
+		point    models.GoPoint
+		color    int
+		want     [][]int // nil when the move is illegal
+		captured int
+	}{
+		{
+			"place",
+			board(".....", ".....", ".....", ".....", "....."),
+			models.GoPoint{Row: 2, Col: 2}, Black,
+			board(".....", ".....", "..X..", ".....", "....."), 0,
+		},
+		{
+			"capture in the corner",
+			board("O....", "X....", ".....", ".....", "....."),
+			models.GoPoint{Row: 0, Col: 1}, Black,
+			board(".X...", "X....", ".....", ".....", "....."), 1,
+		},
+		{
+			"capture a stone",
+			board("XO...", ".....", ".....", ".....", "....."),
+			models.GoPoint{Row: 1, Col: 0}, White,
+			board(".O...", "O....", ".....", ".....", "....."), 1,
+		},
+		{
+			"capture a group",
+			board("XXX..", "XOO..", "XXX..", ".....", "....."),
+			models.GoPoint{Row: 1, Col: 3}, Black,
+			board("XXX..", "X..X.", "XXX..", ".....", "....."), 2,
+		},
+		{
+			"capture two groups at once",
+			board("XO.OX", ".X.X.", ".....", ".....", "....."),
+			models.GoPoint{Row: 0, Col: 2}, Black,
+			board("X.X.X", ".X.X.", ".....", ".....", "....."), 2,
+		},
+		{
+			"capture instead of suicide",
+			board(".XO..", "XO...", "O....", ".....", "....."),
+			models.GoPoint{Row: 0, Col: 0}, White,
+			board("O.O..", ".O...", "O....", ".....", "....."), 2,
+		},
+		{
+			"suicide of a stone",
+			board(".X...", "X....", ".....", ".....", "....."),
+			models.GoPoint{Row: 0, Col: 0}, White,
+			nil, 0,
+		},
+		{
+			"suicide of a group",
+			board(".OX..", "OOX..", "XX...", ".....", "....."),
+			models.GoPoint{Row: 0, Col: 0}, White,
+			nil, 0,
+		},
+		{
+			"occupied",
+			board("X....", ".....", ".....", ".....", "....."),
+			models.GoPoint{Row: 0, Col: 0}, White,
+			nil, 0,
+		},
+		{
+			"out of bounds",
+			board(".....", ".....", ".....", ".....", "....."),
+			models.GoPoint{Row: 5, Col: 0}, Black,
+			nil, 0,
+		},
+	}
+
+	for _, tt := range tests {
+		before := PositionKey(tt.board)
+		got, captured, err := Play(tt.board, tt.point, tt.color)
+		if PositionKey(tt.board) != before {
+			t.Errorf("%s: changed the board passed in", tt.name)
+		}
+		if tt.want == nil {
+			if err == nil {
+				t.Errorf("%s: move allowed", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got board %v, want %v", tt.name, got, tt.want)
+		}
+		if captured != tt.captured {
+			t.Errorf("%s: captured %d, want %d", tt.name, captured, tt.captured)
+		}
+	}
+}
+
+// Positional superko refuses a move whose position key has been seen before,
+// so retaking a ko straight away must recreate an earlier key
+func TestPlaySuperko(t *testing.T) {
+	start := board(".XO..", "XO.O.", ".XO..", ".....", ".....")
+	seen := map[string]bool{PositionKey(start): true}
+
+	taken, captured, err := Play(start, models.GoPoint{Row: 1, Col: 2}, Black)
+	if err != nil || captured != 1 {
+		t.Fatalf("taking the ko: captured %d, %v", captured, err)
+	}
+	if seen[PositionKey(taken)] {
+		t.Fatal("taking the ko repeats a position")
+	}
+	seen[PositionKey(taken)] = true
+
+	retaken, captured, err := Play(taken, models.GoPoint{Row: 1, Col: 1}, White)
+	if err != nil || captured != 1 {
+		t.Fatalf("retaking the ko: captured %d, %v", captured, err)
+	}
+	if !seen[PositionKey(retaken)] {
+		t.Error("retaking the ko straight away does not repeat the position")
+	}
+
+	threat, _, err := Play(taken, models.GoPoint{Row: 4, Col: 4}, White)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seen[PositionKey(threat)] {
+		t.Error("a move elsewhere repeats a position")
+	}
+}
+
+func TestScore(t *testing.T) {
+	// Black walls off the left column and White the right two
+	walls := board(".XO..", ".XO..", ".XO..", ".XO..", ".XO..")
+	invaded := board(".XO..", ".XO..", ".XO.X", ".XO..", ".XO..")
+	invader := []models.GoPoint{{Row: 2, Col: 4}}
+
+	tests := []struct {
+		name                           string
+		board                          [][]int
+		dead                           []models.GoPoint
+		rules                          string
+		blackCaptures, whiteCaptures   int
+		blackTerritory, whiteTerritory int
+		black, white                   float64
+	}{
+		{"japanese", walls, nil, RulesJapanese, 0, 0, 5, 10, 5, 16.5},
+		{"japanese with captures", walls, nil, RulesJapanese, 3, 2, 5, 10, 8, 18.5},
+		{"chinese", walls, nil, RulesChinese, 0, 0, 5, 10, 10, 21.5},
+		{"chinese ignores captures", walls, nil, RulesChinese, 3, 2, 5, 10, 10, 21.5},
+		{"japanese dead stone", invaded, invader, RulesJapanese, 0, 0, 5, 10, 5, 17.5},
+		{"chinese dead stone", invaded, invader, RulesChinese, 0, 0, 5, 10, 10, 21.5},
+		{"unmarked stone spoils territory", invaded, nil, RulesJapanese, 0, 0, 5, 0, 5, 6.5},
+		{"unmarked stone counts on the board", invaded, nil, RulesChinese, 0, 0, 5, 0, 11, 11.5},
+	}
+
+	for _, tt := range tests {
+		got := Score(tt.board, tt.dead, tt.rules, 6.5, tt.blackCaptures, tt.whiteCaptures)
+		if got.BlackTerritory != tt.blackTerritory || got.WhiteTerritory != tt.whiteTerritory {
+			t.Errorf("%s: territory %d-%d, want %d-%d", tt.name, got.BlackTerritory, got.WhiteTerritory, tt.blackTerritory, tt.whiteTerritory)
+		}
+		if got.Black != tt.black || got.White != tt.white {
+			t.Errorf("%s: score %v-%v, want %v-%v", tt.name, got.Black, got.White, tt.black, tt.white)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package gogame
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"altech/internal/models"
+)
+
+// SGFInfo is the game information written to an SGF record
+type SGFInfo struct {
+	Size      int
+	Komi      float64
+	Rules     string
+	BlackName string
+	WhiteName string
+	Date      string // YYYY-MM-DD
+	Result    string // e.g. B+3.5, W+R, 0; empty while the game is in progress
+}
+
+// WriteSGF exports a game as an SGF (FF[4]) record
+func WriteSGF(info SGFInfo, moves []models.GoMove) string {
+	var sb strings.Builder
+	sb.WriteString("(;GM[1]FF[4]CA[UTF-8]AP[altech]")
+	fmt.Fprintf(&sb, "SZ[%d]", info.Size)
+	fmt.Fprintf(&sb, "KM[%s]", strconv.FormatFloat(info.Komi, 'f', -1, 64))
+	rules := "Japanese"
+	if info.Rules == RulesChinese {
+		rules = "Chinese"
+	}
+	fmt.Fprintf(&sb, "RU[%s]", rules)
+	fmt.Fprintf(&sb, "PB[%s]PW[%s]", sgfEscape(info.BlackName), sgfEscape(info.WhiteName))
+	if info.Date != "" {
+		fmt.Fprintf(&sb, "DT[%s]", info.Date)
+	}
+	if info.Result != "" {
+		fmt.Fprintf(&sb, "RE[%s]", info.Result)
+	}
+	sb.WriteString("\n")
+
+	for _, m := range moves {
+		color := "B"
+		if m.Color == White {
+			color = "W"
+		}
+		point := ""
+		if !m.Passed {
+			point = string(rune('a'+m.Col)) + string(rune('a'+m.Row))
+		}
+		fmt.Fprintf(&sb, ";%s[%s]", color, point)
+	}
+	sb.WriteString(")\n")
+	return sb.String()
+}
+
+// SGFResult writes a result the SGF way: B+R for a resignation win,
+// B+3.5 for a win on points and 0 for a draw
+func SGFResult(winner int, resigned bool, margin float64) string {
+	if winner == Empty {
+		return "0"
+	}
+	prefix := "B+"
+	if winner == White {
+		prefix = "W+"
+	}
+	if resigned {
+		return prefix + "R"
+	}
+	return prefix + strconv.FormatFloat(margin, 'f', -1, 64)
+}
+
+func sgfEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, "]", `\]`)
+}
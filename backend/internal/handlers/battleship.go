@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"altech/internal/battleship"
@@ -23,6 +24,11 @@ func (h *Handler) GetBattleshipGames(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	unread, _ := db.GetUnreadChatCounts(h.db, "battleship", userCtx.UserID)
+	for i := range games {
+		games[i].UnreadMessages = unread[games[i].ID]
+	}
+
 	response := models.BattleshipGamesListResponse{
 		YourTurn:  []models.BattleshipGame{},
 		TheirTurn: []models.BattleshipGame{},
@@ -50,46 +56,27 @@ func (h *Handler) GetBattleshipGames(w http.ResponseWriter, r *http.Request) {
 	jsonResponse(w, response, http.StatusOK)
 }
 
-func (h *Handler) CreateBattleshipGame(w http.ResponseWriter, r *http.Request) {
-	userCtx := middleware.GetUser(r)
-	if userCtx == nil {
-		jsonError(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
+// createBattleshipGame starts a game in the setup phase with the variant's
+// board and fleet
+func (h *Handler) createBattleshipGame(creatorID, opponentID int64, settings []byte) (int64, error) {
 	var req models.CreateBattleshipGameRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		jsonError(w, "invalid request body", http.StatusBadRequest)
-		return
+	if err := decodeSettings(settings, &req); err != nil {
+		return 0, err
 	}
 
-	if req.OpponentID == userCtx.UserID {
-		jsonError(w, "cannot play against yourself", http.StatusBadRequest)
-		return
-	}
-
-	// Verify friendship
-	isFriend, err := db.CheckFriendship(h.db, userCtx.UserID, req.OpponentID)
-	if err != nil || !isFriend {
-		jsonError(w, "can only play with friends", http.StatusForbidden)
-		return
+	// Validate variant settings
+	rules, err := battleship.NewRules(req.BoardSize, req.Fleet, req.Salvo, req.NoTouch)
+	if err != nil {
+		return 0, err
 	}
+	fleetJSON, _ := battleship.FleetToJSON(rules.Fleet)
 
-	game, err := db.CreateBattleshipGame(h.db, userCtx.UserID, req.OpponentID)
+	game, err := db.CreateBattleshipGame(h.db, creatorID, opponentID, rules.BoardSize, fleetJSON, rules.Salvo, rules.NoTouch)
 	if err != nil {
-		jsonError(w, "failed to create game", http.StatusInternalServerError)
-		return
+		return 0, errCreateGame
 	}
 
-	jsonResponse(w, models.BattleshipGameResponse{
-		Game:       game,
-		MyBoard:    battleship.CreateEmptyBoard(),
-		EnemyBoard: battleship.CreateEmptyBoard(),
-		MyShips:    []models.Ship{},
-		IsYourTurn: true,
-		ShipsReady: false,
-		Phase:      "setup",
-	}, http.StatusCreated)
+	return game.ID, nil
 }
 
 func (h *Handler) GetBattleshipGame(w http.ResponseWriter, r *http.Request) {
@@ -135,20 +122,24 @@ func (h *Handler) GetBattleshipGame(w http.ResponseWriter, r *http.Request) {
 	}
 	opponentBoard, _ := db.GetBattleshipBoard(h.db, gameID, opponentID)
 
-	// Parse ships and shots
+	// Load shots from the log and derive ship damage from them
+	shotsFired, _ := db.GetBattleshipShots(h.db, gameID, userCtx.UserID)
+	shotsReceived, _ := db.GetBattleshipShots(h.db, gameID, opponentID)
+
 	myShips, _ := battleship.ShipsFromJSON(myBoard.Ships)
-	shotsReceived, _ := battleship.ShotsFromJSON(myBoard.Shots)
+	myShips = battleship.ApplyDamage(myShips, shotsReceived)
 
-	var shotsFired []models.Shot
 	var enemyShips []models.Ship
 	if opponentBoard != nil {
-		shotsFired, _ = battleship.ShotsFromJSON(opponentBoard.Shots)
 		enemyShips, _ = battleship.ShipsFromJSON(opponentBoard.Ships)
+		enemyShips = battleship.ApplyDamage(enemyShips, shotsFired)
 	}
 
-	// Build board views
-	myBoardView := battleship.BuildMyBoard(myShips, shotsReceived)
-	enemyBoardView := battleship.BuildEnemyBoard(shotsFired)
+	// Build board views; the enemy fleet is revealed once the game is over
+	rules := battleship.RulesForGame(game)
+	gameOver := game.Status == "completed"
+	myBoardView := battleship.BuildMyBoard(myShips, shotsReceived, rules.BoardSize)
+	enemyBoardView := battleship.BuildEnemyBoard(enemyShips, shotsFired, rules.BoardSize, gameOver)
 
 	// Calculate enemy ships remaining (not sunk)
 	enemyShipsRemaining := 0
@@ -157,9 +148,9 @@ func (h *Handler) GetBattleshipGame(w http.ResponseWriter, r *http.Request) {
 			enemyShipsRemaining++
 		}
 	}
-	// If enemy hasn't placed ships yet, show 5 (all ships)
-	if len(enemyShips) == 0 && game.Status != "setup" {
-		enemyShipsRemaining = 5
+	// If enemy hasn't placed ships yet, show the whole fleet
+	if len(enemyShips) == 0 {
+		enemyShipsRemaining = rules.ShipCount()
 	}
 
 	// Determine if it's my turn
@@ -170,7 +161,7 @@ func (h *Handler) GetBattleshipGame(w http.ResponseWriter, r *http.Request) {
 		isYourTurn = game.CurrentTurn == userCtx.UserID
 	}
 
-	jsonResponse(w, models.BattleshipGameResponse{
+	response := models.BattleshipGameResponse{
 		Game:                game,
 		MyBoard:             myBoardView,
 		EnemyBoard:          enemyBoardView,
@@ -179,7 +170,17 @@ func (h *Handler) GetBattleshipGame(w http.ResponseWriter, r *http.Request) {
 		ShipsReady:          myBoard.ShipsReady,
 		Phase:               game.Status,
 		EnemyShipsRemaining: enemyShipsRemaining,
-	}, http.StatusOK)
+		Fleet:               rules.Fleet,
+		ShotsThisTurn:       rules.ShotsPerTurn(myShips),
+		MyFleet:             battleship.BuildFleetStatus(myShips, shotsReceived, rules),
+		EnemyFleet:          battleship.BuildFleetStatus(enemyShips, shotsFired, rules),
+		SunkEnemyShips:      battleship.SunkShips(enemyShips, shotsFired),
+	}
+	if gameOver {
+		response.EnemyShips = enemyShips
+	}
+
+	jsonResponse(w, response, http.StatusOK)
 }
 
 func (h *Handler) PlaceBattleshipShips(w http.ResponseWriter, r *http.Request) {
@@ -217,12 +218,14 @@ func (h *Handler) PlaceBattleshipShips(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate ship placement
-	// Set sizes based on type
+	// Set sizes based on type; damage comes from the shot log
 	for i := range req.Ships {
 		req.Ships[i].Size = battleship.ShipSizes[req.Ships[i].Type]
+		req.Ships[i].Hits = 0
 	}
 
-	if err := battleship.ValidateShipPlacement(req.Ships); err != nil {
+	rules := battleship.RulesForGame(game)
+	if err := battleship.ValidateShipPlacement(req.Ships, rules); err != nil {
 		jsonError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -259,13 +262,76 @@ func (h *Handler) PlaceBattleshipShips(w http.ResponseWriter, r *http.Request) {
 	game, _ = db.GetBattleshipGame(h.db, gameID)
 
 	jsonResponse(w, models.BattleshipGameResponse{
-		Game:       game,
-		MyBoard:    battleship.BuildMyBoard(req.Ships, nil),
-		EnemyBoard: battleship.CreateEmptyBoard(),
-		MyShips:    req.Ships,
-		IsYourTurn: game.Status == "active" && game.CurrentTurn == userCtx.UserID,
-		ShipsReady: true,
-		Phase:      game.Status,
+		Game:                game,
+		MyBoard:             battleship.BuildMyBoard(req.Ships, nil, rules.BoardSize),
+		EnemyBoard:          battleship.CreateEmptyBoard(rules.BoardSize),
+		MyShips:             req.Ships,
+		IsYourTurn:          game.Status == "active" && game.CurrentTurn == userCtx.UserID,
+		ShipsReady:          true,
+		Phase:               game.Status,
+		EnemyShipsRemaining: rules.ShipCount(),
+		Fleet:               rules.Fleet,
+		ShotsThisTurn:       rules.ShotsPerTurn(req.Ships),
+		MyFleet:             battleship.BuildFleetStatus(req.Ships, nil, rules),
+		EnemyFleet:          battleship.BuildFleetStatus(nil, nil, rules),
+		SunkEnemyShips:      []models.Ship{},
+	}, http.StatusOK)
+}
+
+func (h *Handler) SuggestBattleshipShips(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	strategy := r.URL.Query().Get("strategy")
+	if strategy == "" {
+		strategy = battleship.StrategyRandom
+	}
+
+	game, err := db.GetBattleshipGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	// Check user is a player
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status != "setup" {
+		jsonError(w, "game is not in setup phase", http.StatusBadRequest)
+		return
+	}
+
+	rules := battleship.RulesForGame(game)
+	ships, err := battleship.GenerateFleet(rules, strategy)
+	if err == battleship.ErrFleetDoesNotFit {
+		jsonError(w, "failed to generate fleet", http.StatusInternalServerError)
+		return
+	}
+	if err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	jsonResponse(w, models.SuggestShipsResponse{
+		Strategy: strategy,
+		Ships:    ships,
+		Board:    battleship.BuildMyBoard(ships, nil, rules.BoardSize),
 	}, http.StatusOK)
 }
 
@@ -318,44 +384,112 @@ func (h *Handler) FireBattleshipShot(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Parse opponent's ships and shots received
+	// Parse opponent's ships and the shots already fired at them
 	opponentShips, _ := battleship.ShipsFromJSON(opponentBoard.Ships)
-	shotsOnOpponent, _ := battleship.ShotsFromJSON(opponentBoard.Shots)
-
-	// Process the shot
-	hit, sunk, shipType, err := battleship.ProcessShot(opponentShips, shotsOnOpponent, req.Row, req.Col)
+	shotsOnOpponent, err := db.GetBattleshipShots(h.db, gameID, userCtx.UserID)
 	if err != nil {
-		jsonError(w, err.Error(), http.StatusBadRequest)
+		jsonError(w, "failed to get shots", http.StatusInternalServerError)
 		return
 	}
 
-	// Record the shot
-	shotsOnOpponent = append(shotsOnOpponent, models.Shot{Row: req.Row, Col: req.Col, Hit: hit})
-	opponentBoard.Shots, _ = battleship.ShotsToJSON(shotsOnOpponent)
-	opponentBoard.Ships, _ = battleship.ShipsToJSON(opponentShips) // Update hits on ships
+	// Work out this turn's targets. Salvo games fire one shot per surviving
+	// ship, or as many as there are untouched cells left if that is fewer.
+	rules := battleship.RulesForGame(game)
+	targets := []models.Cell{{Row: req.Row, Col: req.Col}}
+	if rules.Salvo {
+		myBoard, err := db.GetBattleshipBoard(h.db, gameID, userCtx.UserID)
+		if err != nil {
+			jsonError(w, "failed to get board", http.StatusInternalServerError)
+			return
+		}
+		myShips, _ := battleship.ShipsFromJSON(myBoard.Ships)
+		shotsOnMe, _ := db.GetBattleshipShots(h.db, gameID, opponentID)
 
-	if err := db.UpdateBattleshipBoard(h.db, opponentBoard); err != nil {
-		jsonError(w, "failed to save shot", http.StatusInternalServerError)
-		return
+		allowed := rules.ShotsPerTurn(battleship.ApplyDamage(myShips, shotsOnMe))
+		if open := rules.BoardSize*rules.BoardSize - len(shotsOnOpponent); open < allowed {
+			allowed = open
+		}
+		if len(req.Salvo) > 0 {
+			targets = req.Salvo
+		}
+		if len(targets) != allowed {
+			jsonError(w, fmt.Sprintf("must fire exactly %d shots this turn", allowed), http.StatusBadRequest)
+			return
+		}
 	}
 
-	// Check for game over
-	gameOver := battleship.CheckAllShipsSunk(opponentShips)
-	winnerName := ""
+	// Process each shot; repeating a target within a salvo is rejected like any repeat shot
+	results := make([]models.ShotResult, 0, len(targets))
+	newShots := make([]models.Shot, 0, len(targets))
+	for _, target := range targets {
+		hit, sunk, shipType, err := battleship.ProcessShot(opponentShips, shotsOnOpponent, target.Row, target.Col, rules)
+		if err != nil {
+			jsonError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		shot := models.Shot{
+			GameID:    gameID,
+			ShooterID: userCtx.UserID,
+			Row:       target.Row,
+			Col:       target.Col,
+			Hit:       hit,
+		}
+		if sunk {
+			shot.SunkShip = shipType
+		}
+		shotsOnOpponent = append(shotsOnOpponent, shot)
+		newShots = append(newShots, shot)
+		results = append(results, models.ShotResult{
+			Row:      target.Row,
+			Col:      target.Col,
+			Hit:      hit,
+			Sunk:     sunk,
+			ShipType: shipType,
+		})
+	}
+
+	// Check for game over, otherwise switch turns
+	gameOver := battleship.CheckAllShipsSunk(opponentShips, shotsOnOpponent)
 	if gameOver {
 		game.Status = "completed"
 		game.WinnerID = &userCtx.UserID
-		db.UpdateBattleshipGame(h.db, game)
+	} else {
+		game.CurrentTurn = opponentID
+	}
+
+	// Record the shots
+	err = db.FireBattleshipShots(h.db, game, newShots)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to save shot", http.StatusInternalServerError)
+		return
+	}
+
+	// Summarise the turn, preferring a sunk ship over a plain hit
+	hit, sunk, shipType := false, false, ""
+	for _, result := range results {
+		if result.Sunk && !sunk {
+			sunk = true
+			shipType = result.ShipType
+		}
+		if result.Hit && !hit {
+			hit = true
+			if !sunk {
+				shipType = result.ShipType
+			}
+		}
+	}
 
-		// Get winner name
+	// Get winner name
+	winnerName := ""
+	if gameOver {
 		user, _ := db.GetUserByID(h.db, userCtx.UserID)
 		if user != nil {
 			winnerName = user.Username
 		}
-	} else {
-		// Switch turns
-		game.CurrentTurn = opponentID
-		db.UpdateBattleshipGame(h.db, game)
 	}
 
 	jsonResponse(w, models.FireShotResponse{
@@ -364,9 +498,142 @@ func (h *Handler) FireBattleshipShot(w http.ResponseWriter, r *http.Request) {
 		ShipType: shipType,
 		GameOver: gameOver,
 		Winner:   winnerName,
+		Results:  results,
+	}, http.StatusOK)
+}
+
+func (h *Handler) GetBattleshipHistory(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetBattleshipGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	// Check user is a player
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	shots, err := db.GetBattleshipShotLog(h.db, gameID)
+	if err != nil {
+		jsonError(w, "failed to get shots", http.StatusInternalServerError)
+		return
+	}
+	if shots == nil {
+		shots = []models.Shot{}
+	}
+
+	jsonResponse(w, models.BattleshipHistoryResponse{
+		Shots: shots,
 	}, http.StatusOK)
 }
 
+func (h *Handler) GetBattleshipAnalysis(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetBattleshipGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	// Check user is a player
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status != "completed" {
+		jsonError(w, "game is not completed", http.StatusBadRequest)
+		return
+	}
+
+	rules := battleship.RulesForGame(game)
+
+	// Analyse each player's shots against the other player's fleet
+	players := []struct {
+		id, opponentID int64
+		user           *models.User
+	}{
+		{game.Player1ID, game.Player2ID, game.Player1},
+		{game.Player2ID, game.Player1ID, game.Player2},
+	}
+
+	response := models.BattleshipAnalysisResponse{
+		Players: []models.PlayerShotAnalysis{},
+	}
+	for _, p := range players {
+		opponentBoard, err := db.GetBattleshipBoard(h.db, gameID, p.opponentID)
+		if err != nil {
+			jsonError(w, "failed to get board", http.StatusInternalServerError)
+			return
+		}
+		enemyShips, _ := battleship.ShipsFromJSON(opponentBoard.Ships)
+
+		shots, err := db.GetBattleshipShots(h.db, gameID, p.id)
+		if err != nil {
+			jsonError(w, "failed to get shots", http.StatusInternalServerError)
+			return
+		}
+
+		analysis := battleship.AnalyzeShots(enemyShips, shots, rules)
+		analysis.UserID = p.id
+		if p.user != nil {
+			analysis.Username = p.user.Username
+		}
+		response.Players = append(response.Players, analysis)
+	}
+
+	// Heatmap of the requesting user's placements on this board size
+	placements, err := db.GetBattleshipPlacementsForUser(h.db, userCtx.UserID, rules.BoardSize)
+	if err != nil {
+		jsonError(w, "failed to get placements", http.StatusInternalServerError)
+		return
+	}
+	var fleets [][]models.Ship
+	for _, placement := range placements {
+		ships, err := battleship.ShipsFromJSON(placement)
+		if err == nil && len(ships) > 0 {
+			fleets = append(fleets, ships)
+		}
+	}
+	response.PlacementHeatmap = battleship.PlacementHeatmap(fleets, rules.BoardSize)
+	response.HeatmapGames = len(fleets)
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
 func (h *Handler) ResignBattleshipGame(w http.ResponseWriter, r *http.Request) {
 	userCtx := middleware.GetUser(r)
 	if userCtx == nil {
@@ -409,7 +676,15 @@ func (h *Handler) ResignBattleshipGame(w http.ResponseWriter, r *http.Request) {
 		game.WinnerID = &game.Player1ID
 	}
 
-	db.UpdateBattleshipGame(h.db, game)
+	err = db.UpdateBattleshipGame(h.db, game)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to update game", http.StatusInternalServerError)
+		return
+	}
 
 	jsonResponse(w, map[string]string{"status": "resigned"}, http.StatusOK)
 }
@@ -0,0 +1,382 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"altech/internal/boggle"
+	"altech/internal/db"
+	"altech/internal/middleware"
+	"altech/internal/models"
+)
+
+func (h *Handler) GetBoggleGames(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	games, err := db.GetBoggleGamesForUser(h.db, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get games", http.StatusInternalServerError)
+		return
+	}
+
+	unread, _ := db.GetUnreadChatCounts(h.db, "boggle", userCtx.UserID)
+	for i := range games {
+		games[i].UnreadMessages = unread[games[i].ID]
+	}
+
+	response := models.BoggleGamesListResponse{
+		YourTurn:  []models.BoggleGame{},
+		TheirTurn: []models.BoggleGame{},
+		Completed: []models.BoggleGame{},
+	}
+
+	now := time.Now()
+	for _, game := range games {
+		h.settleBoggleGame(&game, now)
+
+		started := boggleStartedAt(&game, userCtx.UserID)
+		if game.Status == "completed" {
+			response.Completed = append(response.Completed, game)
+		} else if started == nil || now.Before(boggle.Deadline(*started, game.DurationSeconds)) {
+			response.YourTurn = append(response.YourTurn, game)
+		} else {
+			response.TheirTurn = append(response.TheirTurn, game)
+		}
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// createBoggleGame starts a game on a freshly shaken grid
+func (h *Handler) createBoggleGame(creatorID, opponentID int64, settings []byte) (int64, error) {
+	var req models.CreateBoggleGameRequest
+	if err := decodeSettings(settings, &req); err != nil {
+		return 0, err
+	}
+
+	size := boggle.ValidateSize(req.Size)
+	seed, err := boggle.NewSeed()
+	if err != nil {
+		return 0, errCreateGame
+	}
+
+	gridJSON, err := boggle.GridToJSON(boggle.GenerateGrid(size, seed))
+	if err != nil {
+		return 0, errCreateGame
+	}
+
+	game, err := db.CreateBoggleGame(h.db, creatorID, opponentID, size, seed, gridJSON, boggle.Duration)
+	if err != nil {
+		return 0, errCreateGame
+	}
+
+	return game.ID, nil
+}
+
+func (h *Handler) GetBoggleGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetBoggleGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	h.settleBoggleGame(game, time.Now())
+
+	response := h.buildBoggleResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// StartBoggleGame starts the player's time window and reveals the grid
+func (h *Handler) StartBoggleGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetBoggleGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status != "active" {
+		jsonError(w, "game is not active", http.StatusBadRequest)
+		return
+	}
+
+	if boggleStartedAt(game, userCtx.UserID) != nil {
+		jsonError(w, "your timer has already started", http.StatusBadRequest)
+		return
+	}
+
+	if err := db.StartBoggleWindow(h.db, game, userCtx.UserID); err != nil {
+		jsonError(w, "failed to start game", http.StatusInternalServerError)
+		return
+	}
+
+	game, err = db.GetBoggleGame(h.db, gameID)
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	response := h.buildBoggleResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) SubmitBoggleWord(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.SubmitBoggleWordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetBoggleGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status != "active" {
+		jsonError(w, "game is not active", http.StatusBadRequest)
+		return
+	}
+
+	started := boggleStartedAt(game, userCtx.UserID)
+	if started == nil {
+		jsonError(w, "start your timer first", http.StatusBadRequest)
+		return
+	}
+	if time.Now().After(boggle.Deadline(*started, game.DurationSeconds)) {
+		jsonError(w, "time is up", http.StatusBadRequest)
+		return
+	}
+
+	grid, err := boggle.GridFromJSON(game.Grid)
+	if err != nil {
+		jsonError(w, "failed to parse grid", http.StatusInternalServerError)
+		return
+	}
+
+	word := boggle.NormalizeWord(req.Word)
+	path, err := boggle.ValidateWord(grid, word, req.Path)
+	if err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = db.CreateBoggleWord(h.db, &models.BoggleWord{
+		GameID: gameID,
+		UserID: userCtx.UserID,
+		Word:   word,
+		Path:   path,
+		Points: boggle.Score(word),
+	})
+	if err == db.ErrBoggleWordExists {
+		jsonError(w, "you already found that word", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to save word", http.StatusInternalServerError)
+		return
+	}
+
+	response := h.buildBoggleResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) ResignBoggleGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetBoggleGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status == "completed" {
+		jsonError(w, "game is already completed", http.StatusBadRequest)
+		return
+	}
+
+	game.Status = "completed"
+	if userCtx.UserID == game.Player1ID {
+		game.WinnerID = &game.Player2ID
+	} else {
+		game.WinnerID = &game.Player1ID
+	}
+	game.Solution = solveBoggleGame(game)
+
+	db.UpdateBoggleGame(h.db, game)
+
+	jsonResponse(w, map[string]string{"status": "resigned"}, http.StatusOK)
+}
+
+func boggleStartedAt(game *models.BoggleGame, userID int64) *time.Time {
+	if userID == game.Player1ID {
+		return game.Player1StartedAt
+	}
+	return game.Player2StartedAt
+}
+
+// settleBoggleGame completes an active game once both windows have closed,
+// cancelling shared words and scoring the rest
+func (h *Handler) settleBoggleGame(game *models.BoggleGame, now time.Time) {
+	if game.Status != "active" || game.Player1StartedAt == nil || game.Player2StartedAt == nil {
+		return
+	}
+	if now.Before(boggle.Deadline(*game.Player1StartedAt, game.DurationSeconds)) ||
+		now.Before(boggle.Deadline(*game.Player2StartedAt, game.DurationSeconds)) {
+		return
+	}
+
+	words, err := db.GetBoggleWords(h.db, game.ID)
+	if err != nil {
+		return
+	}
+
+	game.Player1Score, game.Player2Score = boggle.Tally(words, game.Player1ID)
+	game.Status = "completed"
+	switch {
+	case game.Player1Score > game.Player2Score:
+		game.WinnerID = &game.Player1ID
+	case game.Player2Score > game.Player1Score:
+		game.WinnerID = &game.Player2ID
+	}
+	// nil WinnerID = draw
+	game.Solution = solveBoggleGame(game)
+
+	db.UpdateBoggleGame(h.db, game)
+}
+
+// solveBoggleGame lists every word on a game's grid, ready to store
+func solveBoggleGame(game *models.BoggleGame) string {
+	grid, _ := boggle.GridFromJSON(game.Grid)
+	solutionJSON, _ := boggle.SolutionToJSON(boggle.Solve(grid))
+	return solutionJSON
+}
+
+// buildBoggleResponse constructs the response. The grid stays hidden until
+// the player starts their window, and the opponent's words until the end.
+func (h *Handler) buildBoggleResponse(game *models.BoggleGame, userID int64) models.BoggleGameResponse {
+	started := boggleStartedAt(game, userID)
+
+	resp := models.BoggleGameResponse{
+		Game:          game,
+		MinWordLength: boggle.MinWordLength(game.Size),
+		StartedAt:     started,
+		SecondsLeft:   boggle.SecondsLeft(started, game.DurationSeconds, time.Now()),
+		YourWords:     []models.BoggleWord{},
+	}
+
+	grid, _ := boggle.GridFromJSON(game.Grid)
+	if started != nil || game.Status == "completed" {
+		resp.Grid = grid
+	}
+
+	words, _ := db.GetBoggleWords(h.db, game.ID)
+	completed := game.Status == "completed"
+	if completed {
+		boggle.Tally(words, game.Player1ID)
+		resp.OpponentWords = []models.BoggleWord{}
+		resp.Solution, _ = boggle.SolutionFromJSON(game.Solution)
+	}
+
+	for _, w := range words {
+		if w.UserID == userID {
+			resp.YourWords = append(resp.YourWords, w)
+			if !w.Cancelled {
+				resp.YourScore += w.Points
+			}
+		} else {
+			resp.OpponentWordCount++
+			if completed {
+				resp.OpponentWords = append(resp.OpponentWords, w)
+			}
+		}
+	}
+
+	return resp
+}
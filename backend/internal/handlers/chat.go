@@ -0,0 +1,371 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"altech/internal/chat"
+	"altech/internal/db"
+	"altech/internal/middleware"
+	"altech/internal/models"
+)
+
+// chatPage reads the paging parameters: before or after a message ID, and
+// the page size
+func chatPage(r *http.Request) (before, after int64, limit int) {
+	q := r.URL.Query()
+	before, _ = strconv.ParseInt(q.Get("before"), 10, 64)
+	after, _ = strconv.ParseInt(q.Get("after"), 10, 64)
+	n, _ := strconv.Atoi(q.Get("limit"))
+	return before, after, chat.ValidatePageSize(n)
+}
+
+// playerGame loads the game named by the path and checks the user plays in
+// it. It writes the error response and returns nil on failure.
+func (h *Handler) playerGame(w http.ResponseWriter, r *http.Request, userID int64) (string, *models.GameSummary) {
+	gameType, game := h.pathGame(w, r)
+	if game == nil {
+		return "", nil
+	}
+
+	if game.Player1ID != userID && game.Player2ID != userID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return "", nil
+	}
+
+	return gameType, game
+}
+
+// pathGame loads the game named by the type and id path values. It writes
+// the error response and returns nil on failure.
+func (h *Handler) pathGame(w http.ResponseWriter, r *http.Request) (string, *models.GameSummary) {
+	gameType := r.PathValue("type")
+	load, ok := gameLoaders[gameType]
+	if !ok {
+		jsonError(w, "unknown game type", http.StatusBadRequest)
+		return "", nil
+	}
+
+	gameID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return "", nil
+	}
+
+	game, _, err := load(h, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return "", nil
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return "", nil
+	}
+
+	return gameType, game
+}
+
+// chatFriend reads the friend a direct message request is for. It writes the
+// error response and returns 0 on failure.
+func (h *Handler) chatFriend(w http.ResponseWriter, r *http.Request, userID int64) int64 {
+	friendID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		jsonError(w, "invalid user ID", http.StatusBadRequest)
+		return 0
+	}
+
+	isFriend, err := db.CheckFriendship(h.db, userID, friendID)
+	if err != nil || !isFriend {
+		jsonError(w, "can only message friends", http.StatusForbidden)
+		return 0
+	}
+
+	return friendID
+}
+
+// prepareChatMessages applies the viewer's profanity filter to messages from
+// others. Messages are stored as sent so the filter can be turned off later.
+func (h *Handler) prepareChatMessages(messages []models.ChatMessage, userID int64) []models.ChatMessage {
+	if messages == nil {
+		return []models.ChatMessage{}
+	}
+
+	settings, err := db.GetChatSettings(h.db, userID)
+	if err != nil || settings.FilterProfanity {
+		for i := range messages {
+			if messages[i].SenderID != userID {
+				messages[i].Body = chat.Filter(messages[i].Body)
+			}
+		}
+	}
+	return messages
+}
+
+// sendChatMessage validates and stores a message, then wakes anyone waiting
+// for it
+func (h *Handler) sendChatMessage(w http.ResponseWriter, r *http.Request, msg *models.ChatMessage) {
+	var req models.SendChatMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	body, err := chat.ValidateMessage(req.Body)
+	if err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	msg.Body = body
+
+	if err := db.CreateChatMessage(h.db, msg); err != nil {
+		jsonError(w, "failed to send message", http.StatusInternalServerError)
+		return
+	}
+
+	h.chatHub.Notify(msg.RecipientID)
+	h.chatHub.Notify(msg.SenderID) // The sender's other sessions
+
+	jsonResponse(w, msg, http.StatusCreated)
+}
+
+func (h *Handler) GetGameChat(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameType, game := h.playerGame(w, r, userCtx.UserID)
+	if game == nil {
+		return
+	}
+
+	before, after, limit := chatPage(r)
+	messages, hasMore, err := db.GetGameChatMessages(h.db, gameType, game.ID, before, after, limit)
+	if err != nil {
+		jsonError(w, "failed to get messages", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, models.ChatMessagesResponse{
+		Messages: h.prepareChatMessages(messages, userCtx.UserID),
+		HasMore:  hasMore,
+	}, http.StatusOK)
+}
+
+func (h *Handler) SendGameChatMessage(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameType, game := h.playerGame(w, r, userCtx.UserID)
+	if game == nil {
+		return
+	}
+
+	recipientID := game.Player1ID
+	if userCtx.UserID == game.Player1ID {
+		recipientID = game.Player2ID
+	}
+
+	h.sendChatMessage(w, r, &models.ChatMessage{
+		GameType:    gameType,
+		GameID:      game.ID,
+		SenderID:    userCtx.UserID,
+		RecipientID: recipientID,
+	})
+}
+
+func (h *Handler) MarkGameChatRead(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameType, game := h.playerGame(w, r, userCtx.UserID)
+	if game == nil {
+		return
+	}
+
+	n, err := db.MarkGameChatRead(h.db, gameType, game.ID, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to mark messages read", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, models.ChatReadResponse{Read: n}, http.StatusOK)
+}
+
+func (h *Handler) GetDirectChat(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	friendID := h.chatFriend(w, r, userCtx.UserID)
+	if friendID == 0 {
+		return
+	}
+
+	before, after, limit := chatPage(r)
+	messages, hasMore, err := db.GetDirectChatMessages(h.db, userCtx.UserID, friendID, before, after, limit)
+	if err != nil {
+		jsonError(w, "failed to get messages", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, models.ChatMessagesResponse{
+		Messages: h.prepareChatMessages(messages, userCtx.UserID),
+		HasMore:  hasMore,
+	}, http.StatusOK)
+}
+
+func (h *Handler) SendDirectMessage(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	friendID := h.chatFriend(w, r, userCtx.UserID)
+	if friendID == 0 {
+		return
+	}
+
+	h.sendChatMessage(w, r, &models.ChatMessage{
+		SenderID:    userCtx.UserID,
+		RecipientID: friendID,
+	})
+}
+
+func (h *Handler) MarkDirectChatRead(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	friendID := h.chatFriend(w, r, userCtx.UserID)
+	if friendID == 0 {
+		return
+	}
+
+	n, err := db.MarkDirectChatRead(h.db, userCtx.UserID, friendID)
+	if err != nil {
+		jsonError(w, "failed to mark messages read", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, models.ChatReadResponse{Read: n}, http.StatusOK)
+}
+
+func (h *Handler) GetChatUnread(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	games, direct, err := db.GetUnreadChatSummary(h.db, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get unread messages", http.StatusInternalServerError)
+		return
+	}
+
+	response := models.ChatUnreadResponse{Games: games, Direct: direct}
+	for _, g := range games {
+		response.Total += g.Count
+	}
+	for _, d := range direct {
+		response.Total += d.Count
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// GetChatUpdates returns every message the user sent or received after the
+// given ID. With wait set and nothing new it holds the request open for up to
+// that many seconds and answers as soon as a message arrives, so clients get
+// messages pushed while connected and fall back to plain polling otherwise.
+func (h *Handler) GetChatUpdates(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+	after, _ := strconv.ParseInt(q.Get("after"), 10, 64)
+	wait, _ := strconv.Atoi(q.Get("wait"))
+	if wait < 0 || wait > chat.MaxWaitSeconds {
+		jsonError(w, "wait must be between 0 and 30 seconds", http.StatusBadRequest)
+		return
+	}
+
+	// Subscribe before reading so a message sent in between still wakes us
+	notify, unsubscribe := h.chatHub.Subscribe(userCtx.UserID)
+	defer unsubscribe()
+
+	messages, hasMore, err := db.GetChatUpdates(h.db, userCtx.UserID, after, chat.MaxPageSize)
+	if err == nil && len(messages) == 0 && wait > 0 {
+		select {
+		case <-notify:
+			messages, hasMore, err = db.GetChatUpdates(h.db, userCtx.UserID, after, chat.MaxPageSize)
+		case <-time.After(time.Duration(wait) * time.Second):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if err != nil {
+		jsonError(w, "failed to get messages", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, models.ChatMessagesResponse{
+		Messages: h.prepareChatMessages(messages, userCtx.UserID),
+		HasMore:  hasMore,
+	}, http.StatusOK)
+}
+
+func (h *Handler) GetChatSettings(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	settings, err := db.GetChatSettings(h.db, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get chat settings", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, settings, http.StatusOK)
+}
+
+func (h *Handler) UpdateChatSettings(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.ChatSettings
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := db.UpdateChatSettings(h.db, userCtx.UserID, &req); err != nil {
+		jsonError(w, "failed to update chat settings", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, req, http.StatusOK)
+}
@@ -0,0 +1,308 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"altech/internal/checkers"
+	"altech/internal/db"
+	"altech/internal/middleware"
+	"altech/internal/models"
+)
+
+func (h *Handler) GetCheckersGames(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	games, err := db.GetCheckersGamesForUser(h.db, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get games", http.StatusInternalServerError)
+		return
+	}
+
+	unread, _ := db.GetUnreadChatCounts(h.db, "checkers", userCtx.UserID)
+	for i := range games {
+		games[i].UnreadMessages = unread[games[i].ID]
+	}
+
+	response := models.CheckersGamesListResponse{
+		YourTurn:  []models.CheckersGame{},
+		TheirTurn: []models.CheckersGame{},
+		Completed: []models.CheckersGame{},
+	}
+
+	for _, game := range games {
+		if game.Status == "completed" {
+			response.Completed = append(response.Completed, game)
+		} else if game.CurrentTurn == userCtx.UserID {
+			response.YourTurn = append(response.YourTurn, game)
+		} else {
+			response.TheirTurn = append(response.TheirTurn, game)
+		}
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// createCheckersGame starts a game from the standard opening position
+func (h *Handler) createCheckersGame(creatorID, opponentID int64, settings []byte) (int64, error) {
+	boardJSON, err := checkers.BoardToJSON(checkers.NewBoard())
+	if err != nil {
+		return 0, errCreateGame
+	}
+
+	// The creator plays Black and moves first
+	game, err := db.CreateCheckersGame(h.db, creatorID, opponentID, boardJSON)
+	if err != nil {
+		return 0, errCreateGame
+	}
+
+	return game.ID, nil
+}
+
+func (h *Handler) GetCheckersGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetCheckersGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	response := h.buildCheckersResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) MakeCheckersMove(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.CheckersMoveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetCheckersGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status != "active" {
+		jsonError(w, "game is not active", http.StatusBadRequest)
+		return
+	}
+
+	if game.CurrentTurn != userCtx.UserID {
+		jsonError(w, "not your turn", http.StatusBadRequest)
+		return
+	}
+
+	board, err := checkers.BoardFromJSON(game.Board)
+	if err != nil {
+		jsonError(w, "failed to parse board", http.StatusInternalServerError)
+		return
+	}
+
+	side, otherSide, opponentID := checkers.Black, checkers.White, game.Player2ID
+	if userCtx.UserID == game.Player2ID {
+		side, otherSide, opponentID = checkers.White, checkers.Black, game.Player1ID
+	}
+
+	// The whole jump sequence is submitted at once and must match a legal move
+	move, err := checkers.FindMove(board, side, req.Path)
+	if err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	quiet, crowned := checkers.ApplyMove(board, move)
+	position := checkers.PositionKey(board, otherSide)
+
+	moves, _ := db.GetCheckersMoves(h.db, gameID)
+	record := &models.CheckersMoveRecord{
+		GameID:   gameID,
+		UserID:   userCtx.UserID,
+		Path:     move.Path,
+		Captured: move.Captured,
+		Notation: move.Notation,
+		Crowned:  crowned,
+		Position: position,
+	}
+
+	game.Board, _ = checkers.BoardToJSON(board)
+	if quiet {
+		game.QuietPlies++
+	} else {
+		game.QuietPlies = 0
+	}
+
+	// Count how often this position has occurred, including the opening
+	// position and the move just made
+	repeats := 1
+	if position == checkers.PositionKey(checkers.NewBoard(), checkers.Black) {
+		repeats++
+	}
+	for _, m := range moves {
+		if m.Position == position {
+			repeats++
+		}
+	}
+
+	switch {
+	case len(checkers.LegalMoves(board, otherSide)) == 0:
+		// A player who cannot move loses
+		game.Status = "completed"
+		game.WinnerID = &userCtx.UserID
+		game.EndReason = checkers.EndNoMoves
+	case game.QuietPlies >= checkers.QuietPlyLimit:
+		game.Status = "completed"
+		game.EndReason = checkers.EndFortyMoves
+	case repeats >= 3:
+		game.Status = "completed"
+		game.EndReason = checkers.EndRepetition
+	default:
+		game.CurrentTurn = opponentID
+	}
+
+	err = db.PlayCheckersMove(h.db, game, record)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to save move", http.StatusInternalServerError)
+		return
+	}
+
+	response := h.buildCheckersResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) ResignCheckersGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetCheckersGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status == "completed" {
+		jsonError(w, "game is already completed", http.StatusBadRequest)
+		return
+	}
+
+	game.Status = "completed"
+	game.EndReason = checkers.EndResigned
+	if userCtx.UserID == game.Player1ID {
+		game.WinnerID = &game.Player2ID
+	} else {
+		game.WinnerID = &game.Player1ID
+	}
+
+	err = db.UpdateCheckersGame(h.db, game)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to update game", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, map[string]string{"status": "resigned"}, http.StatusOK)
+}
+
+// buildCheckersResponse constructs the response with the board, the move
+// list and, for the player to move, every legal move
+func (h *Handler) buildCheckersResponse(game *models.CheckersGame, userID int64) models.CheckersGameResponse {
+	board, _ := checkers.BoardFromJSON(game.Board)
+
+	side := checkers.Black
+	if userID == game.Player2ID {
+		side = checkers.White
+	}
+
+	moves, _ := db.GetCheckersMoves(h.db, game.ID)
+	if moves == nil {
+		moves = []models.CheckersMoveRecord{}
+	}
+
+	isYourTurn := game.Status == "active" && game.CurrentTurn == userID
+
+	resp := models.CheckersGameResponse{
+		Game:       game,
+		Board:      board,
+		YourSide:   side,
+		IsYourTurn: isYourTurn,
+		LegalMoves: []models.CheckersMove{},
+		Moves:      moves,
+	}
+
+	if isYourTurn {
+		resp.LegalMoves = checkers.LegalMoves(board, side)
+	}
+
+	return resp
+}
@@ -0,0 +1,526 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"altech/internal/chess"
+	"altech/internal/db"
+	"altech/internal/middleware"
+	"altech/internal/models"
+)
+
+func (h *Handler) GetChessGames(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	games, err := db.GetChessGamesForUser(h.db, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get games", http.StatusInternalServerError)
+		return
+	}
+
+	unread, _ := db.GetUnreadChatCounts(h.db, "chess", userCtx.UserID)
+	for i := range games {
+		games[i].UnreadMessages = unread[games[i].ID]
+	}
+
+	response := models.ChessGamesListResponse{
+		YourTurn:  []models.ChessGame{},
+		TheirTurn: []models.ChessGame{},
+		Completed: []models.ChessGame{},
+	}
+
+	for _, game := range games {
+		if game.Status == "completed" {
+			response.Completed = append(response.Completed, game)
+		} else if game.CurrentTurn == userCtx.UserID {
+			response.YourTurn = append(response.YourTurn, game)
+		} else {
+			response.TheirTurn = append(response.TheirTurn, game)
+		}
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// createChessGame starts a game from the standard position, a FEN position
+// or the end of an imported PGN
+func (h *Handler) createChessGame(creatorID, opponentID int64, settings []byte) (int64, error) {
+	var req models.CreateChessGameRequest
+	if err := decodeSettings(settings, &req); err != nil {
+		return 0, err
+	}
+
+	if req.FEN != "" && req.PGN != "" {
+		return 0, errors.New("give either a FEN or a PGN, not both")
+	}
+
+	// The creator plays White. A game can start from a FEN position or carry
+	// on from the moves of an imported PGN.
+	var start chess.Position
+	var imported []chess.Move
+	var err error
+	switch {
+	case req.PGN != "":
+		pgn, err := chess.ParsePGN(req.PGN)
+		if err != nil {
+			return 0, err
+		}
+		start, imported = pgn.Start, pgn.Moves
+	case req.FEN != "":
+		start, err = chess.ParseFEN(req.FEN)
+	default:
+		start, err = chess.ParseFEN(chess.StartFEN)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	pos := start
+	keys := []string{start.RepetitionKey()}
+	var moves []models.ChessMove
+	for _, m := range imported {
+		mover := opponentID
+		if pos.Turn == chess.White {
+			mover = creatorID
+		}
+		san := pos.SAN(m)
+		pos = pos.Play(m)
+		keys = append(keys, pos.RepetitionKey())
+		moves = append(moves, models.ChessMove{
+			UserID:        mover,
+			UCI:           m.UCI(),
+			SAN:           san,
+			FEN:           pos.FEN(),
+			RepetitionKey: keys[len(keys)-1],
+		})
+	}
+
+	if over, _, _ := chessOutcome(pos, keys); over {
+		return 0, errors.New("the position is already finished")
+	}
+
+	currentTurn := creatorID
+	if pos.Turn == chess.Black {
+		currentTurn = opponentID
+	}
+
+	game, err := db.CreateChessGame(h.db, creatorID, opponentID, currentTurn, start.FEN(), pos.FEN(), moves)
+	if err != nil {
+		return 0, errCreateGame
+	}
+
+	return game.ID, nil
+}
+
+func (h *Handler) GetChessGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetChessGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	response := h.buildChessResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) MakeChessMove(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.ChessMoveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetChessGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status != "active" {
+		jsonError(w, "game is not active", http.StatusBadRequest)
+		return
+	}
+
+	if game.CurrentTurn != userCtx.UserID {
+		jsonError(w, "not your turn", http.StatusBadRequest)
+		return
+	}
+
+	pos, err := chess.ParseFEN(game.FEN)
+	if err != nil {
+		jsonError(w, "failed to parse position", http.StatusInternalServerError)
+		return
+	}
+
+	move, err := pos.ParseMove(req.Move)
+	if err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	san := pos.SAN(move)
+	pos = pos.Play(move)
+
+	moves, _ := db.GetChessMoves(h.db, gameID)
+	keys := []string{chessStartKey(game)}
+	for _, m := range moves {
+		keys = append(keys, m.RepetitionKey)
+	}
+	keys = append(keys, pos.RepetitionKey())
+
+	record := &models.ChessMove{
+		GameID:        gameID,
+		UserID:        userCtx.UserID,
+		UCI:           move.UCI(),
+		SAN:           san,
+		FEN:           pos.FEN(),
+		RepetitionKey: keys[len(keys)-1],
+	}
+
+	game.FEN = record.FEN
+	if over, reason, winner := chessOutcome(pos, keys); over {
+		game.Status = "completed"
+		game.EndReason = reason
+		switch winner {
+		case chess.White:
+			game.WinnerID = &game.Player1ID
+		case chess.Black:
+			game.WinnerID = &game.Player2ID
+		}
+		// nil WinnerID = draw
+	} else if userCtx.UserID == game.Player1ID {
+		game.CurrentTurn = game.Player2ID
+	} else {
+		game.CurrentTurn = game.Player1ID
+	}
+
+	err = db.PlayChessMove(h.db, game, record)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to save move", http.StatusInternalServerError)
+		return
+	}
+
+	response := h.buildChessResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) ResignChessGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetChessGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status == "completed" {
+		jsonError(w, "game is already completed", http.StatusBadRequest)
+		return
+	}
+
+	game.Status = "completed"
+	game.EndReason = chess.EndResigned
+	if userCtx.UserID == game.Player1ID {
+		game.WinnerID = &game.Player2ID
+	} else {
+		game.WinnerID = &game.Player1ID
+	}
+
+	err = db.UpdateChessGame(h.db, game)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to update game", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, map[string]string{"status": "resigned"}, http.StatusOK)
+}
+
+func (h *Handler) GetChessHistory(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetChessGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	// Check user is a player
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	moves, err := db.GetChessMoves(h.db, gameID)
+	if err != nil {
+		jsonError(w, "failed to get moves", http.StatusInternalServerError)
+		return
+	}
+
+	history := make([]models.ChessHistoryItem, len(moves))
+	for i, move := range moves {
+		playerName, color := "", "white"
+		if move.UserID == game.Player1ID && game.Player1 != nil {
+			playerName = game.Player1.Username
+		} else if game.Player2 != nil {
+			playerName = game.Player2.Username
+		}
+		if move.UserID == game.Player2ID {
+			color = "black"
+		}
+
+		history[i] = models.ChessHistoryItem{
+			MoveNumber: move.MoveNumber,
+			PlayerName: playerName,
+			Color:      color,
+			SAN:        move.SAN,
+			UCI:        move.UCI,
+			FEN:        move.FEN,
+			CreatedAt:  move.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		}
+	}
+
+	jsonResponse(w, models.ChessHistoryResponse{
+		StartFEN: game.StartFEN,
+		Moves:    history,
+	}, http.StatusOK)
+}
+
+func (h *Handler) GetChessPGN(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetChessGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	moves, err := db.GetChessMoves(h.db, gameID)
+	if err != nil {
+		jsonError(w, "failed to get moves", http.StatusInternalServerError)
+		return
+	}
+
+	start, err := chess.ParseFEN(game.StartFEN)
+	if err != nil {
+		jsonError(w, "failed to parse position", http.StatusInternalServerError)
+		return
+	}
+
+	// Replay the stored moves so the SAN is regenerated from the positions
+	pos := start
+	played := make([]chess.Move, 0, len(moves))
+	for _, m := range moves {
+		move, err := pos.ParseMove(m.UCI)
+		if err != nil {
+			jsonError(w, "failed to replay moves", http.StatusInternalServerError)
+			return
+		}
+		played = append(played, move)
+		pos = pos.Play(move)
+	}
+
+	white, black := "?", "?"
+	if game.Player1 != nil {
+		white = game.Player1.Username
+	}
+	if game.Player2 != nil {
+		black = game.Player2.Username
+	}
+
+	result := chess.ResultInProgress
+	if game.Status == "completed" {
+		switch {
+		case game.WinnerID == nil:
+			result = chess.ResultDraw
+		case *game.WinnerID == game.Player1ID:
+			result = chess.ResultWhite
+		default:
+			result = chess.ResultBlack
+		}
+	}
+
+	tags := []chess.PGNTag{
+		{Name: "Event", Value: "Casual correspondence game"},
+		{Name: "Site", Value: "?"},
+		{Name: "Date", Value: game.CreatedAt.Format("2006.01.02")},
+		{Name: "Round", Value: "-"},
+		{Name: "White", Value: white},
+		{Name: "Black", Value: black},
+	}
+
+	jsonResponse(w, models.ChessPGNResponse{
+		PGN: chess.WritePGN(tags, start, played, result),
+	}, http.StatusOK)
+}
+
+// chessOutcome reports whether the game is over at pos. keys holds the
+// repetition key of every position so far, including pos.
+func chessOutcome(pos chess.Position, keys []string) (over bool, reason string, winner int) {
+	if over, reason, winner := pos.Status(); over {
+		return over, reason, winner
+	}
+
+	current := keys[len(keys)-1]
+	repeats := 0
+	for _, k := range keys {
+		if k == current {
+			repeats++
+		}
+	}
+	if repeats >= 3 {
+		return true, chess.EndRepetition, 0
+	}
+	return false, "", 0
+}
+
+func chessStartKey(game *models.ChessGame) string {
+	start, err := chess.ParseFEN(game.StartFEN)
+	if err != nil {
+		return ""
+	}
+	return start.RepetitionKey()
+}
+
+// buildChessResponse constructs the response with the board and, for the
+// player to move, every legal move
+func (h *Handler) buildChessResponse(game *models.ChessGame, userID int64) models.ChessGameResponse {
+	pos, _ := chess.ParseFEN(game.FEN)
+
+	color := "white"
+	if userID == game.Player2ID {
+		color = "black"
+	}
+
+	moves, _ := db.GetChessMoves(h.db, game.ID)
+	if moves == nil {
+		moves = []models.ChessMove{}
+	}
+
+	isYourTurn := game.Status == "active" && game.CurrentTurn == userID
+
+	resp := models.ChessGameResponse{
+		Game:       game,
+		Board:      pos.Grid(),
+		YourColor:  color,
+		IsYourTurn: isYourTurn,
+		InCheck:    pos.InCheck(),
+		LegalMoves: []models.ChessLegalMove{},
+		Moves:      moves,
+	}
+
+	if isYourTurn {
+		for _, m := range pos.LegalMoves() {
+			resp.LegalMoves = append(resp.LegalMoves, models.ChessLegalMove{UCI: m.UCI(), SAN: pos.SAN(m)})
+		}
+	}
+
+	return resp
+}
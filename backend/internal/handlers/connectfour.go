@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"altech/internal/connectfour"
+	"altech/internal/db"
+	"altech/internal/middleware"
+	"altech/internal/models"
+)
+
+func (h *Handler) GetConnectFourGames(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	games, err := db.GetConnectFourGamesForUser(h.db, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get games", http.StatusInternalServerError)
+		return
+	}
+
+	unread, _ := db.GetUnreadChatCounts(h.db, "connectfour", userCtx.UserID)
+	for i := range games {
+		games[i].UnreadMessages = unread[games[i].ID]
+	}
+
+	response := models.ConnectFourGamesListResponse{
+		YourTurn:  []models.ConnectFourGame{},
+		TheirTurn: []models.ConnectFourGame{},
+		Completed: []models.ConnectFourGame{},
+	}
+
+	for _, game := range games {
+		if game.Status == "completed" {
+			response.Completed = append(response.Completed, game)
+		} else if game.CurrentTurn == userCtx.UserID {
+			response.YourTurn = append(response.YourTurn, game)
+		} else {
+			response.TheirTurn = append(response.TheirTurn, game)
+		}
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// createConnectFourGame starts a game on an empty board of the chosen size
+func (h *Handler) createConnectFourGame(creatorID, opponentID int64, settings []byte) (int64, error) {
+	var req models.CreateConnectFourGameRequest
+	if err := decodeSettings(settings, &req); err != nil {
+		return 0, err
+	}
+
+	// Validate board size
+	boardSize := req.BoardSize
+	if boardSize == "" {
+		boardSize = connectfour.DefaultBoardSize
+	}
+	cols, rows, err := connectfour.ParseBoardSize(boardSize)
+	if err != nil {
+		return 0, err
+	}
+
+	boardJSON, err := connectfour.BoardToJSON(connectfour.NewBoard(cols, rows))
+	if err != nil {
+		return 0, errCreateGame
+	}
+
+	game, err := db.CreateConnectFourGame(h.db, creatorID, opponentID, boardSize, req.PopOut, boardJSON)
+	if err != nil {
+		return 0, errCreateGame
+	}
+
+	return game.ID, nil
+}
+
+func (h *Handler) GetConnectFourGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetConnectFourGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	response := h.buildConnectFourResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) MakeConnectFourMove(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.ConnectFourMoveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetConnectFourGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status != "active" {
+		jsonError(w, "game is not active", http.StatusBadRequest)
+		return
+	}
+
+	if game.CurrentTurn != userCtx.UserID {
+		jsonError(w, "not your turn", http.StatusBadRequest)
+		return
+	}
+
+	board, err := connectfour.BoardFromJSON(game.Board)
+	if err != nil {
+		jsonError(w, "failed to parse board", http.StatusInternalServerError)
+		return
+	}
+
+	piece, opponentID := connectfour.Player1, game.Player2ID
+	if userCtx.UserID == game.Player2ID {
+		piece, opponentID = connectfour.Player2, game.Player1ID
+	}
+
+	action := req.Action
+	if action == "" {
+		action = connectfour.ActionDrop
+	}
+
+	var row int
+	switch action {
+	case connectfour.ActionDrop:
+		row, err = connectfour.Drop(board, req.Column, piece)
+	case connectfour.ActionPop:
+		if !game.PopOut {
+			jsonError(w, "popping is only allowed in PopOut games", http.StatusBadRequest)
+			return
+		}
+		row = len(board) - 1
+		err = connectfour.Pop(board, req.Column, piece)
+	default:
+		jsonError(w, "invalid action: must be drop or pop", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	game.Board, _ = connectfour.BoardToJSON(board)
+
+	gameOver, winner := connectfour.CheckResult(board, piece, game.PopOut)
+	if gameOver {
+		game.Status = "completed"
+		switch winner {
+		case connectfour.Player1:
+			game.WinnerID = &game.Player1ID
+		case connectfour.Player2:
+			game.WinnerID = &game.Player2ID
+		}
+		// nil WinnerID = draw
+	} else {
+		game.CurrentTurn = opponentID
+	}
+
+	move := &models.ConnectFourMove{
+		GameID: gameID,
+		UserID: userCtx.UserID,
+		Action: action,
+		Column: req.Column,
+		Row:    row,
+	}
+	err = db.PlayConnectFourMove(h.db, game, move)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to save move", http.StatusInternalServerError)
+		return
+	}
+
+	response := h.buildConnectFourResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) ResignConnectFourGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetConnectFourGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status == "completed" {
+		jsonError(w, "game is already completed", http.StatusBadRequest)
+		return
+	}
+
+	game.Status = "completed"
+	if userCtx.UserID == game.Player1ID {
+		game.WinnerID = &game.Player2ID
+	} else {
+		game.WinnerID = &game.Player1ID
+	}
+
+	err = db.UpdateConnectFourGame(h.db, game)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to update game", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, map[string]string{"status": "resigned"}, http.StatusOK)
+}
+
+// buildConnectFourResponse constructs the response with the board and the
+// moves available to the player
+func (h *Handler) buildConnectFourResponse(game *models.ConnectFourGame, userID int64) models.ConnectFourGameResponse {
+	board, _ := connectfour.BoardFromJSON(game.Board)
+
+	piece := connectfour.Player1
+	if userID == game.Player2ID {
+		piece = connectfour.Player2
+	}
+
+	moves, _ := db.GetConnectFourMoves(h.db, game.ID)
+	if moves == nil {
+		moves = []models.ConnectFourMove{}
+	}
+
+	isYourTurn := game.Status == "active" && game.CurrentTurn == userID
+
+	resp := models.ConnectFourGameResponse{
+		Game:       game,
+		Board:      board,
+		YourPiece:  piece,
+		IsYourTurn: isYourTurn,
+		LegalDrops: []int{},
+		Moves:      moves,
+	}
+
+	if isYourTurn {
+		resp.LegalDrops = connectfour.LegalDrops(board)
+		if game.PopOut {
+			resp.LegalPops = connectfour.LegalPops(board, piece)
+		}
+	}
+
+	if game.Status == "completed" && game.WinnerID != nil {
+		winner := connectfour.Player1
+		if *game.WinnerID == game.Player2ID {
+			winner = connectfour.Player2
+		}
+		resp.WinningCells = connectfour.WinningCells(board, winner)
+	}
+
+	return resp
+}
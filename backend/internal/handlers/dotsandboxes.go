@@ -0,0 +1,300 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"altech/internal/db"
+	"altech/internal/dotsandboxes"
+	"altech/internal/middleware"
+	"altech/internal/models"
+)
+
+func (h *Handler) GetDotsAndBoxesGames(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	games, err := db.GetDotsAndBoxesGamesForUser(h.db, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get games", http.StatusInternalServerError)
+		return
+	}
+
+	unread, _ := db.GetUnreadChatCounts(h.db, "dotsandboxes", userCtx.UserID)
+	for i := range games {
+		games[i].UnreadMessages = unread[games[i].ID]
+	}
+
+	response := models.DotsAndBoxesGamesListResponse{
+		YourTurn:  []models.DotsAndBoxesGame{},
+		TheirTurn: []models.DotsAndBoxesGame{},
+		Completed: []models.DotsAndBoxesGame{},
+	}
+
+	for _, game := range games {
+		if game.Status == "completed" {
+			response.Completed = append(response.Completed, game)
+		} else if game.CurrentTurn == userCtx.UserID {
+			response.YourTurn = append(response.YourTurn, game)
+		} else {
+			response.TheirTurn = append(response.TheirTurn, game)
+		}
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// createDotsAndBoxesGame starts a game on an empty board of the chosen size
+func (h *Handler) createDotsAndBoxesGame(creatorID, opponentID int64, settings []byte) (int64, error) {
+	var req models.CreateDotsAndBoxesGameRequest
+	if err := decodeSettings(settings, &req); err != nil {
+		return 0, err
+	}
+
+	// Validate board size
+	boardSize := req.BoardSize
+	if boardSize == "" {
+		boardSize = dotsandboxes.DefaultBoardSize
+	}
+	rows, cols, err := dotsandboxes.ParseBoardSize(boardSize)
+	if err != nil {
+		return 0, err
+	}
+
+	boardJSON, err := dotsandboxes.BoardToJSON(dotsandboxes.NewBoard(rows, cols))
+	if err != nil {
+		return 0, errCreateGame
+	}
+
+	game, err := db.CreateDotsAndBoxesGame(h.db, creatorID, opponentID, boardSize, boardJSON)
+	if err != nil {
+		return 0, errCreateGame
+	}
+
+	return game.ID, nil
+}
+
+func (h *Handler) GetDotsAndBoxesGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetDotsAndBoxesGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	response := h.buildDotsAndBoxesResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) MakeDotsAndBoxesMove(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.DotsAndBoxesMoveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetDotsAndBoxesGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status != "active" {
+		jsonError(w, "game is not active", http.StatusBadRequest)
+		return
+	}
+
+	if game.CurrentTurn != userCtx.UserID {
+		jsonError(w, "not your turn", http.StatusBadRequest)
+		return
+	}
+
+	board, err := dotsandboxes.BoardFromJSON(game.Board)
+	if err != nil {
+		jsonError(w, "failed to parse board", http.StatusInternalServerError)
+		return
+	}
+
+	player := dotsandboxes.Player1
+	if userCtx.UserID == game.Player2ID {
+		player = dotsandboxes.Player2
+	}
+
+	completed, err := dotsandboxes.DrawEdge(board, req.Orientation, req.Row, req.Col, player)
+	if err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if completed == nil {
+		completed = []models.DotsAndBoxesBox{}
+	}
+
+	game.Board, _ = dotsandboxes.BoardToJSON(board)
+	game.Player1Score, game.Player2Score = dotsandboxes.CountBoxes(board)
+
+	gameOver := dotsandboxes.IsComplete(board)
+	if gameOver {
+		game.Status = "completed"
+		if game.Player1Score > game.Player2Score {
+			game.WinnerID = &game.Player1ID
+		} else if game.Player2Score > game.Player1Score {
+			game.WinnerID = &game.Player2ID
+		}
+		// nil WinnerID = draw
+	} else if len(completed) == 0 {
+		// Switch turns
+		if game.CurrentTurn == game.Player1ID {
+			game.CurrentTurn = game.Player2ID
+		} else {
+			game.CurrentTurn = game.Player1ID
+		}
+	}
+	// If a box was completed, current player keeps their turn (no change)
+
+	move := &models.DotsAndBoxesMove{
+		GameID:         gameID,
+		UserID:         userCtx.UserID,
+		Orientation:    req.Orientation,
+		Row:            req.Row,
+		Col:            req.Col,
+		BoxesCompleted: len(completed),
+	}
+	err = db.PlayDotsAndBoxesMove(h.db, game, move)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to save move", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, models.DotsAndBoxesMoveResponse{
+		Completed:    completed,
+		ExtraTurn:    len(completed) > 0 && !gameOver,
+		GameOver:     gameOver,
+		Board:        board,
+		Player1Score: game.Player1Score,
+		Player2Score: game.Player2Score,
+	}, http.StatusOK)
+}
+
+func (h *Handler) ResignDotsAndBoxesGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetDotsAndBoxesGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status == "completed" {
+		jsonError(w, "game is already completed", http.StatusBadRequest)
+		return
+	}
+
+	game.Status = "completed"
+	if userCtx.UserID == game.Player1ID {
+		game.WinnerID = &game.Player2ID
+	} else {
+		game.WinnerID = &game.Player1ID
+	}
+
+	err = db.UpdateDotsAndBoxesGame(h.db, game)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to update game", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, map[string]string{"status": "resigned"}, http.StatusOK)
+}
+
+// buildDotsAndBoxesResponse constructs the response with the board and move list
+func (h *Handler) buildDotsAndBoxesResponse(game *models.DotsAndBoxesGame, userID int64) models.DotsAndBoxesGameResponse {
+	board, _ := dotsandboxes.BoardFromJSON(game.Board)
+
+	player := dotsandboxes.Player1
+	if userID == game.Player2ID {
+		player = dotsandboxes.Player2
+	}
+
+	moves, _ := db.GetDotsAndBoxesMoves(h.db, game.ID)
+	if moves == nil {
+		moves = []models.DotsAndBoxesMove{}
+	}
+
+	return models.DotsAndBoxesGameResponse{
+		Game:       game,
+		Board:      board,
+		YourPlayer: player,
+		IsYourTurn: game.Status == "active" && game.CurrentTurn == userID,
+		Moves:      moves,
+	}
+}
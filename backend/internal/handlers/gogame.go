@@ -0,0 +1,619 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"altech/internal/db"
+	"altech/internal/gogame"
+	"altech/internal/middleware"
+	"altech/internal/models"
+)
+
+func (h *Handler) GetGoGames(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	games, err := db.GetGoGamesForUser(h.db, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get games", http.StatusInternalServerError)
+		return
+	}
+
+	unread, _ := db.GetUnreadChatCounts(h.db, "go", userCtx.UserID)
+	for i := range games {
+		games[i].UnreadMessages = unread[games[i].ID]
+	}
+
+	response := models.GoGamesListResponse{
+		YourTurn:  []models.GoGame{},
+		TheirTurn: []models.GoGame{},
+		Completed: []models.GoGame{},
+	}
+
+	for _, game := range games {
+		if game.Status == "completed" {
+			response.Completed = append(response.Completed, game)
+		} else if game.Status == "scoring" {
+			if goAccepted(&game, userCtx.UserID) {
+				response.TheirTurn = append(response.TheirTurn, game)
+			} else {
+				response.YourTurn = append(response.YourTurn, game)
+			}
+		} else if game.CurrentTurn == userCtx.UserID {
+			response.YourTurn = append(response.YourTurn, game)
+		} else {
+			response.TheirTurn = append(response.TheirTurn, game)
+		}
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// createGoGame starts a game on an empty board with the chosen size, rules
+// and komi
+func (h *Handler) createGoGame(creatorID, opponentID int64, settings []byte) (int64, error) {
+	var req models.CreateGoGameRequest
+	if err := decodeSettings(settings, &req); err != nil {
+		return 0, err
+	}
+
+	size, err := gogame.ValidateSize(req.Size)
+	if err != nil {
+		return 0, err
+	}
+	rules, err := gogame.ValidateRules(req.Rules)
+	if err != nil {
+		return 0, err
+	}
+	komi, err := gogame.ValidateKomi(req.Komi, rules)
+	if err != nil {
+		return 0, err
+	}
+
+	boardJSON, err := gogame.BoardToJSON(gogame.NewBoard(size))
+	if err != nil {
+		return 0, errCreateGame
+	}
+
+	// The creator plays Black and moves first
+	game, err := db.CreateGoGame(h.db, creatorID, opponentID, size, rules, komi, boardJSON)
+	if err != nil {
+		return 0, errCreateGame
+	}
+
+	return game.ID, nil
+}
+
+func (h *Handler) GetGoGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetGoGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	response := h.buildGoResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) MakeGoMove(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.GoMoveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetGoGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status != "active" {
+		jsonError(w, "game is not active", http.StatusBadRequest)
+		return
+	}
+
+	if game.CurrentTurn != userCtx.UserID {
+		jsonError(w, "not your turn", http.StatusBadRequest)
+		return
+	}
+
+	board, err := gogame.BoardFromJSON(game.Board)
+	if err != nil {
+		jsonError(w, "failed to parse board", http.StatusInternalServerError)
+		return
+	}
+
+	color, opponentID := gogame.Black, game.Player2ID
+	if userCtx.UserID == game.Player2ID {
+		color, opponentID = gogame.White, game.Player1ID
+	}
+
+	moves, _ := db.GetGoMoves(h.db, gameID)
+	move := &models.GoMove{
+		GameID:   gameID,
+		UserID:   userCtx.UserID,
+		Color:    color,
+		Row:      -1,
+		Col:      -1,
+		Position: gogame.PositionKey(board),
+	}
+
+	action := req.Action
+	if action == "" {
+		action = gogame.ActionPlay
+	}
+
+	switch action {
+	case gogame.ActionPlay:
+		point := models.GoPoint{Row: req.Row, Col: req.Col}
+		next, captured, err := gogame.Play(board, point, color)
+		if err != nil {
+			jsonError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Positional superko: no move may recreate an earlier position
+		position := gogame.PositionKey(next)
+		for _, m := range moves {
+			if m.Position == position {
+				jsonError(w, "move repeats an earlier position (superko)", http.StatusBadRequest)
+				return
+			}
+		}
+
+		board = next
+		move.Row, move.Col = req.Row, req.Col
+		move.Captured = captured
+		move.Position = position
+		if color == gogame.Black {
+			game.Player1Captures += captured
+		} else {
+			game.Player2Captures += captured
+		}
+		game.ConsecutivePasses = 0
+	case gogame.ActionPass:
+		move.Passed = true
+		game.ConsecutivePasses++
+	default:
+		jsonError(w, "invalid action: must be play or pass", http.StatusBadRequest)
+		return
+	}
+
+	game.Board, _ = gogame.BoardToJSON(board)
+	game.CurrentTurn = opponentID
+
+	// Two passes in a row end play; both players then mark dead stones
+	if game.ConsecutivePasses >= 2 {
+		game.Status = "scoring"
+		game.DeadStones = "[]"
+		game.Player1Accepted, game.Player2Accepted = false, false
+	}
+
+	err = db.PlayGoMove(h.db, game, move)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to save move", http.StatusInternalServerError)
+		return
+	}
+
+	response := h.buildGoResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// MarkGoDeadStones toggles a group between dead and alive while scoring.
+// Any change withdraws both players' acceptance.
+func (h *Handler) MarkGoDeadStones(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.GoMarkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetGoGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status != "scoring" {
+		jsonError(w, "game is not being scored", http.StatusBadRequest)
+		return
+	}
+
+	board, err := gogame.BoardFromJSON(game.Board)
+	if err != nil {
+		jsonError(w, "failed to parse board", http.StatusInternalServerError)
+		return
+	}
+	dead, err := gogame.PointsFromJSON(game.DeadStones)
+	if err != nil {
+		jsonError(w, "failed to parse dead stones", http.StatusInternalServerError)
+		return
+	}
+
+	dead, err = gogame.ToggleDead(board, dead, models.GoPoint{Row: req.Row, Col: req.Col})
+	if err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	game.DeadStones, _ = gogame.PointsToJSON(dead)
+	game.Player1Accepted, game.Player2Accepted = false, false
+
+	err = db.UpdateGoScoring(h.db, game)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to update game", http.StatusInternalServerError)
+		return
+	}
+
+	response := h.buildGoResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// AcceptGoScore agrees to the current dead stones. Once both players have
+// accepted, the game is scored.
+func (h *Handler) AcceptGoScore(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetGoGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status != "scoring" {
+		jsonError(w, "game is not being scored", http.StatusBadRequest)
+		return
+	}
+
+	if userCtx.UserID == game.Player1ID {
+		game.Player1Accepted = true
+	} else {
+		game.Player2Accepted = true
+	}
+
+	if game.Player1Accepted && game.Player2Accepted {
+		score := goScore(game)
+		game.Status = "completed"
+		game.EndReason = gogame.EndScored
+		game.Player1Score, game.Player2Score = score.Black, score.White
+		if score.Black > score.White {
+			game.WinnerID = &game.Player1ID
+		} else if score.White > score.Black {
+			game.WinnerID = &game.Player2ID
+		}
+		// nil WinnerID = draw (jigo)
+	}
+
+	err = db.UpdateGoScoring(h.db, game)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to update game", http.StatusInternalServerError)
+		return
+	}
+
+	response := h.buildGoResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// ResumeGo goes back to play when the players cannot agree on dead stones.
+// The opponent of the player resuming moves first.
+func (h *Handler) ResumeGo(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetGoGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status != "scoring" {
+		jsonError(w, "game is not being scored", http.StatusBadRequest)
+		return
+	}
+
+	game.Status = "active"
+	game.ConsecutivePasses = 0
+	game.DeadStones = "[]"
+	game.Player1Accepted, game.Player2Accepted = false, false
+	if userCtx.UserID == game.Player1ID {
+		game.CurrentTurn = game.Player2ID
+	} else {
+		game.CurrentTurn = game.Player1ID
+	}
+
+	err = db.UpdateGoScoring(h.db, game)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to update game", http.StatusInternalServerError)
+		return
+	}
+
+	response := h.buildGoResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) ResignGoGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetGoGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status == "completed" {
+		jsonError(w, "game is already completed", http.StatusBadRequest)
+		return
+	}
+
+	game.Status = "completed"
+	game.EndReason = gogame.EndResigned
+	if userCtx.UserID == game.Player1ID {
+		game.WinnerID = &game.Player2ID
+	} else {
+		game.WinnerID = &game.Player1ID
+	}
+
+	err = db.UpdateGoGame(h.db, game)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to update game", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, map[string]string{"status": "resigned"}, http.StatusOK)
+}
+
+func (h *Handler) GetGoSGF(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetGoGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	moves, err := db.GetGoMoves(h.db, gameID)
+	if err != nil {
+		jsonError(w, "failed to get moves", http.StatusInternalServerError)
+		return
+	}
+
+	info := gogame.SGFInfo{
+		Size:  game.Size,
+		Komi:  game.Komi,
+		Rules: game.Rules,
+		Date:  game.CreatedAt.Format("2006-01-02"),
+	}
+	if game.Player1 != nil {
+		info.BlackName = game.Player1.Username
+	}
+	if game.Player2 != nil {
+		info.WhiteName = game.Player2.Username
+	}
+	if game.Status == "completed" {
+		winner := gogame.Empty
+		if game.WinnerID != nil && *game.WinnerID == game.Player1ID {
+			winner = gogame.Black
+		} else if game.WinnerID != nil {
+			winner = gogame.White
+		}
+		margin := game.Player1Score - game.Player2Score
+		if margin < 0 {
+			margin = -margin
+		}
+		info.Result = gogame.SGFResult(winner, game.EndReason == gogame.EndResigned, margin)
+	}
+
+	jsonResponse(w, models.GoSGFResponse{
+		SGF: gogame.WriteSGF(info, moves),
+	}, http.StatusOK)
+}
+
+func goAccepted(game *models.GoGame, userID int64) bool {
+	if userID == game.Player1ID {
+		return game.Player1Accepted
+	}
+	return game.Player2Accepted
+}
+
+// goScore counts the board with the currently marked dead stones
+func goScore(game *models.GoGame) models.GoScore {
+	board, _ := gogame.BoardFromJSON(game.Board)
+	dead, _ := gogame.PointsFromJSON(game.DeadStones)
+	return gogame.Score(board, dead, game.Rules, game.Komi, game.Player1Captures, game.Player2Captures)
+}
+
+// buildGoResponse constructs the response with the board and, once play has
+// stopped, the dead stones and score
+func (h *Handler) buildGoResponse(game *models.GoGame, userID int64) models.GoGameResponse {
+	board, _ := gogame.BoardFromJSON(game.Board)
+	dead, _ := gogame.PointsFromJSON(game.DeadStones)
+
+	color := gogame.Black
+	if userID == game.Player2ID {
+		color = gogame.White
+	}
+
+	moves, _ := db.GetGoMoves(h.db, game.ID)
+	if moves == nil {
+		moves = []models.GoMove{}
+	}
+
+	resp := models.GoGameResponse{
+		Game:       game,
+		Board:      board,
+		YourColor:  color,
+		IsYourTurn: game.Status == "active" && game.CurrentTurn == userID,
+		DeadStones: dead,
+		Moves:      moves,
+	}
+
+	if game.Status == "scoring" || game.EndReason == gogame.EndScored {
+		score := goScore(game)
+		resp.Score = &score
+	}
+
+	return resp
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"altech/internal/auth"
+	"altech/internal/chat"
 	"altech/internal/db"
 	"altech/internal/middleware"
 	"altech/internal/models"
@@ -16,14 +17,18 @@ import (
 )
 
 type Handler struct {
-	db        *sql.DB
-	jwtSecret string
+	db          *sql.DB
+	jwtSecret   string
+	dailySecret string // Salts the daily puzzles; kept apart from the JWT key
+	chatHub     *chat.Hub
 }
 
-func New(database *sql.DB, jwtSecret string) *Handler {
+func New(database *sql.DB, jwtSecret, dailySecret string) *Handler {
 	return &Handler{
-		db:        database,
-		jwtSecret: jwtSecret,
+		db:          database,
+		jwtSecret:   jwtSecret,
+		dailySecret: dailySecret,
+		chatHub:     chat.NewHub(),
 	}
 }
 
@@ -0,0 +1,385 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"altech/internal/battleship"
+	"altech/internal/chess"
+	"altech/internal/connectfour"
+	"altech/internal/db"
+	"altech/internal/dotsandboxes"
+	"altech/internal/gogame"
+	"altech/internal/mastermind"
+	"altech/internal/memory"
+	"altech/internal/middleware"
+	"altech/internal/models"
+)
+
+var (
+	errNotFriends = errors.New("can only play with friends")
+	// errCreateGame is a failure on our side; any other error from creating a
+	// game is a problem with its settings
+	errCreateGame = errors.New("failed to create game")
+)
+
+const (
+	defaultInvitationExpiry = 24 * time.Hour
+	minInvitationExpiry     = 5 * time.Minute
+	maxInvitationExpiry     = 7 * 24 * time.Hour
+)
+
+// playableGame is a game type that can be started on a player's behalf, by
+// invitation or rematch. validate checks variant settings up front so a bad
+// invitation is refused when sent rather than when accepted.
+type playableGame struct {
+	create   func(h *Handler, creatorID, opponentID int64, settings []byte) (int64, error)
+	validate func(settings []byte) error
+}
+
+// settings checks variant settings for a game created later on a player's
+// behalf and returns them ready to store. They must be an object; any
+// opponent in them is dropped.
+func (g playableGame) settings(raw json.RawMessage) ([]byte, error) {
+	settings := map[string]json.RawMessage{}
+	if len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &settings); err != nil {
+			return nil, errors.New("settings must be an object")
+		}
+	}
+	delete(settings, "opponent_id")
+	settingsJSON, _ := json.Marshal(settings)
+
+	if g.validate != nil {
+		if err := g.validate(settingsJSON); err != nil {
+			return nil, err
+		}
+	}
+	return settingsJSON, nil
+}
+
+// playableGames maps game types to the functions that create them. Games are
+// only started on a player's behalf, through createGame.
+var playableGames = map[string]playableGame{
+	"scrabble": {(*Handler).createScrabbleGame, nil},
+	"battleship": {(*Handler).createBattleshipGame, func(settings []byte) error {
+		var req models.CreateBattleshipGameRequest
+		if err := json.Unmarshal(settings, &req); err != nil {
+			return err
+		}
+		_, err := battleship.NewRules(req.BoardSize, req.Fleet, req.Salvo, req.NoTouch)
+		return err
+	}},
+	"mastermind": {(*Handler).createMastermindGame, func(settings []byte) error {
+		var req models.CreateMastermindGameRequest
+		if err := json.Unmarshal(settings, &req); err != nil {
+			return err
+		}
+		codeLength := mastermind.ValidateCodeLength(req.CodeLength)
+		numColors := mastermind.ValidateNumColors(req.NumColors)
+		return mastermind.ValidateSettings(codeLength, numColors, req.AllowRepeats)
+	}},
+	"wordmind": {(*Handler).createWordmindGame, nil},
+	"memory": {(*Handler).createMemoryGame, func(settings []byte) error {
+		var req models.CreateMemoryGameRequest
+		if err := json.Unmarshal(settings, &req); err != nil {
+			return err
+		}
+		if req.BoardSize == "" {
+			req.BoardSize = memory.DefaultBoardSize
+		}
+		if req.Theme == "" {
+			req.Theme = memory.DefaultTheme
+		}
+		rows, cols, err := memory.ParseBoardSize(req.BoardSize)
+		if err != nil {
+			return err
+		}
+		return memory.ValidateBoard(rows, cols, memory.ValidateMatchSize(req.MatchSize), req.Theme)
+	}},
+	"connectfour": {(*Handler).createConnectFourGame, func(settings []byte) error {
+		var req models.CreateConnectFourGameRequest
+		if err := json.Unmarshal(settings, &req); err != nil {
+			return err
+		}
+		if req.BoardSize == "" {
+			return nil
+		}
+		_, _, err := connectfour.ParseBoardSize(req.BoardSize)
+		return err
+	}},
+	"checkers": {(*Handler).createCheckersGame, nil},
+	"chess": {(*Handler).createChessGame, func(settings []byte) error {
+		var req models.CreateChessGameRequest
+		if err := json.Unmarshal(settings, &req); err != nil {
+			return err
+		}
+		switch {
+		case req.FEN != "" && req.PGN != "":
+			return errors.New("give either a FEN or a PGN, not both")
+		case req.PGN != "":
+			_, err := chess.ParsePGN(req.PGN)
+			return err
+		case req.FEN != "":
+			_, err := chess.ParseFEN(req.FEN)
+			return err
+		}
+		return nil
+	}},
+	"boggle": {(*Handler).createBoggleGame, nil},
+	"dotsandboxes": {(*Handler).createDotsAndBoxesGame, func(settings []byte) error {
+		var req models.CreateDotsAndBoxesGameRequest
+		if err := json.Unmarshal(settings, &req); err != nil {
+			return err
+		}
+		if req.BoardSize == "" {
+			return nil
+		}
+		_, _, err := dotsandboxes.ParseBoardSize(req.BoardSize)
+		return err
+	}},
+	"othello": {(*Handler).createOthelloGame, nil},
+	"go": {(*Handler).createGoGame, func(settings []byte) error {
+		var req models.CreateGoGameRequest
+		if err := json.Unmarshal(settings, &req); err != nil {
+			return err
+		}
+		if _, err := gogame.ValidateSize(req.Size); err != nil {
+			return err
+		}
+		rules, err := gogame.ValidateRules(req.Rules)
+		if err != nil {
+			return err
+		}
+		_, err = gogame.ValidateKomi(req.Komi, rules)
+		return err
+	}},
+	"yahtzee": {(*Handler).createYahtzeeGame, nil},
+}
+
+func (h *Handler) CreateGameInvitation(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.CreateGameInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	game, ok := playableGames[req.GameType]
+	if !ok {
+		jsonError(w, "unknown game type", http.StatusBadRequest)
+		return
+	}
+
+	if req.OpponentID == userCtx.UserID {
+		jsonError(w, "cannot play against yourself", http.StatusBadRequest)
+		return
+	}
+
+	// Verify friendship
+	isFriend, err := db.CheckFriendship(h.db, userCtx.UserID, req.OpponentID)
+	if err != nil || !isFriend {
+		jsonError(w, "can only play with friends", http.StatusForbidden)
+		return
+	}
+
+	// The opponent is filled in on acceptance
+	settingsJSON, err := game.settings(req.Settings)
+	if err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	expiry := defaultInvitationExpiry
+	if req.ExpiresInMinutes != 0 {
+		expiry = time.Duration(req.ExpiresInMinutes) * time.Minute
+		if expiry < minInvitationExpiry || expiry > maxInvitationExpiry {
+			jsonError(w, "expires_in_minutes must be between 5 and 10080", http.StatusBadRequest)
+			return
+		}
+	}
+
+	inv, err := db.CreateGameInvitation(h.db, userCtx.UserID, req.OpponentID, req.GameType, string(settingsJSON), time.Now().Add(expiry))
+	if err != nil {
+		jsonError(w, "failed to create invitation", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, inv, http.StatusCreated)
+}
+
+func (h *Handler) GetIncomingInvitations(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	invitations, err := db.GetIncomingGameInvitations(h.db, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get invitations", http.StatusInternalServerError)
+		return
+	}
+
+	if invitations == nil {
+		invitations = []models.GameInvitation{}
+	}
+
+	jsonResponse(w, models.GameInvitationsResponse{Invitations: invitations}, http.StatusOK)
+}
+
+func (h *Handler) GetOutgoingInvitations(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	invitations, err := db.GetOutgoingGameInvitations(h.db, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get invitations", http.StatusInternalServerError)
+		return
+	}
+
+	if invitations == nil {
+		invitations = []models.GameInvitation{}
+	}
+
+	jsonResponse(w, models.GameInvitationsResponse{Invitations: invitations}, http.StatusOK)
+}
+
+func (h *Handler) RespondToGameInvitation(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Extract invitation ID from path
+	parts := strings.Split(r.URL.Path, "/")
+	invitationID, err := strconv.ParseInt(parts[len(parts)-1], 10, 64)
+	if err != nil {
+		jsonError(w, "invalid invitation ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.GameInvitationAction
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	inv, err := db.GetGameInvitation(h.db, invitationID)
+	if err == db.ErrInvitationNotFound {
+		jsonError(w, "invitation not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get invitation", http.StatusInternalServerError)
+		return
+	}
+
+	// Only the receiver may answer and only the sender may cancel
+	var status string
+	switch {
+	case req.Action == "accept" && inv.ReceiverID == userCtx.UserID:
+		status = "accepted"
+	case req.Action == "decline" && inv.ReceiverID == userCtx.UserID:
+		status = "declined"
+	case req.Action == "cancel" && inv.SenderID == userCtx.UserID:
+		status = "cancelled"
+	case req.Action != "accept" && req.Action != "decline" && req.Action != "cancel":
+		jsonError(w, "action must be 'accept', 'decline' or 'cancel'", http.StatusBadRequest)
+		return
+	default:
+		jsonError(w, "invitation not found", http.StatusNotFound)
+		return
+	}
+
+	if inv.Status == "expired" {
+		jsonError(w, "invitation has expired", http.StatusBadRequest)
+		return
+	}
+
+	if err := db.RespondToGameInvitation(h.db, inv.ID, status); err != nil {
+		if err == db.ErrInvitationExpired {
+			jsonError(w, "invitation has expired", http.StatusBadRequest)
+			return
+		}
+		if err == db.ErrInvitationNotPending {
+			jsonError(w, "invitation is not pending", http.StatusConflict)
+			return
+		}
+		jsonError(w, "failed to update invitation", http.StatusInternalServerError)
+		return
+	}
+
+	if status == "accepted" {
+		gameID, err := h.createGame(inv.GameType, inv.SenderID, inv.ReceiverID, inv.Settings)
+		if err != nil {
+			db.ReopenGameInvitation(h.db, inv.ID)
+			createGameError(w, err)
+			return
+		}
+		// An accepted invitation with no game could never be played, so
+		// reopen it for the receiver to accept again
+		if err := db.SetGameInvitationGame(h.db, inv.ID, gameID); err != nil {
+			db.ReopenGameInvitation(h.db, inv.ID)
+			jsonError(w, "failed to link game", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	inv, err = db.GetGameInvitation(h.db, inv.ID)
+	if err != nil {
+		jsonError(w, "failed to get invitation", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, inv, http.StatusOK)
+}
+
+// createGame starts a game of gameType between two friends with the given
+// variant settings. The creator moves first.
+func (h *Handler) createGame(gameType string, creatorID, opponentID int64, settings []byte) (int64, error) {
+	if creatorID == opponentID {
+		return 0, errors.New("cannot play against yourself")
+	}
+
+	// Verify friendship
+	isFriend, err := db.CheckFriendship(h.db, creatorID, opponentID)
+	if err != nil || !isFriend {
+		return 0, errNotFriends
+	}
+
+	return playableGames[gameType].create(h, creatorID, opponentID, settings)
+}
+
+// createGameError responds with the status for an error from createGame
+func createGameError(w http.ResponseWriter, err error) {
+	switch err {
+	case errNotFriends:
+		jsonError(w, err.Error(), http.StatusForbidden)
+	case errCreateGame:
+		jsonError(w, err.Error(), http.StatusInternalServerError)
+	default:
+		jsonError(w, err.Error(), http.StatusBadRequest)
+	}
+}
+
+// decodeSettings reads a game's variant settings into its create request
+func decodeSettings(settings []byte, req any) error {
+	if len(settings) == 0 {
+		return nil
+	}
+	return json.Unmarshal(settings, req)
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"altech/internal/db"
 	"altech/internal/mastermind"
@@ -23,6 +24,11 @@ func (h *Handler) GetMastermindGames(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	unread, _ := db.GetUnreadChatCounts(h.db, "mastermind", userCtx.UserID)
+	for i := range games {
+		games[i].UnreadMessages = unread[games[i].ID]
+	}
+
 	response := models.MastermindGamesListResponse{
 		YourTurn:  []models.MastermindGame{},
 		TheirTurn: []models.MastermindGame{},
@@ -50,53 +56,32 @@ func (h *Handler) GetMastermindGames(w http.ResponseWriter, r *http.Request) {
 	jsonResponse(w, response, http.StatusOK)
 }
 
-func (h *Handler) CreateMastermindGame(w http.ResponseWriter, r *http.Request) {
-	userCtx := middleware.GetUser(r)
-	if userCtx == nil {
-		jsonError(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
+// createMastermindGame starts a game in the setup phase, where each player
+// picks a code for the other
+func (h *Handler) createMastermindGame(creatorID, opponentID int64, settings []byte) (int64, error) {
 	var req models.CreateMastermindGameRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		jsonError(w, "invalid request body", http.StatusBadRequest)
-		return
-	}
-
-	if req.OpponentID == userCtx.UserID {
-		jsonError(w, "cannot play against yourself", http.StatusBadRequest)
-		return
-	}
-
-	// Verify friendship
-	isFriend, err := db.CheckFriendship(h.db, userCtx.UserID, req.OpponentID)
-	if err != nil || !isFriend {
-		jsonError(w, "can only play with friends", http.StatusForbidden)
-		return
+	if err := decodeSettings(settings, &req); err != nil {
+		return 0, err
 	}
 
 	// Validate and set defaults for difficulty options
 	numColors := mastermind.ValidateNumColors(req.NumColors)
+	codeLength := mastermind.ValidateCodeLength(req.CodeLength)
+	maxGuesses := mastermind.ValidateMaxGuesses(req.MaxGuesses)
 	allowRepeats := req.AllowRepeats
+	assistant := mastermind.ValidateAssistant(req.Assistant)
 
-	// If numColors is 4 and repeats disabled, that's only 4 options for 4 slots - force enable repeats
-	// Actually allow it, but keep the validation
+	// Without repeats every peg needs its own colour
+	if err := mastermind.ValidateSettings(codeLength, numColors, allowRepeats); err != nil {
+		return 0, err
+	}
 
-	game, err := db.CreateMastermindGame(h.db, userCtx.UserID, req.OpponentID, numColors, allowRepeats)
+	game, err := db.CreateMastermindGame(h.db, creatorID, opponentID, numColors, codeLength, maxGuesses, allowRepeats, assistant)
 	if err != nil {
-		jsonError(w, "failed to create game", http.StatusInternalServerError)
-		return
+		return 0, errCreateGame
 	}
 
-	jsonResponse(w, models.MastermindGameResponse{
-		Game:         game,
-		MyGuesses:    []models.MastermindGuessResponse{},
-		TheirGuesses: []models.MastermindGuessResponse{},
-		SecretSet:    false,
-		IsYourTurn:   true,
-		Phase:        "setup",
-		Round:        0,
-	}, http.StatusCreated)
+	return game.ID, nil
 }
 
 func (h *Handler) GetMastermindGame(w http.ResponseWriter, r *http.Request) {
@@ -173,7 +158,7 @@ func (h *Handler) SetMastermindSecret(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate the code against game settings
-	if err := mastermind.ValidateCode(req.Code, game.NumColors, game.AllowRepeats); err != nil {
+	if err := mastermind.ValidateCode(req.Code, game.CodeLength, game.NumColors, game.AllowRepeats); err != nil {
 		jsonError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -245,7 +230,7 @@ func (h *Handler) MakeMastermindGuess(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate the guess against game settings
-	if err := mastermind.ValidateCode(req.Guess, game.NumColors, game.AllowRepeats); err != nil {
+	if err := mastermind.ValidateCode(req.Guess, game.CodeLength, game.NumColors, game.AllowRepeats); err != nil {
 		jsonError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -306,7 +291,7 @@ func (h *Handler) MakeMastermindGuess(w http.ResponseWriter, r *http.Request) {
 		game.Player1ID, game.Player2ID,
 		p1Guesses, p2Guesses,
 		p1Secret, p2Secret,
-		game.MaxGuesses,
+		game.MaxGuesses, game.CodeLength,
 	)
 
 	if gameOver {
@@ -326,6 +311,90 @@ func (h *Handler) MakeMastermindGuess(w http.ResponseWriter, r *http.Request) {
 	jsonResponse(w, response, http.StatusOK)
 }
 
+func (h *Handler) MastermindAssistant(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.MastermindAssistantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetMastermindGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	// Check user is a player
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Assistant == mastermind.AssistantOff {
+		jsonError(w, "assistant is disabled for this game", http.StatusForbidden)
+		return
+	}
+	if req.RevealBest && game.Assistant != mastermind.AssistantSolver {
+		jsonError(w, "best guess is disabled for this game", http.StatusForbidden)
+		return
+	}
+
+	// Only the player's own guesses and feedback are used
+	myGuesses, err := db.GetMastermindGuesses(h.db, gameID, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get guesses", http.StatusInternalServerError)
+		return
+	}
+	guesses := convertToGuessResults(myGuesses)
+
+	count, candidates := mastermind.Candidates(guesses, game.CodeLength, game.NumColors, game.AllowRepeats)
+	response := models.MastermindAssistantResponse{
+		RemainingCandidates: count,
+	}
+
+	if len(req.Guess) > 0 {
+		if err := mastermind.ValidateCode(req.Guess, game.CodeLength, game.NumColors, game.AllowRepeats); err != nil {
+			jsonError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		contradictions := mastermind.Contradictions(req.Guess, guesses)
+		consistent := len(contradictions) == 0
+		response.Consistent = &consistent
+		for _, c := range contradictions {
+			response.Contradictions = append(response.Contradictions, models.MastermindContradiction{
+				GuessNumber:    c.GuessNumber,
+				Correct:        c.Correct,
+				Misplaced:      c.Misplaced,
+				WouldCorrect:   c.WouldCorrect,
+				WouldMisplaced: c.WouldMisplaced,
+			})
+		}
+	}
+
+	if req.RevealBest {
+		response.BestGuess = mastermind.BestGuess(candidates)
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
 func (h *Handler) ResignMastermindGame(w http.ResponseWriter, r *http.Request) {
 	userCtx := middleware.GetUser(r)
 	if userCtx == nil {
@@ -373,6 +442,152 @@ func (h *Handler) ResignMastermindGame(w http.ResponseWriter, r *http.Request) {
 	jsonResponse(w, map[string]string{"status": "resigned"}, http.StatusOK)
 }
 
+func (h *Handler) GetMastermindDaily(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	date, daily, err := h.currentMastermindDaily(userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get daily puzzle", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, h.buildMastermindDailyResponse(date, daily), http.StatusOK)
+}
+
+func (h *Handler) StartMastermindDaily(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	date := mastermind.DailyDate(time.Now())
+	daily, err := db.CreateMastermindDaily(h.db, userCtx.UserID, date)
+	if err == db.ErrDailyAlreadyPlayed {
+		jsonError(w, "you have already played today's puzzle", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to start daily puzzle", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, h.buildMastermindDailyResponse(date, daily), http.StatusCreated)
+}
+
+func (h *Handler) MakeMastermindDailyGuess(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.MakeMastermindGuessRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	date, daily, err := h.currentMastermindDaily(userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get daily puzzle", http.StatusInternalServerError)
+		return
+	}
+	if daily == nil {
+		jsonError(w, "daily puzzle not started", http.StatusNotFound)
+		return
+	}
+
+	if daily.Status != "active" {
+		jsonError(w, "daily puzzle is already finished", http.StatusBadRequest)
+		return
+	}
+
+	if err := mastermind.ValidateCode(req.Guess, mastermind.DailyCodeLength, mastermind.DailyNumColors, mastermind.DailyAllowRepeats); err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	secret := mastermind.DailyCode(daily.Date, h.dailySecret)
+	correct, misplaced := mastermind.EvaluateGuess(secret, req.Guess)
+
+	// The unique guess number rejects a second guess racing this one
+	guessJSON, _ := mastermind.CodeToJSON(req.Guess)
+	if err := db.CreateMastermindDailyGuess(h.db, daily.ID, guessJSON, correct, misplaced, daily.GuessCount+1); err != nil {
+		jsonError(w, "failed to save guess", http.StatusInternalServerError)
+		return
+	}
+
+	guesses, _ := db.GetMastermindDailyGuesses(h.db, daily.ID)
+	results := convertDailyToGuessResults(guesses)
+
+	daily.GuessCount = len(results)
+	finished, solved := mastermind.CheckDailyResult(results, mastermind.DailyCodeLength, mastermind.DailyMaxGuesses)
+	if finished {
+		now := time.Now()
+		daily.Status = "failed"
+		if solved {
+			daily.Status = "solved"
+		}
+		daily.CompletedAt = &now
+		daily.DurationMs = now.Sub(daily.StartedAt).Milliseconds()
+	}
+	if err := db.UpdateMastermindDaily(h.db, daily); err != nil {
+		jsonError(w, "failed to update daily puzzle", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, h.buildMastermindDailyResponse(date, daily), http.StatusOK)
+}
+
+func (h *Handler) GetMastermindDailyLeaderboard(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	date := r.URL.Query().Get("date")
+	if date == "" {
+		date = mastermind.DailyDate(time.Now())
+	} else if _, err := time.Parse(mastermind.DailyDateFormat, date); err != nil {
+		jsonError(w, "invalid date: must be YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	// Attempts abandoned past the grace period count as failed
+	if err := db.FailStaleMastermindDailies(h.db, staleMastermindDailyDate()); err != nil {
+		jsonError(w, "failed to get leaderboard", http.StatusInternalServerError)
+		return
+	}
+
+	attempts, err := db.GetMastermindDailyLeaderboard(h.db, userCtx.UserID, date)
+	if err != nil {
+		jsonError(w, "failed to get leaderboard", http.StatusInternalServerError)
+		return
+	}
+
+	response := models.MastermindDailyLeaderboardResponse{
+		Date:    date,
+		Entries: []models.MastermindDailyLeaderboardEntry{},
+	}
+	for i, attempt := range attempts {
+		response.Entries = append(response.Entries, models.MastermindDailyLeaderboardEntry{
+			Rank:       i + 1,
+			User:       attempt.User,
+			Solved:     attempt.Status == "solved",
+			GuessCount: attempt.GuessCount,
+			DurationMs: attempt.DurationMs,
+		})
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
 // Helper functions
 
 func (h *Handler) buildMastermindResponse(game *models.MastermindGame, userID int64) models.MastermindGameResponse {
@@ -466,3 +681,82 @@ func convertToGuessResponses(guesses []models.MastermindGuess) []models.Mastermi
 	}
 	return responses
 }
+
+// currentMastermindDaily returns the puzzle date a user is playing and their
+// attempt at it, nil if not started. An attempt begun before midnight is
+// still theirs to finish during the grace period; after that it is closed as
+// failed and today's puzzle is current.
+func (h *Handler) currentMastermindDaily(userID int64) (string, *models.MastermindDaily, error) {
+	if err := db.FailStaleMastermindDailies(h.db, staleMastermindDailyDate()); err != nil {
+		return "", nil, err
+	}
+
+	daily, err := db.GetActiveMastermindDaily(h.db, userID)
+	if err == nil {
+		return daily.Date, daily, nil
+	}
+	if err != db.ErrDailyNotFound {
+		return "", nil, err
+	}
+
+	date := mastermind.DailyDate(time.Now())
+	daily, err = db.GetMastermindDaily(h.db, userID, date)
+	if err == db.ErrDailyNotFound {
+		return date, nil, nil
+	}
+	return date, daily, err
+}
+
+// staleMastermindDailyDate returns the earliest puzzle date whose attempts
+// can still be finished
+func staleMastermindDailyDate() string {
+	return mastermind.DailyDate(time.Now().Add(-mastermind.DailyGracePeriod))
+}
+
+func (h *Handler) buildMastermindDailyResponse(date string, daily *models.MastermindDaily) models.MastermindDailyResponse {
+	response := models.MastermindDailyResponse{
+		Date:         date,
+		CodeLength:   mastermind.DailyCodeLength,
+		NumColors:    mastermind.DailyNumColors,
+		MaxGuesses:   mastermind.DailyMaxGuesses,
+		AllowRepeats: mastermind.DailyAllowRepeats,
+		Attempt:      daily,
+		Guesses:      []models.MastermindDailyGuessResponse{},
+	}
+	if daily == nil {
+		return response
+	}
+
+	guesses, _ := db.GetMastermindDailyGuesses(h.db, daily.ID)
+	for _, g := range guesses {
+		code, _ := mastermind.CodeFromJSON(g.Guess)
+		response.Guesses = append(response.Guesses, models.MastermindDailyGuessResponse{
+			Guess:       code,
+			Correct:     g.Correct,
+			Misplaced:   g.Misplaced,
+			GuessNumber: g.GuessNumber,
+			CreatedAt:   g.CreatedAt,
+		})
+	}
+
+	// Show the code only once this attempt is over
+	if daily.Status != "active" {
+		response.Secret = mastermind.DailyCode(daily.Date, h.dailySecret)
+	}
+
+	return response
+}
+
+func convertDailyToGuessResults(guesses []models.MastermindDailyGuess) []mastermind.GuessResult {
+	results := make([]mastermind.GuessResult, len(guesses))
+	for i, g := range guesses {
+		code, _ := mastermind.CodeFromJSON(g.Guess)
+		results[i] = mastermind.GuessResult{
+			Guess:       code,
+			Correct:     g.Correct,
+			Misplaced:   g.Misplaced,
+			GuessNumber: g.GuessNumber,
+		}
+	}
+	return results
+}
@@ -2,7 +2,11 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
+	"time"
 
 	"altech/internal/db"
 	"altech/internal/memory"
@@ -23,6 +27,11 @@ func (h *Handler) GetMemoryGames(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	unread, _ := db.GetUnreadChatCounts(h.db, "memory", userCtx.UserID)
+	for i := range games {
+		games[i].UnreadMessages = unread[games[i].ID]
+	}
+
 	response := models.MemoryGamesListResponse{
 		YourTurn:  []models.MemoryGame{},
 		TheirTurn: []models.MemoryGame{},
@@ -42,65 +51,50 @@ func (h *Handler) GetMemoryGames(w http.ResponseWriter, r *http.Request) {
 	jsonResponse(w, response, http.StatusOK)
 }
 
-func (h *Handler) CreateMemoryGame(w http.ResponseWriter, r *http.Request) {
-	userCtx := middleware.GetUser(r)
-	if userCtx == nil {
-		jsonError(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
+// createMemoryGame starts a game on a freshly shuffled board
+func (h *Handler) createMemoryGame(creatorID, opponentID int64, settings []byte) (int64, error) {
 	var req models.CreateMemoryGameRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		jsonError(w, "invalid request body", http.StatusBadRequest)
-		return
+	if err := decodeSettings(settings, &req); err != nil {
+		return 0, err
 	}
 
-	if req.OpponentID == userCtx.UserID {
-		jsonError(w, "cannot play against yourself", http.StatusBadRequest)
-		return
-	}
-
-	// Verify friendship
-	isFriend, err := db.CheckFriendship(h.db, userCtx.UserID, req.OpponentID)
-	if err != nil || !isFriend {
-		jsonError(w, "can only play with friends", http.StatusForbidden)
-		return
-	}
-
-	// Validate board size
+	// Validate board size, match size and theme
 	boardSize := req.BoardSize
 	if boardSize == "" {
-		boardSize = "4x5"
+		boardSize = memory.DefaultBoardSize
 	}
 	rows, cols, err := memory.ParseBoardSize(boardSize)
 	if err != nil {
-		jsonError(w, err.Error(), http.StatusBadRequest)
-		return
+		return 0, err
+	}
+	matchSize := memory.ValidateMatchSize(req.MatchSize)
+	theme := req.Theme
+	if theme == "" {
+		theme = memory.DefaultTheme
+	}
+	if err := memory.ValidateBoard(rows, cols, matchSize, theme); err != nil {
+		return 0, err
 	}
 
 	// Generate board
-	board := memory.GenerateBoard(rows, cols)
+	board := memory.GenerateBoard(rows, cols, matchSize)
 	boardJSON, err := memory.BoardToJSON(board)
 	if err != nil {
-		jsonError(w, "failed to generate board", http.StatusInternalServerError)
-		return
+		return 0, errCreateGame
 	}
 
 	matched := memory.InitMatched(rows, cols)
 	matchedJSON, err := memory.MatchedToJSON(matched)
 	if err != nil {
-		jsonError(w, "failed to initialize game", http.StatusInternalServerError)
-		return
+		return 0, errCreateGame
 	}
 
-	game, err := db.CreateMemoryGame(h.db, userCtx.UserID, req.OpponentID, boardSize, boardJSON, matchedJSON)
+	game, err := db.CreateMemoryGame(h.db, creatorID, opponentID, fmt.Sprintf("%dx%d", rows, cols), matchSize, theme, boardJSON, matchedJSON)
 	if err != nil {
-		jsonError(w, "failed to create game", http.StatusInternalServerError)
-		return
+		return 0, errCreateGame
 	}
 
-	response := h.buildMemoryResponse(game, userCtx.UserID)
-	jsonResponse(w, response, http.StatusCreated)
+	return game.ID, nil
 }
 
 func (h *Handler) GetMemoryGame(w http.ResponseWriter, r *http.Request) {
@@ -192,48 +186,48 @@ func (h *Handler) RevealTiles(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rows := len(board)
-	cols := len(board[0])
-
-	// Validate positions
-	if req.Row1 < 0 || req.Row1 >= rows || req.Col1 < 0 || req.Col1 >= cols ||
-		req.Row2 < 0 || req.Row2 >= rows || req.Col2 < 0 || req.Col2 >= cols {
-		jsonError(w, "position out of bounds", http.StatusBadRequest)
-		return
-	}
-
-	if req.Row1 == req.Row2 && req.Col1 == req.Col2 {
-		jsonError(w, "must select two different tiles", http.StatusBadRequest)
-		return
+	// Older clients send two tiles as row1/col1/row2/col2
+	positions := req.Positions
+	if len(positions) == 0 {
+		positions = []models.MemoryPosition{
+			{Row: req.Row1, Col: req.Col1},
+			{Row: req.Row2, Col: req.Col2},
+		}
 	}
 
-	if matched[req.Row1][req.Col1] || matched[req.Row2][req.Col2] {
-		jsonError(w, "tile already matched", http.StatusBadRequest)
+	if err := memory.ValidateSelection(board, matched, positions, game.MatchSize); err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	// Reveal tiles
-	tile1 := board[req.Row1][req.Col1]
-	tile2 := board[req.Row2][req.Col2]
-	isMatch := memory.CheckMatch(board, req.Row1, req.Col1, req.Row2, req.Col2)
+	reveals := make([]models.MemoryReveal, len(positions))
+	tiles := make([]int, len(positions))
+	for i, p := range positions {
+		tiles[i] = board[p.Row][p.Col]
+		reveals[i] = models.MemoryReveal{Row: p.Row, Col: p.Col, Tile: tiles[i]}
+	}
+	isMatch := memory.CheckMatch(board, positions)
 
 	// Record move
 	move := &models.MemoryMove{
 		GameID:  gameID,
 		UserID:  userCtx.UserID,
-		Row1:    req.Row1,
-		Col1:    req.Col1,
-		Row2:    req.Row2,
-		Col2:    req.Col2,
-		Tile1:   tile1,
-		Tile2:   tile2,
+		Row1:    positions[0].Row,
+		Col1:    positions[0].Col,
+		Row2:    positions[1].Row,
+		Col2:    positions[1].Col,
+		Tile1:   tiles[0],
+		Tile2:   tiles[1],
+		Reveals: reveals,
 		Matched: isMatch,
 	}
 	db.CreateMemoryMove(h.db, move)
 
 	if isMatch {
-		matched[req.Row1][req.Col1] = true
-		matched[req.Row2][req.Col2] = true
+		for _, p := range positions {
+			matched[p.Row][p.Col] = true
+		}
 
 		// Update score
 		if userCtx.UserID == game.Player1ID {
@@ -248,9 +242,9 @@ func (h *Handler) RevealTiles(w http.ResponseWriter, r *http.Request) {
 	game.Matched = matchedJSON
 
 	// Check if game is over
-	totalPairs := (rows * cols) / 2
-	matchedCount := memory.CountMatched(matched)
-	gameOver := matchedCount == totalPairs
+	totalGroups := memory.TotalGroups(board, game.MatchSize)
+	matchedCount := memory.CountMatched(matched, game.MatchSize)
+	gameOver := matchedCount == totalGroups
 
 	if gameOver {
 		game.Status = "completed"
@@ -273,8 +267,9 @@ func (h *Handler) RevealTiles(w http.ResponseWriter, r *http.Request) {
 	db.UpdateMemoryGame(h.db, game)
 
 	jsonResponse(w, models.RevealTilesResponse{
-		Tile1:     tile1,
-		Tile2:     tile2,
+		Tile1:     tiles[0],
+		Tile2:     tiles[1],
+		Tiles:     tiles,
 		Matched:   isMatch,
 		ExtraTurn: isMatch && !gameOver,
 		GameOver:  gameOver,
@@ -326,17 +321,369 @@ func (h *Handler) ResignMemoryGame(w http.ResponseWriter, r *http.Request) {
 	jsonResponse(w, map[string]string{"status": "resigned"}, http.StatusOK)
 }
 
+func (h *Handler) GetMemoryReplay(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetMemoryGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	// The replay shows the whole layout, so only once it no longer matters
+	if game.Status != "completed" {
+		jsonError(w, "replay is available once the game is over", http.StatusBadRequest)
+		return
+	}
+
+	board, err := memory.BoardFromJSON(game.Board)
+	if err != nil {
+		jsonError(w, "failed to parse board", http.StatusInternalServerError)
+		return
+	}
+
+	moves, err := db.GetMemoryMoves(h.db, gameID)
+	if err != nil {
+		jsonError(w, "failed to get moves", http.StatusInternalServerError)
+		return
+	}
+	// Moves come back newest first
+	slices.Reverse(moves)
+
+	steps, accuracy := memory.Replay(game, moves)
+
+	jsonResponse(w, models.MemoryReplayResponse{
+		Game:     game,
+		Board:    board,
+		Tiles:    memory.ThemeTiles(game.Theme, memory.TotalGroups(board, game.MatchSize)),
+		Steps:    steps,
+		Accuracy: accuracy,
+	}, http.StatusOK)
+}
+
+func (h *Handler) GetMemoryThemes(w http.ResponseWriter, r *http.Request) {
+	jsonResponse(w, models.MemoryThemesResponse{Themes: memory.Themes()}, http.StatusOK)
+}
+
+func (h *Handler) GetMemorySoloGames(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	games, err := db.GetMemorySoloGamesForUser(h.db, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get games", http.StatusInternalServerError)
+		return
+	}
+
+	response := models.MemorySoloGamesListResponse{
+		Active:    []models.MemorySoloGame{},
+		Completed: []models.MemorySoloGame{},
+	}
+	for _, game := range games {
+		if game.Status == "completed" {
+			response.Completed = append(response.Completed, game)
+		} else {
+			response.Active = append(response.Active, game)
+		}
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) CreateMemorySoloGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.CreateMemorySoloGameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Same board rules as multiplayer
+	boardSize := req.BoardSize
+	if boardSize == "" {
+		boardSize = memory.DefaultBoardSize
+	}
+	rows, cols, err := memory.ParseBoardSize(boardSize)
+	if err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	matchSize := memory.ValidateMatchSize(req.MatchSize)
+	theme := req.Theme
+	if theme == "" {
+		theme = memory.DefaultTheme
+	}
+	if err := memory.ValidateBoard(rows, cols, matchSize, theme); err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	board := memory.GenerateBoard(rows, cols, matchSize)
+	boardJSON, err := memory.BoardToJSON(board)
+	if err != nil {
+		jsonError(w, "failed to generate board", http.StatusInternalServerError)
+		return
+	}
+
+	matchedJSON, err := memory.MatchedToJSON(memory.InitMatched(rows, cols))
+	if err != nil {
+		jsonError(w, "failed to initialize game", http.StatusInternalServerError)
+		return
+	}
+
+	game, err := db.CreateMemorySoloGame(h.db, userCtx.UserID, fmt.Sprintf("%dx%d", rows, cols), matchSize, theme, boardJSON, matchedJSON)
+	if err != nil {
+		jsonError(w, "failed to create game", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, h.buildMemorySoloResponse(game), http.StatusCreated)
+}
+
+func (h *Handler) GetMemorySoloGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetMemorySoloGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.UserID != userCtx.UserID {
+		jsonError(w, "not your game", http.StatusForbidden)
+		return
+	}
+
+	jsonResponse(w, h.buildMemorySoloResponse(game), http.StatusOK)
+}
+
+func (h *Handler) RevealMemorySoloTiles(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.RevealTilesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Timestamp on arrival, before any database work
+	now := time.Now()
+
+	game, err := db.GetMemorySoloGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.UserID != userCtx.UserID {
+		jsonError(w, "not your game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status != "active" {
+		jsonError(w, "game is not active", http.StatusBadRequest)
+		return
+	}
+
+	board, err := memory.BoardFromJSON(game.Board)
+	if err != nil {
+		jsonError(w, "failed to parse board", http.StatusInternalServerError)
+		return
+	}
+
+	matched, err := memory.MatchedFromJSON(game.Matched)
+	if err != nil {
+		jsonError(w, "failed to parse matched state", http.StatusInternalServerError)
+		return
+	}
+
+	positions := req.Positions
+	if len(positions) == 0 {
+		positions = []models.MemoryPosition{
+			{Row: req.Row1, Col: req.Col1},
+			{Row: req.Row2, Col: req.Col2},
+		}
+	}
+
+	if err := memory.ValidateSelection(board, matched, positions, game.MatchSize); err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The clock starts with the first reveal
+	if game.StartedAt == nil {
+		game.StartedAt = &now
+	}
+	elapsed := now.Sub(*game.StartedAt).Milliseconds()
+
+	reveals := make([]models.MemoryReveal, len(positions))
+	tiles := make([]int, len(positions))
+	for i, p := range positions {
+		tiles[i] = board[p.Row][p.Col]
+		reveals[i] = models.MemoryReveal{Row: p.Row, Col: p.Col, Tile: tiles[i]}
+	}
+	isMatch := memory.CheckMatch(board, positions)
+
+	move := &models.MemorySoloMove{
+		GameID:    gameID,
+		Reveals:   reveals,
+		Matched:   isMatch,
+		ElapsedMs: elapsed,
+		CreatedAt: now,
+	}
+	if err := db.CreateMemorySoloMove(h.db, move); err != nil {
+		jsonError(w, "failed to save move", http.StatusInternalServerError)
+		return
+	}
+
+	if isMatch {
+		for _, p := range positions {
+			matched[p.Row][p.Col] = true
+		}
+	}
+	game.Matched, _ = memory.MatchedToJSON(matched)
+	game.Flips++
+
+	gameOver := memory.CountMatched(matched, game.MatchSize) == memory.TotalGroups(board, game.MatchSize)
+	if gameOver {
+		game.Status = "completed"
+		game.CompletedAt = &now
+		game.DurationMs = elapsed
+	}
+
+	if err := db.UpdateMemorySoloGame(h.db, game); err != nil {
+		jsonError(w, "failed to update game", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, models.MemorySoloRevealResponse{
+		Tiles:     tiles,
+		Matched:   isMatch,
+		GameOver:  gameOver,
+		Flips:     game.Flips,
+		ElapsedMs: elapsed,
+	}, http.StatusOK)
+}
+
+func (h *Handler) GetMemorySoloBests(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	bests, err := db.GetMemorySoloBests(h.db, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get personal bests", http.StatusInternalServerError)
+		return
+	}
+	if bests == nil {
+		bests = []models.MemorySoloBest{}
+	}
+
+	jsonResponse(w, models.MemorySoloBestsResponse{Bests: bests}, http.StatusOK)
+}
+
+func (h *Handler) GetMemorySoloLeaderboard(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	boardSize := r.URL.Query().Get("board_size")
+	if boardSize == "" {
+		boardSize = memory.DefaultBoardSize
+	}
+	rows, cols, err := memory.ParseBoardSize(boardSize)
+	if err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	boardSize = fmt.Sprintf("%dx%d", rows, cols)
+
+	matchSize, _ := strconv.Atoi(r.URL.Query().Get("match_size"))
+	matchSize = memory.ValidateMatchSize(matchSize)
+
+	entries, err := db.GetMemorySoloLeaderboard(h.db, userCtx.UserID, boardSize, matchSize)
+	if err != nil {
+		jsonError(w, "failed to get leaderboard", http.StatusInternalServerError)
+		return
+	}
+	if entries == nil {
+		entries = []models.MemorySoloLeaderboardEntry{}
+	}
+
+	jsonResponse(w, models.MemorySoloLeaderboardResponse{
+		BoardSize: boardSize,
+		MatchSize: matchSize,
+		Entries:   entries,
+	}, http.StatusOK)
+}
+
 // buildMemoryResponse constructs the response with visible board state.
 func (h *Handler) buildMemoryResponse(game *models.MemoryGame, userID int64) models.MemoryGameResponse {
 	board, _ := memory.BoardFromJSON(game.Board)
 	matched, _ := memory.MatchedFromJSON(game.Matched)
 
 	visibleBoard := memory.BuildVisibleBoard(board, matched)
-	matchedCount := memory.CountMatched(matched)
-
-	rows := len(board)
-	cols := len(board[0])
-	totalPairs := (rows * cols) / 2
+	matchedCount := memory.CountMatched(matched, game.MatchSize)
+	totalGroups := memory.TotalGroups(board, game.MatchSize)
 
 	moves, _ := db.GetMemoryMoves(h.db, game.ID)
 	if moves == nil {
@@ -350,8 +697,9 @@ func (h *Handler) buildMemoryResponse(game *models.MemoryGame, userID int64) mod
 		Board:        visibleBoard,
 		IsYourTurn:   isYourTurn,
 		Moves:        moves,
-		TotalPairs:   totalPairs,
+		TotalPairs:   totalGroups,
 		MatchedCount: matchedCount,
+		Tiles:        memory.ThemeTiles(game.Theme, totalGroups),
 	}
 
 	// Send full board to the current turn player so they can reveal tiles client-side
@@ -361,3 +709,25 @@ func (h *Handler) buildMemoryResponse(game *models.MemoryGame, userID int64) mod
 
 	return resp
 }
+
+// buildMemorySoloResponse never includes the hidden board, so a solo run can
+// only be completed through validated reveals
+func (h *Handler) buildMemorySoloResponse(game *models.MemorySoloGame) models.MemorySoloGameResponse {
+	board, _ := memory.BoardFromJSON(game.Board)
+	matched, _ := memory.MatchedFromJSON(game.Matched)
+	totalGroups := memory.TotalGroups(board, game.MatchSize)
+
+	moves, _ := db.GetMemorySoloMoves(h.db, game.ID)
+	if moves == nil {
+		moves = []models.MemorySoloMove{}
+	}
+
+	return models.MemorySoloGameResponse{
+		Game:         game,
+		Board:        memory.BuildVisibleBoard(board, matched),
+		Moves:        moves,
+		TotalPairs:   totalGroups,
+		MatchedCount: memory.CountMatched(matched, game.MatchSize),
+		Tiles:        memory.ThemeTiles(game.Theme, totalGroups),
+	}
+}
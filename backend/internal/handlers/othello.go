@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"altech/internal/db"
+	"altech/internal/middleware"
+	"altech/internal/models"
+	"altech/internal/othello"
+)
+
+func (h *Handler) GetOthelloGames(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	games, err := db.GetOthelloGamesForUser(h.db, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get games", http.StatusInternalServerError)
+		return
+	}
+
+	unread, _ := db.GetUnreadChatCounts(h.db, "othello", userCtx.UserID)
+	for i := range games {
+		games[i].UnreadMessages = unread[games[i].ID]
+	}
+
+	response := models.OthelloGamesListResponse{
+		YourTurn:  []models.OthelloGame{},
+		TheirTurn: []models.OthelloGame{},
+		Completed: []models.OthelloGame{},
+	}
+
+	for _, game := range games {
+		if game.Status == "completed" {
+			response.Completed = append(response.Completed, game)
+		} else if game.CurrentTurn == userCtx.UserID {
+			response.YourTurn = append(response.YourTurn, game)
+		} else {
+			response.TheirTurn = append(response.TheirTurn, game)
+		}
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// createOthelloGame starts a game from the four-disc opening position
+func (h *Handler) createOthelloGame(creatorID, opponentID int64, settings []byte) (int64, error) {
+	boardJSON, err := othello.BoardToJSON(othello.NewBoard())
+	if err != nil {
+		return 0, errCreateGame
+	}
+
+	// The creator plays Black and moves first
+	game, err := db.CreateOthelloGame(h.db, creatorID, opponentID, boardJSON)
+	if err != nil {
+		return 0, errCreateGame
+	}
+
+	return game.ID, nil
+}
+
+func (h *Handler) GetOthelloGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetOthelloGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	response := h.buildOthelloResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) MakeOthelloMove(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.OthelloMoveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetOthelloGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status != "active" {
+		jsonError(w, "game is not active", http.StatusBadRequest)
+		return
+	}
+
+	if game.CurrentTurn != userCtx.UserID {
+		jsonError(w, "not your turn", http.StatusBadRequest)
+		return
+	}
+
+	board, err := othello.BoardFromJSON(game.Board)
+	if err != nil {
+		jsonError(w, "failed to parse board", http.StatusInternalServerError)
+		return
+	}
+
+	piece, opponentID := othello.Black, game.Player2ID
+	if userCtx.UserID == game.Player2ID {
+		piece, opponentID = othello.White, game.Player1ID
+	}
+
+	flipped, err := othello.Play(board, req.Row, req.Col, piece)
+	if err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	moves := []*models.OthelloMove{{
+		GameID:  gameID,
+		UserID:  userCtx.UserID,
+		Row:     req.Row,
+		Col:     req.Col,
+		Flipped: len(flipped),
+	}}
+
+	game.Board, _ = othello.BoardToJSON(board)
+	game.Player1Score, game.Player2Score = othello.Count(board)
+
+	switch {
+	case len(othello.LegalMoves(board, othello.Opponent(piece))) > 0:
+		game.CurrentTurn = opponentID
+	case len(othello.LegalMoves(board, piece)) > 0:
+		// The opponent has no move, so their turn passes automatically
+		moves = append(moves, &models.OthelloMove{
+			GameID: gameID,
+			UserID: opponentID,
+			Passed: true,
+			Row:    -1,
+			Col:    -1,
+		})
+	default:
+		// Neither side can move
+		game.Status = "completed"
+		if game.Player1Score > game.Player2Score {
+			game.WinnerID = &game.Player1ID
+		} else if game.Player2Score > game.Player1Score {
+			game.WinnerID = &game.Player2ID
+		}
+		// nil WinnerID = draw
+	}
+
+	err = db.PlayOthelloMove(h.db, game, moves...)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to save move", http.StatusInternalServerError)
+		return
+	}
+
+	response := h.buildOthelloResponse(game, userCtx.UserID)
+	response.Flipped = flipped
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) ResignOthelloGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetOthelloGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status == "completed" {
+		jsonError(w, "game is already completed", http.StatusBadRequest)
+		return
+	}
+
+	game.Status = "completed"
+	if userCtx.UserID == game.Player1ID {
+		game.WinnerID = &game.Player2ID
+	} else {
+		game.WinnerID = &game.Player1ID
+	}
+
+	err = db.UpdateOthelloGame(h.db, game)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to update game", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, map[string]string{"status": "resigned"}, http.StatusOK)
+}
+
+// buildOthelloResponse constructs the response with the board and the legal
+// moves of the player to move
+func (h *Handler) buildOthelloResponse(game *models.OthelloGame, userID int64) models.OthelloGameResponse {
+	board, _ := othello.BoardFromJSON(game.Board)
+
+	piece := othello.Black
+	if userID == game.Player2ID {
+		piece = othello.White
+	}
+
+	moves, _ := db.GetOthelloMoves(h.db, game.ID)
+	if moves == nil {
+		moves = []models.OthelloMove{}
+	}
+
+	resp := models.OthelloGameResponse{
+		Game:       game,
+		Board:      board,
+		YourPiece:  piece,
+		IsYourTurn: game.Status == "active" && game.CurrentTurn == userID,
+		LegalMoves: []models.OthelloSquare{},
+		Moves:      moves,
+	}
+
+	if game.Status == "active" {
+		toMove := othello.Black
+		if game.CurrentTurn == game.Player2ID {
+			toMove = othello.White
+		}
+		resp.LegalMoves = othello.LegalMoves(board, toMove)
+	}
+
+	return resp
+}
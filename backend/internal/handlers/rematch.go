@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"altech/internal/battleship"
+	"altech/internal/chess"
+	"altech/internal/db"
+	"altech/internal/middleware"
+	"altech/internal/models"
+)
+
+// gameLoader loads a game's summary and the create request that would set
+// up another game with the same settings. The opponent is filled in later.
+type gameLoader func(h *Handler, gameID int64) (*models.GameSummary, any, error)
+
+// gameLoaders holds a loader for every two-player game type. Rematches use
+// the settings; series and chat only need the players, and tournaments the
+// winner and scores.
+var gameLoaders = map[string]gameLoader{
+	"scrabble": func(h *Handler, gameID int64) (*models.GameSummary, any, error) {
+		g, err := db.GetScrabbleGame(h.db, gameID)
+		if err != nil {
+			return nil, nil, err
+		}
+		summary := &models.GameSummary{ID: g.ID, Player1ID: g.Player1ID, Player2ID: g.Player2ID, Status: g.Status, WinnerID: g.WinnerID}
+		summary.Scores = []float64{float64(g.Player1Score), float64(g.Player2Score)}
+		return summary, models.CreateScrabbleGameRequest{}, nil
+	},
+	"battleship": func(h *Handler, gameID int64) (*models.GameSummary, any, error) {
+		g, err := db.GetBattleshipGame(h.db, gameID)
+		if err != nil {
+			return nil, nil, err
+		}
+		fleet, _ := battleship.FleetFromJSON(g.Fleet)
+		return &models.GameSummary{ID: g.ID, Player1ID: g.Player1ID, Player2ID: g.Player2ID, Status: g.Status, WinnerID: g.WinnerID},
+			models.CreateBattleshipGameRequest{BoardSize: g.BoardSize, Fleet: fleet, Salvo: g.Salvo, NoTouch: g.NoTouch}, nil
+	},
+	"mastermind": func(h *Handler, gameID int64) (*models.GameSummary, any, error) {
+		g, err := db.GetMastermindGame(h.db, gameID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &models.GameSummary{ID: g.ID, Player1ID: g.Player1ID, Player2ID: g.Player2ID, Status: g.Status, WinnerID: g.WinnerID},
+			models.CreateMastermindGameRequest{
+				NumColors:    g.NumColors,
+				CodeLength:   g.CodeLength,
+				MaxGuesses:   g.MaxGuesses,
+				AllowRepeats: g.AllowRepeats,
+				Assistant:    g.Assistant,
+			}, nil
+	},
+	"wordmind": func(h *Handler, gameID int64) (*models.GameSummary, any, error) {
+		g, err := db.GetWordmindGame(h.db, gameID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &models.GameSummary{ID: g.ID, Player1ID: g.Player1ID, Player2ID: g.Player2ID, Status: g.Status, WinnerID: g.WinnerID},
+			models.CreateWordmindGameRequest{MaxGuesses: g.MaxGuesses}, nil
+	},
+	"memory": func(h *Handler, gameID int64) (*models.GameSummary, any, error) {
+		g, err := db.GetMemoryGame(h.db, gameID)
+		if err != nil {
+			return nil, nil, err
+		}
+		summary := &models.GameSummary{ID: g.ID, Player1ID: g.Player1ID, Player2ID: g.Player2ID, Status: g.Status, WinnerID: g.WinnerID}
+		summary.Scores = []float64{float64(g.Player1Score), float64(g.Player2Score)}
+		return summary, models.CreateMemoryGameRequest{BoardSize: g.BoardSize, MatchSize: g.MatchSize, Theme: g.Theme}, nil
+	},
+	"connectfour": func(h *Handler, gameID int64) (*models.GameSummary, any, error) {
+		g, err := db.GetConnectFourGame(h.db, gameID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &models.GameSummary{ID: g.ID, Player1ID: g.Player1ID, Player2ID: g.Player2ID, Status: g.Status, WinnerID: g.WinnerID},
+			models.CreateConnectFourGameRequest{BoardSize: g.BoardSize, PopOut: g.PopOut}, nil
+	},
+	"checkers": func(h *Handler, gameID int64) (*models.GameSummary, any, error) {
+		g, err := db.GetCheckersGame(h.db, gameID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &models.GameSummary{ID: g.ID, Player1ID: g.Player1ID, Player2ID: g.Player2ID, Status: g.Status, WinnerID: g.WinnerID},
+			models.CreateCheckersGameRequest{}, nil
+	},
+	"chess": func(h *Handler, gameID int64) (*models.GameSummary, any, error) {
+		g, err := db.GetChessGame(h.db, gameID)
+		if err != nil {
+			return nil, nil, err
+		}
+		// A game set up from a FEN or PGN is replayed from the same position
+		req := models.CreateChessGameRequest{}
+		if g.StartFEN != chess.StartFEN {
+			req.FEN = g.StartFEN
+		}
+		return &models.GameSummary{ID: g.ID, Player1ID: g.Player1ID, Player2ID: g.Player2ID, Status: g.Status, WinnerID: g.WinnerID},
+			req, nil
+	},
+	"boggle": func(h *Handler, gameID int64) (*models.GameSummary, any, error) {
+		g, err := db.GetBoggleGame(h.db, gameID)
+		if err != nil {
+			return nil, nil, err
+		}
+		summary := &models.GameSummary{ID: g.ID, Player1ID: g.Player1ID, Player2ID: g.Player2ID, Status: g.Status, WinnerID: g.WinnerID}
+		summary.Scores = []float64{float64(g.Player1Score), float64(g.Player2Score)}
+		return summary, models.CreateBoggleGameRequest{Size: g.Size}, nil
+	},
+	"dotsandboxes": func(h *Handler, gameID int64) (*models.GameSummary, any, error) {
+		g, err := db.GetDotsAndBoxesGame(h.db, gameID)
+		if err != nil {
+			return nil, nil, err
+		}
+		summary := &models.GameSummary{ID: g.ID, Player1ID: g.Player1ID, Player2ID: g.Player2ID, Status: g.Status, WinnerID: g.WinnerID}
+		summary.Scores = []float64{float64(g.Player1Score), float64(g.Player2Score)}
+		return summary, models.CreateDotsAndBoxesGameRequest{BoardSize: g.BoardSize}, nil
+	},
+	"othello": func(h *Handler, gameID int64) (*models.GameSummary, any, error) {
+		g, err := db.GetOthelloGame(h.db, gameID)
+		if err != nil {
+			return nil, nil, err
+		}
+		summary := &models.GameSummary{ID: g.ID, Player1ID: g.Player1ID, Player2ID: g.Player2ID, Status: g.Status, WinnerID: g.WinnerID}
+		summary.Scores = []float64{float64(g.Player1Score), float64(g.Player2Score)}
+		return summary, models.CreateOthelloGameRequest{}, nil
+	},
+	"go": func(h *Handler, gameID int64) (*models.GameSummary, any, error) {
+		g, err := db.GetGoGame(h.db, gameID)
+		if err != nil {
+			return nil, nil, err
+		}
+		summary := &models.GameSummary{ID: g.ID, Player1ID: g.Player1ID, Player2ID: g.Player2ID, Status: g.Status, WinnerID: g.WinnerID}
+		summary.Scores = []float64{g.Player1Score, g.Player2Score}
+		return summary, models.CreateGoGameRequest{Size: g.Size, Rules: g.Rules, Komi: &g.Komi}, nil
+	},
+	"yahtzee": func(h *Handler, gameID int64) (*models.GameSummary, any, error) {
+		g, err := db.GetYahtzeeGame(h.db, gameID)
+		if err != nil {
+			return nil, nil, err
+		}
+		summary := &models.GameSummary{ID: g.ID, Player1ID: g.Player1ID, Player2ID: g.Player2ID, Status: g.Status, WinnerID: g.WinnerID}
+		summary.Scores = []float64{float64(g.Player1Score), float64(g.Player2Score)}
+		return summary, models.CreateYahtzeeGameRequest{}, nil
+	},
+}
+
+// gameFinished reports whether a game is over. Scrabble marks resigned games
+// as resigned rather than completed.
+func gameFinished(status string) bool {
+	return status == "completed" || status == "resigned"
+}
+
+// Rematch returns a handler that starts a new game from a finished one with
+// the same settings and the other player moving first. Asking again, or
+// asking as the other player, returns the rematch already made.
+func (h *Handler) Rematch(gameType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userCtx := middleware.GetUser(r)
+		if userCtx == nil {
+			jsonError(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		gameID := extractGameID(r)
+		if gameID == 0 {
+			jsonError(w, "invalid game ID", http.StatusBadRequest)
+			return
+		}
+
+		game, settings, err := gameLoaders[gameType](h, gameID)
+		if err == db.ErrGameNotFound {
+			jsonError(w, "game not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			jsonError(w, "failed to get game", http.StatusInternalServerError)
+			return
+		}
+
+		if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+			jsonError(w, "not a player in this game", http.StatusForbidden)
+			return
+		}
+
+		if !gameFinished(game.Status) {
+			jsonError(w, "game is not completed", http.StatusBadRequest)
+			return
+		}
+
+		status := http.StatusCreated
+		rematchID := int64(0)
+		err = db.ClaimRematch(h.db, gameType, game.ID)
+		switch {
+		case err == db.ErrRematchExists:
+			rematchID, err = db.GetRematchOf(h.db, gameType, game.ID)
+			if err != nil {
+				jsonError(w, "failed to get rematch", http.StatusInternalServerError)
+				return
+			}
+			if rematchID == 0 {
+				jsonError(w, "rematch is already being created", http.StatusConflict)
+				return
+			}
+			status = http.StatusOK
+		case err != nil:
+			jsonError(w, "failed to create rematch", http.StatusInternalServerError)
+			return
+		default:
+			settingsJSON, _ := json.Marshal(settings)
+			newID, err := h.createGame(gameType, game.Player2ID, game.Player1ID, settingsJSON)
+			if err != nil {
+				db.ReleaseRematch(h.db, gameType, game.ID)
+				createGameError(w, err)
+				return
+			}
+			// An unlinked claim would block every later request, so give it up
+			// and let the next one try again
+			if err := db.SetRematchGame(h.db, gameType, game.ID, newID); err != nil {
+				db.ReleaseRematch(h.db, gameType, game.ID)
+				jsonError(w, "failed to link rematch", http.StatusInternalServerError)
+				return
+			}
+			rematchID = newID
+		}
+
+		series, err := h.buildGameSeries(gameType, rematchID)
+		if err != nil {
+			jsonError(w, "failed to get series", http.StatusInternalServerError)
+			return
+		}
+
+		jsonResponse(w, models.RematchResponse{
+			GameType:       gameType,
+			GameID:         rematchID,
+			PreviousGameID: game.ID,
+			Series:         series,
+		}, status)
+	}
+}
+
+// GameSeries returns a handler listing the chain of rematches a game belongs
+// to with each player's wins
+func (h *Handler) GameSeries(gameType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userCtx := middleware.GetUser(r)
+		if userCtx == nil {
+			jsonError(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		gameID := extractGameID(r)
+		if gameID == 0 {
+			jsonError(w, "invalid game ID", http.StatusBadRequest)
+			return
+		}
+
+		game, _, err := gameLoaders[gameType](h, gameID)
+		if err == db.ErrGameNotFound {
+			jsonError(w, "game not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			jsonError(w, "failed to get game", http.StatusInternalServerError)
+			return
+		}
+
+		if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+			jsonError(w, "not a player in this game", http.StatusForbidden)
+			return
+		}
+
+		series, err := h.buildGameSeries(gameType, game.ID)
+		if err != nil {
+			jsonError(w, "failed to get series", http.StatusInternalServerError)
+			return
+		}
+
+		jsonResponse(w, series, http.StatusOK)
+	}
+}
+
+// buildGameSeries walks back to the first game of a series, then forward
+// through each rematch, counting wins for completed games
+func (h *Handler) buildGameSeries(gameType string, gameID int64) (*models.GameSeries, error) {
+	firstID := gameID
+	for {
+		previousID, err := db.GetPreviousGame(h.db, gameType, firstID)
+		if err != nil {
+			return nil, err
+		}
+		if previousID == 0 {
+			break
+		}
+		firstID = previousID
+	}
+
+	series := &models.GameSeries{GameType: gameType, Games: []models.GameSummary{}}
+	wins := map[int64]int{}
+	for id := firstID; id != 0; {
+		game, _, err := gameLoaders[gameType](h, id)
+		if err != nil {
+			return nil, err
+		}
+		series.Games = append(series.Games, *game)
+
+		if gameFinished(game.Status) {
+			if game.WinnerID != nil {
+				wins[*game.WinnerID]++
+			} else {
+				series.Draws++
+			}
+		}
+
+		if id, err = db.GetRematchOf(h.db, gameType, id); err != nil {
+			return nil, err
+		}
+	}
+
+	first := series.Games[0]
+	series.Scores = []models.SeriesScore{
+		{UserID: first.Player1ID, Wins: wins[first.Player1ID]},
+		{UserID: first.Player2ID, Wins: wins[first.Player2ID]},
+	}
+	return series, nil
+}
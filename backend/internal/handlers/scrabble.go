@@ -25,6 +25,11 @@ func (h *Handler) GetScrabbleGames(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	unread, _ := db.GetUnreadChatCounts(h.db, "scrabble", userCtx.UserID)
+	for i := range games {
+		games[i].UnreadMessages = unread[games[i].ID]
+	}
+
 	// Categorize games
 	response := models.ScrabbleGamesListResponse{
 		YourTurn:  []models.ScrabbleGame{},
@@ -45,31 +50,8 @@ func (h *Handler) GetScrabbleGames(w http.ResponseWriter, r *http.Request) {
 	jsonResponse(w, response, http.StatusOK)
 }
 
-func (h *Handler) CreateScrabbleGame(w http.ResponseWriter, r *http.Request) {
-	userCtx := middleware.GetUser(r)
-	if userCtx == nil {
-		jsonError(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-
-	var req models.CreateScrabbleGameRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		jsonError(w, "invalid request body", http.StatusBadRequest)
-		return
-	}
-
-	if req.OpponentID == userCtx.UserID {
-		jsonError(w, "cannot play against yourself", http.StatusBadRequest)
-		return
-	}
-
-	// Verify friendship
-	isFriend, err := db.CheckFriendship(h.db, userCtx.UserID, req.OpponentID)
-	if err != nil || !isFriend {
-		jsonError(w, "can only play with friends", http.StatusForbidden)
-		return
-	}
-
+// createScrabbleGame starts a game and deals both players their racks
+func (h *Handler) createScrabbleGame(creatorID, opponentID int64, settings []byte) (int64, error) {
 	// Initialize game
 	tileBag := scrabble.CreateTileBag()
 	board := scrabble.CreateEmptyBoard()
@@ -82,28 +64,19 @@ func (h *Handler) CreateScrabbleGame(w http.ResponseWriter, r *http.Request) {
 	boardJSON, _ := scrabble.BoardToJSON(board)
 
 	// Create game
-	game, err := db.CreateScrabbleGame(h.db, userCtx.UserID, req.OpponentID, tileBagJSON, boardJSON)
+	game, err := db.CreateScrabbleGame(h.db, creatorID, opponentID, tileBagJSON, boardJSON)
 	if err != nil {
-		jsonError(w, "failed to create game", http.StatusInternalServerError)
-		return
+		return 0, errCreateGame
 	}
 
 	// Create racks
 	player1RackJSON, _ := scrabble.RackToJSON(player1Tiles)
 	player2RackJSON, _ := scrabble.RackToJSON(player2Tiles)
 
-	db.CreateScrabbleRack(h.db, game.ID, userCtx.UserID, player1RackJSON)
-	db.CreateScrabbleRack(h.db, game.ID, req.OpponentID, player2RackJSON)
-
-	// Parse board for response
-	game.Board, _ = scrabble.BoardFromJSON(boardJSON)
+	db.CreateScrabbleRack(h.db, game.ID, creatorID, player1RackJSON)
+	db.CreateScrabbleRack(h.db, game.ID, opponentID, player2RackJSON)
 
-	jsonResponse(w, models.ScrabbleGameResponse{
-		Game:           game,
-		Rack:           player1Tiles,
-		IsYourTurn:     true,
-		TilesRemaining: len(remaining),
-	}, http.StatusCreated)
+	return game.ID, nil
 }
 
 func (h *Handler) GetScrabbleGame(w http.ResponseWriter, r *http.Request) {
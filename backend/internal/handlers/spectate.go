@@ -0,0 +1,343 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"altech/internal/battleship"
+	"altech/internal/db"
+	"altech/internal/memory"
+	"altech/internal/middleware"
+	"altech/internal/models"
+	"altech/internal/scrabble"
+)
+
+// spectatorView builds what a spectator sees of a game, from player 1's side.
+// Games without hidden information reuse the player response built for no
+// user, so there is no turn and nothing to play; the rest filter private
+// state until the game ends.
+type spectatorView func(h *Handler, gameID int64) (any, error)
+
+var spectatorViews = map[string]spectatorView{
+	"scrabble": func(h *Handler, gameID int64) (any, error) {
+		game, err := db.GetScrabbleGame(h.db, gameID)
+		if err != nil {
+			return nil, err
+		}
+		game.Board, _ = scrabble.BoardFromJSON(game.BoardState)
+		tileBag, _ := scrabble.TileBagFromJSON(game.TileBag)
+		lastMove, _ := db.GetLastScrabbleMove(h.db, gameID)
+
+		// Racks are never shown to spectators
+		return models.ScrabbleGameResponse{
+			Game:           game,
+			Rack:           []models.Tile{},
+			TilesRemaining: len(tileBag),
+			LastMove:       lastMove,
+		}, nil
+	},
+	"battleship": func(h *Handler, gameID int64) (any, error) {
+		game, err := db.GetBattleshipGame(h.db, gameID)
+		if err != nil {
+			return nil, err
+		}
+		return h.buildBattleshipSpectatorView(game), nil
+	},
+	"mastermind": func(h *Handler, gameID int64) (any, error) {
+		game, err := db.GetMastermindGame(h.db, gameID)
+		if err != nil {
+			return nil, err
+		}
+		resp := h.buildMastermindResponse(game, game.Player1ID)
+		resp.IsYourTurn = false
+		if game.Status != "completed" {
+			resp.MySecret = nil
+		}
+		return resp, nil
+	},
+	"wordmind": func(h *Handler, gameID int64) (any, error) {
+		game, err := db.GetWordmindGame(h.db, gameID)
+		if err != nil {
+			return nil, err
+		}
+		resp := h.buildWordmindResponse(game, game.Player1ID)
+		resp.IsYourTurn = false
+		if game.Status != "completed" {
+			resp.MySecret = ""
+		}
+		return resp, nil
+	},
+	"memory": func(h *Handler, gameID int64) (any, error) {
+		game, err := db.GetMemoryGame(h.db, gameID)
+		if err != nil {
+			return nil, err
+		}
+		resp := h.buildMemoryResponse(game, 0)
+		if game.Status == "completed" {
+			resp.FullBoard, _ = memory.BoardFromJSON(game.Board)
+		}
+		return resp, nil
+	},
+	"connectfour": func(h *Handler, gameID int64) (any, error) {
+		game, err := db.GetConnectFourGame(h.db, gameID)
+		if err != nil {
+			return nil, err
+		}
+		return h.buildConnectFourResponse(game, 0), nil
+	},
+	"checkers": func(h *Handler, gameID int64) (any, error) {
+		game, err := db.GetCheckersGame(h.db, gameID)
+		if err != nil {
+			return nil, err
+		}
+		return h.buildCheckersResponse(game, 0), nil
+	},
+	"chess": func(h *Handler, gameID int64) (any, error) {
+		game, err := db.GetChessGame(h.db, gameID)
+		if err != nil {
+			return nil, err
+		}
+		return h.buildChessResponse(game, 0), nil
+	},
+	"boggle": func(h *Handler, gameID int64) (any, error) {
+		game, err := db.GetBoggleGame(h.db, gameID)
+		if err != nil {
+			return nil, err
+		}
+		h.settleBoggleGame(game, time.Now())
+
+		// The grid and words could be passed on to a player who has not
+		// started yet, so spectators only see them once the game is over
+		resp := h.buildBoggleResponse(game, game.Player1ID)
+		if game.Status != "completed" {
+			resp.Grid = nil
+			resp.YourWords = []models.BoggleWord{}
+			resp.YourScore = 0
+		}
+		return resp, nil
+	},
+	"dotsandboxes": func(h *Handler, gameID int64) (any, error) {
+		game, err := db.GetDotsAndBoxesGame(h.db, gameID)
+		if err != nil {
+			return nil, err
+		}
+		return h.buildDotsAndBoxesResponse(game, 0), nil
+	},
+	"othello": func(h *Handler, gameID int64) (any, error) {
+		game, err := db.GetOthelloGame(h.db, gameID)
+		if err != nil {
+			return nil, err
+		}
+		return h.buildOthelloResponse(game, 0), nil
+	},
+	"go": func(h *Handler, gameID int64) (any, error) {
+		game, err := db.GetGoGame(h.db, gameID)
+		if err != nil {
+			return nil, err
+		}
+		return h.buildGoResponse(game, 0), nil
+	},
+	"yahtzee": func(h *Handler, gameID int64) (any, error) {
+		game, err := db.GetYahtzeeGame(h.db, gameID)
+		if err != nil {
+			return nil, err
+		}
+		return buildYahtzeeResponse(game, 0), nil
+	},
+}
+
+// buildBattleshipSpectatorView shows both boards the way each player's
+// opponent sees them: shots, hits and sunk ships. Both fleets are revealed
+// once the game is over.
+func (h *Handler) buildBattleshipSpectatorView(game *models.BattleshipGame) models.BattleshipGameResponse {
+	rules := battleship.RulesForGame(game)
+	gameOver := game.Status == "completed"
+
+	shotsByPlayer1, _ := db.GetBattleshipShots(h.db, game.ID, game.Player1ID)
+	shotsByPlayer2, _ := db.GetBattleshipShots(h.db, game.ID, game.Player2ID)
+
+	var player1Ships, player2Ships []models.Ship
+	shipsReady := false
+	if board, err := db.GetBattleshipBoard(h.db, game.ID, game.Player1ID); err == nil {
+		player1Ships, _ = battleship.ShipsFromJSON(board.Ships)
+		player1Ships = battleship.ApplyDamage(player1Ships, shotsByPlayer2)
+		shipsReady = board.ShipsReady
+	}
+	if board, err := db.GetBattleshipBoard(h.db, game.ID, game.Player2ID); err == nil {
+		player2Ships, _ = battleship.ShipsFromJSON(board.Ships)
+		player2Ships = battleship.ApplyDamage(player2Ships, shotsByPlayer1)
+	}
+
+	player2ShipsRemaining := 0
+	for _, ship := range player2Ships {
+		if ship.Hits < ship.Size {
+			player2ShipsRemaining++
+		}
+	}
+	if len(player2Ships) == 0 {
+		player2ShipsRemaining = rules.ShipCount()
+	}
+
+	response := models.BattleshipGameResponse{
+		Game:                game,
+		MyBoard:             battleship.BuildEnemyBoard(player1Ships, shotsByPlayer2, rules.BoardSize, gameOver),
+		EnemyBoard:          battleship.BuildEnemyBoard(player2Ships, shotsByPlayer1, rules.BoardSize, gameOver),
+		MyShips:             battleship.SunkShips(player1Ships, shotsByPlayer2),
+		ShipsReady:          shipsReady,
+		Phase:               game.Status,
+		EnemyShipsRemaining: player2ShipsRemaining,
+		Fleet:               rules.Fleet,
+		MyFleet:             battleship.BuildFleetStatus(player1Ships, shotsByPlayer2, rules),
+		EnemyFleet:          battleship.BuildFleetStatus(player2Ships, shotsByPlayer1, rules),
+		SunkEnemyShips:      battleship.SunkShips(player2Ships, shotsByPlayer1),
+	}
+	if gameOver {
+		response.MyShips = player1Ships
+		response.EnemyShips = player2Ships
+	}
+
+	return response
+}
+
+// GetGameWatchable reports whether the player and their opponent have opened
+// a game to their friends
+func (h *Handler) GetGameWatchable(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameType, game := h.playerGame(w, r, userCtx.UserID)
+	if game == nil {
+		return
+	}
+
+	response, err := h.watchableResponse(gameType, game, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get watchable", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// SetGameWatchable lets a player open a game to their own friends, or close
+// it to them again. The opponent's friends can only watch if the opponent
+// opens it too.
+func (h *Handler) SetGameWatchable(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameType, game := h.playerGame(w, r, userCtx.UserID)
+	if game == nil {
+		return
+	}
+
+	var req models.SetWatchableRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := db.SetGameWatchable(h.db, gameType, game.ID, game.Player1ID, game.Player2ID, userCtx.UserID, req.Watchable); err != nil {
+		jsonError(w, "failed to update watchable", http.StatusInternalServerError)
+		return
+	}
+
+	response, err := h.watchableResponse(gameType, game, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get watchable", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// watchableResponse reports from a player's side who a game is open to
+func (h *Handler) watchableResponse(gameType string, game *models.GameSummary, userID int64) (models.WatchableResponse, error) {
+	player1, player2, err := db.GetGameWatchable(h.db, gameType, game.ID)
+	if userID == game.Player2ID {
+		player1, player2 = player2, player1
+	}
+	return models.WatchableResponse{Watchable: player1, OpponentWatchable: player2}, err
+}
+
+// GetWatchableGames lists the games friends have opened to spectators.
+// Finished games are left out.
+func (h *Handler) GetWatchableGames(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	games, err := db.GetWatchableGamesForUser(h.db, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get games", http.StatusInternalServerError)
+		return
+	}
+
+	response := models.WatchableGamesResponse{Games: []models.WatchableGame{}}
+	for _, g := range games {
+		load, ok := gameLoaders[g.GameType]
+		if !ok {
+			continue
+		}
+		summary, _, err := load(h, g.GameID)
+		if err != nil || gameFinished(summary.Status) {
+			continue
+		}
+		g.Status = summary.Status
+		response.Games = append(response.Games, g)
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// SpectateGame returns a read-only view of a game to a friend of a player
+// who opened it
+func (h *Handler) SpectateGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameType, game := h.pathGame(w, r)
+	if game == nil {
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		open1, open2, err := db.GetGameWatchable(h.db, gameType, game.ID)
+		if err != nil {
+			jsonError(w, "failed to get game", http.StatusInternalServerError)
+			return
+		}
+		if !open1 && !open2 {
+			jsonError(w, "game is not open to spectators", http.StatusForbidden)
+			return
+		}
+
+		// Each player only opens the game to their own friends
+		friend1, _ := db.CheckFriendship(h.db, userCtx.UserID, game.Player1ID)
+		friend2, _ := db.CheckFriendship(h.db, userCtx.UserID, game.Player2ID)
+		if !(open1 && friend1) && !(open2 && friend2) {
+			jsonError(w, "can only watch games friends have opened to you", http.StatusForbidden)
+			return
+		}
+	}
+
+	view, err := spectatorViews[gameType](h, game.ID)
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, models.SpectatorViewResponse{GameType: gameType, View: view}, http.StatusOK)
+}
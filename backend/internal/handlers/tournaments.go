@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"altech/internal/db"
+	"altech/internal/middleware"
+	"altech/internal/models"
+	"altech/internal/tournament"
+)
+
+// CreateTournament sets up a tournament among the organiser's friends and
+// starts the games of its first round
+func (h *Handler) CreateTournament(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.CreateTournamentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		jsonError(w, "name is required", http.StatusBadRequest)
+		return
+	}
+	if len(name) > tournament.MaxNameLength {
+		jsonError(w, "name must be at most 100 characters", http.StatusBadRequest)
+		return
+	}
+
+	game, ok := playableGames[req.GameType]
+	if !ok {
+		jsonError(w, "unknown game type", http.StatusBadRequest)
+		return
+	}
+
+	if err := tournament.ValidateFormat(req.Format); err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := tournament.ValidateParticipants(req.ParticipantIDs); err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The organiser may play; everyone else must be their friend
+	for _, id := range req.ParticipantIDs {
+		if id == userCtx.UserID {
+			continue
+		}
+		isFriend, err := db.CheckFriendship(h.db, userCtx.UserID, id)
+		if err != nil || !isFriend {
+			jsonError(w, "can only invite friends", http.StatusForbidden)
+			return
+		}
+	}
+
+	// Games can only be created between friends, so any two participants
+	// must be able to meet
+	for i, a := range req.ParticipantIDs {
+		for _, b := range req.ParticipantIDs[i+1:] {
+			isFriend, err := db.CheckFriendship(h.db, a, b)
+			if err != nil || !isFriend {
+				jsonError(w, "participants must all be friends with each other", http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
+	settings, err := game.settings(req.Settings)
+	if err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	t, err := db.CreateTournament(h.db, &models.Tournament{
+		OrganizerID: userCtx.UserID,
+		Name:        name,
+		GameType:    req.GameType,
+		Format:      req.Format,
+		Settings:    settings,
+		TotalRounds: tournament.Rounds(req.Format, len(req.ParticipantIDs)),
+	}, req.ParticipantIDs, tournament.Matches(req.Format, req.ParticipantIDs))
+	if err != nil {
+		jsonError(w, "failed to create tournament", http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := h.advanceTournament(t); err != nil {
+		jsonError(w, "failed to start tournament", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, t, http.StatusCreated)
+}
+
+func (h *Handler) GetTournaments(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	tournaments, err := db.GetTournamentsForUser(h.db, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get tournaments", http.StatusInternalServerError)
+		return
+	}
+
+	for i := range tournaments {
+		h.advanceTournament(&tournaments[i])
+	}
+
+	if tournaments == nil {
+		tournaments = []models.Tournament{}
+	}
+
+	jsonResponse(w, models.TournamentsResponse{Tournaments: tournaments}, http.StatusOK)
+}
+
+func (h *Handler) GetTournament(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	t, _ := h.loadTournament(w, r, userCtx.UserID)
+	if t == nil {
+		return
+	}
+
+	jsonResponse(w, t, http.StatusOK)
+}
+
+// GetTournamentStandings ranks the participants on results so far
+func (h *Handler) GetTournamentStandings(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	t, matches := h.loadTournament(w, r, userCtx.UserID)
+	if t == nil {
+		return
+	}
+
+	jsonResponse(w, models.TournamentStandingsResponse{
+		Tournament: t,
+		Standings:  tournament.Standings(t.Format, t.Participants, matches),
+	}, http.StatusOK)
+}
+
+// GetTournamentBracket returns every match grouped by round. Elimination
+// matches are in bracket order, so the winners of positions 1 and 2 meet at
+// position 1 of the next round.
+func (h *Handler) GetTournamentBracket(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	t, matches := h.loadTournament(w, r, userCtx.UserID)
+	if t == nil {
+		return
+	}
+
+	rounds := make([]models.TournamentRound, t.TotalRounds)
+	for i := range rounds {
+		rounds[i] = models.TournamentRound{
+			Round:   i + 1,
+			Name:    tournament.RoundName(t.Format, i+1, t.TotalRounds),
+			Matches: []models.TournamentMatch{},
+		}
+	}
+	for _, m := range matches {
+		if m.Round >= 1 && m.Round <= len(rounds) {
+			rounds[m.Round-1].Matches = append(rounds[m.Round-1].Matches, m)
+		}
+	}
+
+	jsonResponse(w, models.TournamentBracketResponse{Tournament: t, Rounds: rounds}, http.StatusOK)
+}
+
+// loadTournament loads the tournament named in the path, checks the user
+// organises or plays in it and brings it up to date. It writes the error
+// response and returns nil on failure.
+func (h *Handler) loadTournament(w http.ResponseWriter, r *http.Request, userID int64) (*models.Tournament, []models.TournamentMatch) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		jsonError(w, "invalid tournament ID", http.StatusBadRequest)
+		return nil, nil
+	}
+
+	t, err := db.GetTournament(h.db, id)
+	if err == db.ErrTournamentNotFound {
+		jsonError(w, "tournament not found", http.StatusNotFound)
+		return nil, nil
+	}
+	if err != nil {
+		jsonError(w, "failed to get tournament", http.StatusInternalServerError)
+		return nil, nil
+	}
+
+	inTournament, err := db.InTournament(h.db, t.ID, userID)
+	if err != nil || !inTournament {
+		jsonError(w, "not in this tournament", http.StatusForbidden)
+		return nil, nil
+	}
+
+	matches, err := h.advanceTournament(t)
+	if err != nil {
+		jsonError(w, "failed to get tournament", http.StatusInternalServerError)
+		return nil, nil
+	}
+
+	return t, matches
+}
+
+// advanceTournament brings a tournament up to date with its games: it
+// records finished games, moves elimination winners on, starts the games of
+// matches that are ready and completes the tournament after the last match.
+// Nothing runs in the background, so this happens whenever it is viewed. It
+// fills in the participants and returns the matches.
+func (h *Handler) advanceTournament(t *models.Tournament) ([]models.TournamentMatch, error) {
+	participants, err := db.GetTournamentParticipants(h.db, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	t.Participants = participants
+
+	matches, err := db.GetTournamentMatches(h.db, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if t.Status != "active" {
+		return matches, nil
+	}
+
+	load := gameLoaders[t.GameType]
+	for i := range matches {
+		m := &matches[i]
+		if m.Status != "active" || m.GameID == nil {
+			continue
+		}
+
+		game, _, err := load(h, *m.GameID)
+		if err != nil || !gameFinished(game.Status) {
+			continue
+		}
+
+		// A knockout match needs a winner, so a drawn game is played again
+		if game.WinnerID == nil && t.Format == tournament.SingleElimination {
+			if err := db.ReplayTournamentMatch(h.db, m.ID, *m.GameID); err != nil {
+				return nil, err
+			}
+			m.Status, m.GameID = "pending", nil
+			m.Replays++
+			continue
+		}
+
+		completed, err := db.CompleteTournamentMatch(h.db, m.ID, game.WinnerID, game.Scores)
+		if err != nil {
+			return nil, err
+		}
+		m.Status, m.WinnerID, m.Scores = "completed", game.WinnerID, game.Scores
+
+		if completed && t.Format == tournament.SingleElimination && m.Round < t.TotalRounds {
+			round, position := tournament.NextMatch(m.Round, m.Position)
+			slot := tournament.AdvanceSlot(m.Position)
+			if err := db.SetTournamentMatchPlayer(h.db, t.ID, round, position, slot, *game.WinnerID); err != nil {
+				return nil, err
+			}
+			for j := range matches {
+				if matches[j].Round == round && matches[j].Position == position {
+					tournament.Advance(&matches[j], m.Position, *game.WinnerID)
+				}
+			}
+		}
+	}
+
+	round := tournament.CurrentRound(matches)
+	if round == 0 {
+		winnerID := tournament.Winner(t.Format, participants, matches)
+		if err := db.CompleteTournament(h.db, t.ID, winnerID); err != nil {
+			return nil, err
+		}
+		t.Status, t.WinnerID = "completed", winnerID
+		return matches, nil
+	}
+	if round != t.CurrentRound {
+		if err := db.SetTournamentRound(h.db, t.ID, round); err != nil {
+			return nil, err
+		}
+		t.CurrentRound = round
+	}
+
+	// Round robin plays a round at a time; an elimination match starts as
+	// soon as both its players are known
+	for i := range matches {
+		m := &matches[i]
+		if (m.Status != "pending" && m.Status != "creating") || m.Player1ID == nil || m.Player2ID == nil {
+			continue
+		}
+		if t.Format == tournament.RoundRobin && m.Round != round {
+			continue
+		}
+
+		claimed, err := db.ClaimTournamentMatch(h.db, m.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !claimed {
+			continue
+		}
+
+		// A game that cannot be created, say because two players are no
+		// longer friends, or linked gives up the claim and is tried again
+		// next time
+		gameID, err := h.createGame(t.GameType, *m.Player1ID, *m.Player2ID, t.Settings)
+		if err != nil {
+			db.ReleaseTournamentMatch(h.db, m.ID)
+			continue
+		}
+		if err := db.SetTournamentMatchGame(h.db, m.ID, gameID); err != nil {
+			db.ReleaseTournamentMatch(h.db, m.ID)
+			continue
+		}
+		m.Status, m.GameID = "active", &gameID
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,406 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"altech/internal/db"
+	"altech/internal/mastermind"
+	"altech/internal/middleware"
+	"altech/internal/models"
+	"altech/internal/wordmind"
+)
+
+func (h *Handler) GetWordmindGames(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	games, err := db.GetWordmindGamesForUser(h.db, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get games", http.StatusInternalServerError)
+		return
+	}
+
+	unread, _ := db.GetUnreadChatCounts(h.db, "wordmind", userCtx.UserID)
+	for i := range games {
+		games[i].UnreadMessages = unread[games[i].ID]
+	}
+
+	response := models.WordmindGamesListResponse{
+		YourTurn:  []models.WordmindGame{},
+		TheirTurn: []models.WordmindGame{},
+		Completed: []models.WordmindGame{},
+	}
+
+	for _, game := range games {
+		if game.Status == "completed" {
+			response.Completed = append(response.Completed, game)
+		} else if game.Status == "setup" {
+			// During setup, check if current user has picked their word
+			secret, _ := db.GetWordmindSecret(h.db, game.ID, userCtx.UserID)
+			if secret == "" {
+				response.YourTurn = append(response.YourTurn, game)
+			} else {
+				response.TheirTurn = append(response.TheirTurn, game)
+			}
+		} else if game.CurrentTurn == userCtx.UserID {
+			response.YourTurn = append(response.YourTurn, game)
+		} else {
+			response.TheirTurn = append(response.TheirTurn, game)
+		}
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// createWordmindGame starts a game in the setup phase, where each player
+// picks a word for the other
+func (h *Handler) createWordmindGame(creatorID, opponentID int64, settings []byte) (int64, error) {
+	var req models.CreateWordmindGameRequest
+	if err := decodeSettings(settings, &req); err != nil {
+		return 0, err
+	}
+
+	maxGuesses := wordmind.ValidateMaxGuesses(req.MaxGuesses)
+
+	game, err := db.CreateWordmindGame(h.db, creatorID, opponentID, maxGuesses)
+	if err != nil {
+		return 0, errCreateGame
+	}
+
+	return game.ID, nil
+}
+
+func (h *Handler) GetWordmindGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetWordmindGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	// Check user is a player
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	response := h.buildWordmindResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) SetWordmindSecret(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.SetWordmindSecretRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetWordmindGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	// Check user is a player
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status != "setup" {
+		jsonError(w, "game is not in setup phase", http.StatusBadRequest)
+		return
+	}
+
+	word := wordmind.NormalizeWord(req.Word)
+	if err := wordmind.ValidateWord(word); err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := db.SetWordmindSecret(h.db, gameID, userCtx.UserID, word); err != nil {
+		jsonError(w, "failed to save secret", http.StatusInternalServerError)
+		return
+	}
+
+	// Check if both players have picked their words
+	bothSet, _ := db.BothWordmindSecretsSet(h.db, gameID)
+	if bothSet {
+		game.Status = "active"
+		db.UpdateWordmindGame(h.db, game)
+	}
+
+	// Refetch game for updated status
+	game, _ = db.GetWordmindGame(h.db, gameID)
+
+	response := h.buildWordmindResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) MakeWordmindGuess(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.MakeWordmindGuessRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetWordmindGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	// Check user is a player
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status != "active" {
+		jsonError(w, "game is not active", http.StatusBadRequest)
+		return
+	}
+
+	if game.CurrentTurn != userCtx.UserID {
+		jsonError(w, "not your turn", http.StatusBadRequest)
+		return
+	}
+
+	word := wordmind.NormalizeWord(req.Word)
+	if err := wordmind.ValidateWord(word); err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	opponentID := game.Player1ID
+	if userCtx.UserID == game.Player1ID {
+		opponentID = game.Player2ID
+	}
+
+	opponentSecret, err := db.GetWordmindSecret(h.db, gameID, opponentID)
+	if err != nil || opponentSecret == "" {
+		jsonError(w, "opponent secret not found", http.StatusInternalServerError)
+		return
+	}
+
+	// Evaluate the guess
+	feedback := wordmind.EvaluateGuess(opponentSecret, word)
+	feedbackJSON, _ := wordmind.FeedbackToJSON(feedback)
+
+	myGuesses, _ := db.GetWordmindGuesses(h.db, gameID, userCtx.UserID)
+	guessNum := len(myGuesses) + 1
+
+	err = db.CreateWordmindGuess(h.db, gameID, userCtx.UserID, word, feedbackJSON, wordmind.CountCorrect(feedback), guessNum)
+	if err == db.ErrWordmindGuessExists {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to save guess", http.StatusInternalServerError)
+		return
+	}
+
+	// Check win condition
+	myGuesses, _ = db.GetWordmindGuesses(h.db, gameID, userCtx.UserID)
+	opponentGuesses, _ := db.GetWordmindGuesses(h.db, gameID, opponentID)
+
+	var p1Guesses, p2Guesses []mastermind.GuessResult
+	if userCtx.UserID == game.Player1ID {
+		p1Guesses = convertWordmindToGuessResults(myGuesses)
+		p2Guesses = convertWordmindToGuessResults(opponentGuesses)
+	} else {
+		p1Guesses = convertWordmindToGuessResults(opponentGuesses)
+		p2Guesses = convertWordmindToGuessResults(myGuesses)
+	}
+
+	gameOver, winnerID := wordmind.CheckWinCondition(
+		game.Player1ID, game.Player2ID,
+		p1Guesses, p2Guesses,
+		game.MaxGuesses,
+	)
+
+	if gameOver {
+		game.Status = "completed"
+		game.WinnerID = winnerID
+		db.UpdateWordmindGame(h.db, game)
+	} else {
+		// Switch turns
+		game.CurrentTurn = opponentID
+		db.UpdateWordmindGame(h.db, game)
+	}
+
+	// Refetch game for response
+	game, _ = db.GetWordmindGame(h.db, gameID)
+
+	response := h.buildWordmindResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) ResignWordmindGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetWordmindGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	// Check user is a player
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status == "completed" {
+		jsonError(w, "game is already completed", http.StatusBadRequest)
+		return
+	}
+
+	// Set winner as opponent
+	game.Status = "completed"
+	if userCtx.UserID == game.Player1ID {
+		game.WinnerID = &game.Player2ID
+	} else {
+		game.WinnerID = &game.Player1ID
+	}
+
+	db.UpdateWordmindGame(h.db, game)
+
+	jsonResponse(w, map[string]string{"status": "resigned"}, http.StatusOK)
+}
+
+// Helper functions
+
+func (h *Handler) buildWordmindResponse(game *models.WordmindGame, userID int64) models.WordmindGameResponse {
+	opponentID := game.Player1ID
+	if userID == game.Player1ID {
+		opponentID = game.Player2ID
+	}
+
+	myGuesses, _ := db.GetWordmindGuesses(h.db, game.ID, userID)
+	theirGuesses, _ := db.GetWordmindGuesses(h.db, game.ID, opponentID)
+
+	mySecret, _ := db.GetWordmindSecret(h.db, game.ID, userID)
+	hasSecret := mySecret != ""
+
+	// Determine turn
+	isYourTurn := false
+	switch game.Status {
+	case "setup":
+		isYourTurn = !hasSecret
+	case "active":
+		isYourTurn = game.CurrentTurn == userID
+	}
+
+	response := models.WordmindGameResponse{
+		Game:         game,
+		MySecret:     mySecret,
+		MyGuesses:    convertWordmindGuessResponses(myGuesses),
+		TheirGuesses: convertWordmindGuessResponses(theirGuesses),
+		SecretSet:    hasSecret,
+		IsYourTurn:   isYourTurn,
+		Phase:        game.Status,
+		Round:        max(len(myGuesses), len(theirGuesses)),
+	}
+
+	// Show opponent's word only when game is completed
+	if game.Status == "completed" {
+		response.OpponentSecret, _ = db.GetWordmindSecret(h.db, game.ID, opponentID)
+	}
+
+	return response
+}
+
+func convertWordmindToGuessResults(guesses []models.WordmindGuess) []mastermind.GuessResult {
+	results := make([]mastermind.GuessResult, len(guesses))
+	for i, g := range guesses {
+		results[i] = mastermind.GuessResult{
+			Correct:     g.Correct,
+			GuessNumber: g.GuessNumber,
+		}
+	}
+	return results
+}
+
+func convertWordmindGuessResponses(guesses []models.WordmindGuess) []models.WordmindGuessResponse {
+	responses := make([]models.WordmindGuessResponse, len(guesses))
+	for i, g := range guesses {
+		feedback, _ := wordmind.FeedbackFromJSON(g.Feedback)
+		responses[i] = models.WordmindGuessResponse{
+			ID:          g.ID,
+			GameID:      g.GameID,
+			UserID:      g.UserID,
+			Word:        g.Word,
+			Feedback:    feedback,
+			Correct:     g.Correct,
+			GuessNumber: g.GuessNumber,
+			CreatedAt:   g.CreatedAt,
+		}
+	}
+	return responses
+}
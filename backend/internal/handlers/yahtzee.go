@@ -0,0 +1,454 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"altech/internal/db"
+	"altech/internal/middleware"
+	"altech/internal/models"
+	"altech/internal/yahtzee"
+)
+
+func (h *Handler) GetYahtzeeGames(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	games, err := db.GetYahtzeeGamesForUser(h.db, userCtx.UserID)
+	if err != nil {
+		jsonError(w, "failed to get games", http.StatusInternalServerError)
+		return
+	}
+
+	unread, _ := db.GetUnreadChatCounts(h.db, "yahtzee", userCtx.UserID)
+	for i := range games {
+		games[i].UnreadMessages = unread[games[i].ID]
+	}
+
+	response := models.YahtzeeGamesListResponse{
+		YourTurn:  []models.YahtzeeGame{},
+		TheirTurn: []models.YahtzeeGame{},
+		Completed: []models.YahtzeeGame{},
+	}
+
+	for _, game := range games {
+		if game.Status == "completed" {
+			response.Completed = append(response.Completed, game)
+		} else if game.CurrentTurn == userCtx.UserID {
+			response.YourTurn = append(response.YourTurn, game)
+		} else {
+			response.TheirTurn = append(response.TheirTurn, game)
+		}
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// createYahtzeeGame starts a game with two blank scorecards
+func (h *Handler) createYahtzeeGame(creatorID, opponentID int64, settings []byte) (int64, error) {
+	cardJSON, err := yahtzee.ScorecardToJSON(yahtzee.NewScorecard())
+	if err != nil {
+		return 0, errCreateGame
+	}
+
+	// The creator rolls first
+	game, err := db.CreateYahtzeeGame(h.db, creatorID, opponentID, cardJSON)
+	if err != nil {
+		return 0, errCreateGame
+	}
+
+	return game.ID, nil
+}
+
+func (h *Handler) GetYahtzeeGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetYahtzeeGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	response := buildYahtzeeResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) RollYahtzeeDice(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.YahtzeeRollRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetYahtzeeGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status != "active" {
+		jsonError(w, "game is not active", http.StatusBadRequest)
+		return
+	}
+
+	if game.CurrentTurn != userCtx.UserID {
+		jsonError(w, "not your turn", http.StatusBadRequest)
+		return
+	}
+
+	if game.RollsUsed >= yahtzee.RollsPerTurn {
+		jsonError(w, "no rolls left this turn", http.StatusBadRequest)
+		return
+	}
+
+	if req.Held != nil && len(req.Held) != yahtzee.NumDice {
+		jsonError(w, "held must have one entry per die", http.StatusBadRequest)
+		return
+	}
+
+	// Nothing can be held before the first roll of a turn
+	var dice []int
+	held := make([]bool, yahtzee.NumDice)
+	if game.RollsUsed > 0 {
+		dice, err = yahtzee.DiceFromJSON(game.Dice)
+		if err != nil {
+			jsonError(w, "failed to parse dice", http.StatusInternalServerError)
+			return
+		}
+		copy(held, req.Held)
+	}
+
+	dice, err = yahtzee.Roll(dice, held)
+	if err != nil {
+		jsonError(w, "failed to roll dice", http.StatusInternalServerError)
+		return
+	}
+
+	roll := &models.YahtzeeRoll{
+		GameID:     gameID,
+		UserID:     userCtx.UserID,
+		TurnNumber: game.TurnNumber,
+		RollNumber: game.RollsUsed + 1,
+		Held:       held,
+		Dice:       dice,
+	}
+
+	game.Dice, _ = yahtzee.DiceToJSON(dice)
+	game.RollsUsed++
+
+	err = db.SaveYahtzeeRoll(h.db, game, roll)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to save roll", http.StatusInternalServerError)
+		return
+	}
+
+	response := buildYahtzeeResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) ScoreYahtzeeTurn(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.YahtzeeScoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetYahtzeeGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status != "active" {
+		jsonError(w, "game is not active", http.StatusBadRequest)
+		return
+	}
+
+	if game.CurrentTurn != userCtx.UserID {
+		jsonError(w, "not your turn", http.StatusBadRequest)
+		return
+	}
+
+	if game.RollsUsed == 0 {
+		jsonError(w, "roll the dice first", http.StatusBadRequest)
+		return
+	}
+
+	dice, err := yahtzee.DiceFromJSON(game.Dice)
+	if err != nil {
+		jsonError(w, "failed to parse dice", http.StatusInternalServerError)
+		return
+	}
+
+	cardJSON, opponentID := &game.Player1Scorecard, game.Player2ID
+	if userCtx.UserID == game.Player2ID {
+		cardJSON, opponentID = &game.Player2Scorecard, game.Player1ID
+	}
+
+	card, err := yahtzee.ScorecardFromJSON(*cardJSON)
+	if err != nil {
+		jsonError(w, "failed to parse scorecard", http.StatusInternalServerError)
+		return
+	}
+
+	points, bonus, err := yahtzee.Entry(card, req.Category, dice)
+	if err != nil {
+		jsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entry := &models.YahtzeeEntry{
+		GameID:     gameID,
+		UserID:     userCtx.UserID,
+		TurnNumber: game.TurnNumber,
+		Category:   req.Category,
+		Dice:       dice,
+		Points:     points,
+		Bonus:      bonus,
+	}
+
+	yahtzee.Record(card, req.Category, points, bonus)
+	*cardJSON, _ = yahtzee.ScorecardToJSON(card)
+	if userCtx.UserID == game.Player1ID {
+		game.Player1Score = card.Total
+	} else {
+		game.Player2Score = card.Total
+	}
+
+	game.Dice = ""
+	game.RollsUsed = 0
+	game.TurnNumber++
+	game.CurrentTurn = opponentID
+
+	opponentJSON := game.Player2Scorecard
+	if userCtx.UserID == game.Player2ID {
+		opponentJSON = game.Player1Scorecard
+	}
+	if other, _ := yahtzee.ScorecardFromJSON(opponentJSON); yahtzee.IsComplete(card) && yahtzee.IsComplete(other) {
+		game.Status = "completed"
+		if game.Player1Score > game.Player2Score {
+			game.WinnerID = &game.Player1ID
+		} else if game.Player2Score > game.Player1Score {
+			game.WinnerID = &game.Player2ID
+		}
+		// nil WinnerID = draw
+	}
+
+	err = db.SaveYahtzeeEntry(h.db, game, entry)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to save entry", http.StatusInternalServerError)
+		return
+	}
+
+	response := buildYahtzeeResponse(game, userCtx.UserID)
+	jsonResponse(w, response, http.StatusOK)
+}
+
+func (h *Handler) ResignYahtzeeGame(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetYahtzeeGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	if game.Status == "completed" {
+		jsonError(w, "game is already completed", http.StatusBadRequest)
+		return
+	}
+
+	game.Status = "completed"
+	if userCtx.UserID == game.Player1ID {
+		game.WinnerID = &game.Player2ID
+	} else {
+		game.WinnerID = &game.Player1ID
+	}
+
+	err = db.UpdateYahtzeeGame(h.db, game)
+	if err == db.ErrGameChanged {
+		jsonError(w, "game has changed, reload and try again", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to update game", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, map[string]string{"status": "resigned"}, http.StatusOK)
+}
+
+// GetYahtzeeHistory returns every roll and scorecard entry of a game so a
+// disputed turn can be checked against the dice that were actually rolled
+func (h *Handler) GetYahtzeeHistory(w http.ResponseWriter, r *http.Request) {
+	userCtx := middleware.GetUser(r)
+	if userCtx == nil {
+		jsonError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	gameID := extractGameID(r)
+	if gameID == 0 {
+		jsonError(w, "invalid game ID", http.StatusBadRequest)
+		return
+	}
+
+	game, err := db.GetYahtzeeGame(h.db, gameID)
+	if err == db.ErrGameNotFound {
+		jsonError(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		jsonError(w, "failed to get game", http.StatusInternalServerError)
+		return
+	}
+
+	if game.Player1ID != userCtx.UserID && game.Player2ID != userCtx.UserID {
+		jsonError(w, "not a player in this game", http.StatusForbidden)
+		return
+	}
+
+	rolls, err := db.GetYahtzeeRolls(h.db, gameID)
+	if err != nil {
+		jsonError(w, "failed to get rolls", http.StatusInternalServerError)
+		return
+	}
+	entries, err := db.GetYahtzeeEntries(h.db, gameID)
+	if err != nil {
+		jsonError(w, "failed to get entries", http.StatusInternalServerError)
+		return
+	}
+
+	response := models.YahtzeeHistoryResponse{
+		Rolls:   rolls,
+		Entries: entries,
+	}
+	if response.Rolls == nil {
+		response.Rolls = []models.YahtzeeRoll{}
+	}
+	if response.Entries == nil {
+		response.Entries = []models.YahtzeeEntry{}
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// buildYahtzeeResponse constructs the response with both scorecards and, for
+// the player who has rolled, the boxes the dice may go in
+func buildYahtzeeResponse(game *models.YahtzeeGame, userID int64) models.YahtzeeGameResponse {
+	yourJSON, theirJSON := game.Player1Scorecard, game.Player2Scorecard
+	if userID == game.Player2ID {
+		yourJSON, theirJSON = game.Player2Scorecard, game.Player1Scorecard
+	}
+	yours, _ := yahtzee.ScorecardFromJSON(yourJSON)
+	theirs, _ := yahtzee.ScorecardFromJSON(theirJSON)
+
+	resp := models.YahtzeeGameResponse{
+		Game:              game,
+		Dice:              []int{},
+		RollsLeft:         yahtzee.RollsPerTurn - game.RollsUsed,
+		IsYourTurn:        game.Status == "active" && game.CurrentTurn == userID,
+		YourScorecard:     yours,
+		OpponentScorecard: theirs,
+	}
+
+	if game.Status == "active" && game.RollsUsed > 0 {
+		resp.Dice, _ = yahtzee.DiceFromJSON(game.Dice)
+		if resp.IsYourTurn {
+			resp.Options = yahtzee.Options(yours, resp.Dice)
+		}
+	}
+
+	return resp
+}
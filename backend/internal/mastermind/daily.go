@@ -0,0 +1,52 @@
+package mastermind
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand"
+	"time"
+)
+
+// Fixed settings for the daily puzzle so every player gets the same game
+const (
+	DailyCodeLength   = CodeLength
+	DailyNumColors    = 6
+	DailyMaxGuesses   = MaxGuesses
+	DailyAllowRepeats = true
+)
+
+// DailyDateFormat is the layout of a daily puzzle's date (UTC)
+const DailyDateFormat = "2006-01-02"
+
+// DailyGracePeriod is how long after its day ends an attempt can still be
+// finished. Attempts left unfinished after that count as failed.
+const DailyGracePeriod = 2 * time.Hour
+
+// DailyDate returns the puzzle date for t
+func DailyDate(t time.Time) string {
+	return t.UTC().Format(DailyDateFormat)
+}
+
+// DailyCode derives the secret for a date. The same date and salt always
+// give the same code; the salt keeps it from being worked out from the
+// source alone.
+func DailyCode(date, salt string) []int {
+	sum := sha256.Sum256([]byte(salt + ":mastermind-daily:" + date))
+	rng := rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(sum[:8]))))
+
+	code := make([]int, DailyCodeLength)
+	for i := range code {
+		code[i] = rng.Intn(DailyNumColors)
+	}
+	return code
+}
+
+// CheckDailyResult reports whether a solo attempt is over and whether it was solved
+func CheckDailyResult(guesses []GuessResult, codeLength, maxGuesses int) (finished, solved bool) {
+	for _, g := range guesses {
+		if g.Correct == codeLength {
+			return true, true
+		}
+	}
+	return len(guesses) >= maxGuesses, false
+}
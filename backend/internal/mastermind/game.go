@@ -3,25 +3,30 @@ package mastermind
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 )
 
 const (
-	CodeLength = 4
-	MaxGuesses = 10
+	CodeLength    = 4 // default code length
+	MinCodeLength = 3
+	MaxCodeLength = 6
+	MaxGuesses    = 10 // default guess limit
+	MaxGuessLimit = 20
+	MaxColors     = 10
 )
 
-var Colors = []string{"red", "orange", "yellow", "green", "blue", "purple", "pink", "brown"}
+var Colors = []string{"red", "orange", "yellow", "green", "blue", "purple", "pink", "brown", "white", "black"}
 
-// ValidateCode checks if the code is valid
-func ValidateCode(code []int, numColors int, allowRepeats bool) error {
-	if len(code) != CodeLength {
-		return errors.New("code must have exactly 4 colors")
+// ValidateCode checks if the code is valid for the game's settings
+func ValidateCode(code []int, codeLength, numColors int, allowRepeats bool) error {
+	if len(code) != codeLength {
+		return fmt.Errorf("code must have exactly %d colors", codeLength)
 	}
 
 	seen := make(map[int]bool)
 	for i, c := range code {
 		if c < 0 || c >= numColors {
-			return errors.New("invalid color index at position " + string(rune('1'+i)))
+			return fmt.Errorf("invalid color index at position %d", i+1)
 		}
 		if !allowRepeats {
 			if seen[c] {
@@ -34,23 +39,47 @@ func ValidateCode(code []int, numColors int, allowRepeats bool) error {
 	return nil
 }
 
-// ValidateNumColors ensures num_colors is valid (4, 6, or 8)
+// ValidateNumColors ensures num_colors is valid (2 to 10)
 func ValidateNumColors(n int) int {
-	if n == 4 || n == 6 || n == 8 {
+	if n >= 2 && n <= MaxColors {
 		return n
 	}
 	return 6 // default
 }
 
+// ValidateCodeLength ensures code_length is valid (3 to 6)
+func ValidateCodeLength(n int) int {
+	if n >= MinCodeLength && n <= MaxCodeLength {
+		return n
+	}
+	return CodeLength
+}
+
+// ValidateMaxGuesses ensures max_guesses is valid (1 to 20)
+func ValidateMaxGuesses(n int) int {
+	if n >= 1 && n <= MaxGuessLimit {
+		return n
+	}
+	return MaxGuesses
+}
+
+// ValidateSettings checks that a code can actually be made from the settings
+func ValidateSettings(codeLength, numColors int, allowRepeats bool) error {
+	if !allowRepeats && numColors < codeLength {
+		return fmt.Errorf("need at least %d colors for a %d-color code without repeats", codeLength, codeLength)
+	}
+	return nil
+}
+
 // EvaluateGuess compares guess against secret and returns feedback
 // correct = number of right color in right position (black pegs)
 // misplaced = number of right color in wrong position (white pegs)
 func EvaluateGuess(secret, guess []int) (correct, misplaced int) {
 	// Count matches in correct position
-	secretRemaining := make([]int, CodeLength)
-	guessRemaining := make([]int, CodeLength)
+	secretRemaining := make([]int, len(secret))
+	guessRemaining := make([]int, len(secret))
 
-	for i := range CodeLength {
+	for i := range secret {
 		if secret[i] == guess[i] {
 			correct++
 		} else {
@@ -84,14 +113,14 @@ func CheckWinCondition(
 	player1ID, player2ID int64,
 	p1Guesses, p2Guesses []GuessResult,
 	p1Secret, p2Secret []int,
-	maxGuesses int,
+	maxGuesses, codeLength int,
 ) (gameOver bool, winnerID *int64) {
 	// Find if each player has cracked the code
 	var p1Cracked, p2Cracked bool
 	var p1CrackRound, p2CrackRound int
 
 	for _, g := range p1Guesses {
-		if g.Correct == CodeLength {
+		if g.Correct == codeLength {
 			p1Cracked = true
 			p1CrackRound = g.GuessNumber
 			break
@@ -99,7 +128,7 @@ func CheckWinCondition(
 	}
 
 	for _, g := range p2Guesses {
-		if g.Correct == CodeLength {
+		if g.Correct == codeLength {
 			p2Cracked = true
 			p2CrackRound = g.GuessNumber
 			break
@@ -0,0 +1,150 @@
+package mastermind
+
+// Assistant modes, chosen per game when it is created
+const (
+	AssistantOff    = "off"    // no help; for competitive games
+	AssistantHints  = "hints"  // remaining-candidate count and consistency warnings
+	AssistantSolver = "solver" // hints plus a minimax best guess on request
+)
+
+// SolverLimit caps how many candidate codes are kept in memory and searched
+// by BestGuess. The candidate count itself is always exact.
+const SolverLimit = 1500
+
+// ValidateAssistant ensures the assistant mode is valid
+func ValidateAssistant(mode string) string {
+	switch mode {
+	case AssistantHints, AssistantSolver:
+		return mode
+	}
+	return AssistantOff // default
+}
+
+// Contradiction records an earlier guess whose feedback rules out a code
+type Contradiction struct {
+	GuessNumber    int
+	Correct        int // feedback the guess actually got
+	Misplaced      int
+	WouldCorrect   int // feedback it would have got if the code were the secret
+	WouldMisplaced int
+}
+
+// Candidates walks every code allowed by the settings and returns how many
+// are consistent with all the guesses, plus the first SolverLimit of them in
+// lexicographic order.
+func Candidates(guesses []GuessResult, codeLength, numColors int, allowRepeats bool) (count int, codes [][]int) {
+	code := make([]int, codeLength)
+	for {
+		if allowRepeats || distinct(code) {
+			if consistent(code, guesses) {
+				count++
+				if len(codes) < SolverLimit {
+					codes = append(codes, append([]int(nil), code...))
+				}
+			}
+		}
+
+		// Advance to the next code, odometer style
+		i := codeLength - 1
+		for i >= 0 {
+			code[i]++
+			if code[i] < numColors {
+				break
+			}
+			code[i] = 0
+			i--
+		}
+		if i < 0 {
+			return count, codes
+		}
+	}
+}
+
+// Contradictions lists every guess whose feedback could not have happened
+// if code were the secret
+func Contradictions(code []int, guesses []GuessResult) []Contradiction {
+	var result []Contradiction
+	for _, g := range guesses {
+		correct, misplaced := feedback(code, g.Guess)
+		if correct != g.Correct || misplaced != g.Misplaced {
+			result = append(result, Contradiction{
+				GuessNumber:    g.GuessNumber,
+				Correct:        g.Correct,
+				Misplaced:      g.Misplaced,
+				WouldCorrect:   correct,
+				WouldMisplaced: misplaced,
+			})
+		}
+	}
+	return result
+}
+
+// BestGuess picks the candidate whose worst-case feedback leaves the fewest
+// codes remaining (Knuth's minimax), preferring the earliest on ties
+func BestGuess(candidates [][]int) []int {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	var best []int
+	bestWorst := len(candidates) + 1
+	buckets := make(map[[2]int]int)
+	for _, guess := range candidates {
+		clear(buckets)
+		worst := 0
+		for _, secret := range candidates {
+			correct, misplaced := feedback(secret, guess)
+			key := [2]int{correct, misplaced}
+			buckets[key]++
+			if buckets[key] > worst {
+				worst = buckets[key]
+			}
+			if worst >= bestWorst {
+				break
+			}
+		}
+		if worst < bestWorst {
+			bestWorst = worst
+			best = guess
+		}
+	}
+	return best
+}
+
+func consistent(code []int, guesses []GuessResult) bool {
+	for _, g := range guesses {
+		correct, misplaced := feedback(code, g.Guess)
+		if correct != g.Correct || misplaced != g.Misplaced {
+			return false
+		}
+	}
+	return true
+}
+
+// feedback is an allocation-free EvaluateGuess for the solver's inner loops
+func feedback(secret, guess []int) (correct, misplaced int) {
+	var secretCounts, guessCounts [MaxColors]int
+	for i := range secret {
+		if secret[i] == guess[i] {
+			correct++
+		} else {
+			secretCounts[secret[i]]++
+			guessCounts[guess[i]]++
+		}
+	}
+	for c := range secretCounts {
+		misplaced += min(secretCounts[c], guessCounts[c])
+	}
+	return correct, misplaced
+}
+
+func distinct(code []int) bool {
+	var seen [MaxColors]bool
+	for _, c := range code {
+		if seen[c] {
+			return false
+		}
+		seen[c] = true
+	}
+	return true
+}
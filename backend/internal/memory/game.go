@@ -3,35 +3,73 @@ package memory
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math/rand"
+	"strconv"
+	"strings"
+
+	"altech/internal/models"
+)
+
+const (
+	MinBoardDim      = 2
+	MaxBoardDim      = 10
+	DefaultBoardSize = "4x5"
+	DefaultMatchSize = 2
 )
 
-// ParseBoardSize validates and returns rows, cols for a board size string.
+// ParseBoardSize validates and returns rows, cols for a "RxC" board size
+// string, each between 2 and 10.
 func ParseBoardSize(size string) (int, int, error) {
-	switch size {
-	case "4x5":
-		return 4, 5, nil
-	case "6x6":
-		return 6, 6, nil
-	case "8x8":
-		return 8, 8, nil
-	default:
-		return 0, 0, errors.New("invalid board size: must be 4x5, 6x6, or 8x8")
+	parts := strings.Split(size, "x")
+	if len(parts) != 2 {
+		return 0, 0, errors.New("invalid board size: must look like 4x5")
+	}
+	rows, err1 := strconv.Atoi(parts[0])
+	cols, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil {
+		return 0, 0, errors.New("invalid board size: must look like 4x5")
+	}
+	if rows < MinBoardDim || rows > MaxBoardDim || cols < MinBoardDim || cols > MaxBoardDim {
+		return 0, 0, fmt.Errorf("invalid board size: rows and columns must be between %d and %d", MinBoardDim, MaxBoardDim)
+	}
+	return rows, cols, nil
+}
+
+// ValidateMatchSize ensures match_size is 2 (pairs) or 3 (triples)
+func ValidateMatchSize(n int) int {
+	if n == 3 {
+		return 3
+	}
+	return DefaultMatchSize
+}
+
+// ValidateBoard checks that a board can be split into whole groups and that
+// the theme has enough distinct tiles for it
+func ValidateBoard(rows, cols, matchSize int, theme string) error {
+	total := rows * cols
+	if total%matchSize != 0 {
+		return fmt.Errorf("a %dx%d board has %d tiles, which cannot be split into groups of %d", rows, cols, total, matchSize)
+	}
+	if !ValidTheme(theme) {
+		return errors.New("invalid theme: " + theme)
+	}
+	if groups := total / matchSize; groups > len(themes[theme]) {
+		return fmt.Errorf("theme %s only has %d tiles, board needs %d", theme, len(themes[theme]), groups)
 	}
+	return nil
 }
 
-// GenerateBoard creates a shuffled board of tile pairs.
-// Each tile ID represents a unique shape+color combination.
-// The board contains (rows*cols/2) pairs, each appearing exactly twice.
-func GenerateBoard(rows, cols int) [][]int {
+// GenerateBoard creates a shuffled board of tile groups.
+// Each tile ID maps to a tile in the game's theme.
+// The board contains (rows*cols/matchSize) groups, each ID appearing matchSize times.
+func GenerateBoard(rows, cols, matchSize int) [][]int {
 	totalTiles := rows * cols
-	numPairs := totalTiles / 2
 
-	// Create pairs: each pair ID appears twice
+	// Create groups: each ID appears matchSize times
 	tiles := make([]int, totalTiles)
-	for i := 0; i < numPairs; i++ {
-		tiles[i*2] = i
-		tiles[i*2+1] = i
+	for i := range tiles {
+		tiles[i] = i / matchSize
 	}
 
 	// Shuffle
@@ -51,9 +89,37 @@ func GenerateBoard(rows, cols int) [][]int {
 	return board
 }
 
-// CheckMatch returns true if the two positions have the same tile.
-func CheckMatch(board [][]int, r1, c1, r2, c2 int) bool {
-	return board[r1][c1] == board[r2][c2]
+// ValidateSelection checks a turn's tiles are on the board, distinct and
+// not yet matched
+func ValidateSelection(board [][]int, matched [][]bool, positions []models.MemoryPosition, matchSize int) error {
+	if len(positions) != matchSize {
+		return fmt.Errorf("must select exactly %d tiles", matchSize)
+	}
+
+	seen := make(map[models.MemoryPosition]bool)
+	for _, p := range positions {
+		if p.Row < 0 || p.Row >= len(board) || p.Col < 0 || p.Col >= len(board[p.Row]) {
+			return errors.New("position out of bounds")
+		}
+		if seen[p] {
+			return errors.New("must select different tiles")
+		}
+		seen[p] = true
+		if matched[p.Row][p.Col] {
+			return errors.New("tile already matched")
+		}
+	}
+	return nil
+}
+
+// CheckMatch returns true if every position holds the same tile.
+func CheckMatch(board [][]int, positions []models.MemoryPosition) bool {
+	for _, p := range positions[1:] {
+		if board[p.Row][p.Col] != board[positions[0].Row][positions[0].Col] {
+			return false
+		}
+	}
+	return true
 }
 
 // BuildVisibleBoard returns a board where matched tiles show their ID and hidden tiles are -1.
@@ -74,8 +140,8 @@ func BuildVisibleBoard(board [][]int, matched [][]bool) [][]int {
 	return visible
 }
 
-// CountMatched counts the number of matched positions (each pair = 2 matched positions).
-func CountMatched(matched [][]bool) int {
+// CountMatched counts the number of matched groups (each group = matchSize matched positions).
+func CountMatched(matched [][]bool, matchSize int) int {
 	count := 0
 	for _, row := range matched {
 		for _, m := range row {
@@ -84,7 +150,16 @@ func CountMatched(matched [][]bool) int {
 			}
 		}
 	}
-	return count / 2 // pairs
+	return count / matchSize
+}
+
+// TotalGroups returns how many groups a board holds
+func TotalGroups(board [][]int, matchSize int) int {
+	total := 0
+	for _, row := range board {
+		total += len(row)
+	}
+	return total / matchSize
 }
 
 // InitMatched creates an all-false matched grid.
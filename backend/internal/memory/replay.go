@@ -0,0 +1,92 @@
+package memory
+
+import "altech/internal/models"
+
+// Replay walks a game's moves in order, rebuilding both scores after each
+// move and flagging moves that missed a match the player could have made
+// from tiles already seen by either player.
+func Replay(game *models.MemoryGame, moves []models.MemoryMove) ([]models.MemoryReplayStep, []models.MemoryAccuracy) {
+	matchSize := game.MatchSize
+	if matchSize == 0 {
+		matchSize = DefaultMatchSize
+	}
+
+	accuracy := map[int64]*models.MemoryAccuracy{
+		game.Player1ID: {UserID: game.Player1ID},
+		game.Player2ID: {UserID: game.Player2ID},
+	}
+
+	// Face-down tiles whose value has been seen, by position
+	seen := make(map[models.MemoryPosition]int)
+
+	steps := make([]models.MemoryReplayStep, 0, len(moves))
+	var p1Score, p2Score, matchedCount int
+	for i, move := range moves {
+		acc := accuracy[move.UserID]
+		if acc == nil {
+			continue
+		}
+		acc.Moves++
+
+		// Could the player have completed the first tile's group from memory?
+		missed := false
+		if len(move.Reveals) > 0 {
+			first := move.Reveals[0]
+			firstPos := models.MemoryPosition{Row: first.Row, Col: first.Col}
+			known := 0
+			for pos, tile := range seen {
+				if tile == first.Tile && pos != firstPos {
+					known++
+				}
+			}
+			if known >= matchSize-1 {
+				acc.Opportunities++
+				if !move.Matched {
+					acc.Missed++
+					missed = true
+				}
+			}
+		}
+
+		for _, r := range move.Reveals {
+			pos := models.MemoryPosition{Row: r.Row, Col: r.Col}
+			if move.Matched {
+				delete(seen, pos)
+			} else {
+				seen[pos] = r.Tile
+			}
+		}
+
+		if move.Matched {
+			acc.Matches++
+			matchedCount++
+			if move.UserID == game.Player1ID {
+				p1Score++
+			} else {
+				p2Score++
+			}
+		}
+
+		steps = append(steps, models.MemoryReplayStep{
+			MoveNumber:   i + 1,
+			UserID:       move.UserID,
+			Reveals:      move.Reveals,
+			Matched:      move.Matched,
+			MissedMatch:  missed,
+			Player1Score: p1Score,
+			Player2Score: p2Score,
+			MatchedCount: matchedCount,
+			CreatedAt:    move.CreatedAt,
+		})
+	}
+
+	result := []models.MemoryAccuracy{*accuracy[game.Player1ID], *accuracy[game.Player2ID]}
+	for i := range result {
+		result[i].Accuracy = 1
+		if result[i].Opportunities > 0 {
+			result[i].Accuracy = 1 - float64(result[i].Missed)/float64(result[i].Opportunities)
+		}
+	}
+
+	return steps, result
+}
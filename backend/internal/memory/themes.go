@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+
+	"altech/internal/models"
+)
+
+const DefaultTheme = "emoji"
+
+var emojiTiles = []string{
+	"🐶", "🐱", "🦊", "🐻", "🐼", "🐸", "🦄", "🐵",
+	"🐔", "🐧", "🐢", "🐙", "🦋", "🐞", "🐠", "🦀",
+	"🍎", "🍌", "🍇", "🍓", "🍕", "🍩", "🍪", "🍉",
+	"⚽", "🏀", "🎲", "🎮", "🚗", "✈️", "🚀", "⛵",
+	"⭐", "🌙", "☀️", "🔥", "💎", "🎁", "🎈", "🎵",
+	"🐝", "🦉", "🐳", "🦒", "🌵", "🍄", "🥕", "🧁",
+	"🎸", "🚲",
+}
+
+var (
+	shapeNames  = []string{"circle", "triangle", "square", "diamond", "star", "hexagon", "cross", "heart"}
+	shapeColors = []struct{ Name, Hex string }{
+		{"red", "#e74c3c"}, {"blue", "#3498db"}, {"green", "#2ecc71"}, {"orange", "#f39c12"},
+		{"purple", "#9b59b6"}, {"amber", "#e67e22"}, {"teal", "#1abc9c"}, {"pink", "#e84393"},
+	}
+)
+
+// themes maps a theme name to its tiles; a tile's ID is its index
+var themes = map[string][]models.MemoryTile{
+	"emoji":   buildEmojiTheme(),
+	"shapes":  buildShapesTheme(),
+	"numbers": buildNumbersTheme(60),
+}
+
+// ValidTheme reports whether name is a known tile theme
+func ValidTheme(name string) bool {
+	_, ok := themes[name]
+	return ok
+}
+
+// ThemeTiles returns the first count tiles of a theme, i.e. the tiles a
+// board with count distinct IDs uses
+func ThemeTiles(name string, count int) []models.MemoryTile {
+	tiles := themes[name]
+	if count > len(tiles) {
+		count = len(tiles)
+	}
+	return tiles[:count]
+}
+
+// Themes lists every theme with how many distinct tiles it offers
+func Themes() []models.MemoryTheme {
+	var list []models.MemoryTheme
+	for name, tiles := range themes {
+		list = append(list, models.MemoryTheme{Name: name, TileCount: len(tiles)})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+func buildEmojiTheme() []models.MemoryTile {
+	tiles := make([]models.MemoryTile, len(emojiTiles))
+	for i, e := range emojiTiles {
+		tiles[i] = models.MemoryTile{ID: i, Name: e, Symbol: e}
+	}
+	return tiles
+}
+
+// buildShapesTheme cycles shapes fastest, then colours, so ID n is
+// shape n%8 in colour n/8
+func buildShapesTheme() []models.MemoryTile {
+	var tiles []models.MemoryTile
+	for _, color := range shapeColors {
+		for _, shape := range shapeNames {
+			tiles = append(tiles, models.MemoryTile{
+				ID:    len(tiles),
+				Name:  color.Name + " " + shape,
+				Shape: shape,
+				Color: color.Hex,
+			})
+		}
+	}
+	return tiles
+}
+
+func buildNumbersTheme(count int) []models.MemoryTile {
+	tiles := make([]models.MemoryTile, count)
+	for i := range tiles {
+		n := fmt.Sprintf("%d", i+1)
+		tiles[i] = models.MemoryTile{ID: i, Name: n, Symbol: n}
+	}
+	return tiles
+}
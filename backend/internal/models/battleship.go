@@ -3,18 +3,24 @@ package models
 import "time"
 
 type BattleshipGame struct {
-	ID           int64     `json:"id"`
-	Player1ID    int64     `json:"player1_id"`
-	Player2ID    int64     `json:"player2_id"`
-	CurrentTurn  int64     `json:"current_turn"`
-	Status       string    `json:"status"` // setup, active, completed
-	WinnerID     *int64    `json:"winner_id,omitempty"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID          int64     `json:"id"`
+	Player1ID   int64     `json:"player1_id"`
+	Player2ID   int64     `json:"player2_id"`
+	CurrentTurn int64     `json:"current_turn"`
+	Status      string    `json:"status"` // setup, active, completed
+	WinnerID    *int64    `json:"winner_id,omitempty"`
+	BoardSize   int       `json:"board_size"` // 8, 10, or 12
+	Fleet       string    `json:"-"`          // JSON object of ship type -> count
+	Salvo       bool      `json:"salvo"`      // one shot per surviving ship
+	NoTouch     bool      `json:"no_touch"`   // ships may not be adjacent
+	MoveCount   int       `json:"move_count"` // turns fired, salvos counting once
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 
 	// Populated for responses
-	Player1 *User `json:"player1,omitempty"`
-	Player2 *User `json:"player2,omitempty"`
+	Player1        *User `json:"player1,omitempty"`
+	Player2        *User `json:"player2,omitempty"`
+	UnreadMessages int   `json:"unread_messages,omitempty"` // Unread chat messages, set on games lists
 }
 
 type BattleshipBoard struct {
@@ -22,34 +28,51 @@ type BattleshipBoard struct {
 	GameID     int64  `json:"game_id"`
 	UserID     int64  `json:"user_id"`
 	Ships      string `json:"-"` // JSON array of ship placements
-	Shots      string `json:"-"` // JSON array of shots received
 	ShipsReady bool   `json:"ships_ready"`
 }
 
 type Ship struct {
-	Type      string `json:"type"` // carrier, battleship, cruiser, submarine, destroyer
-	StartRow  int    `json:"start_row"`
-	StartCol  int    `json:"start_col"`
-	Horizontal bool  `json:"horizontal"`
-	Size      int    `json:"size"`
-	Hits      int    `json:"hits"`
+	Type       string `json:"type"` // carrier, battleship, cruiser, submarine, destroyer
+	StartRow   int    `json:"start_row"`
+	StartCol   int    `json:"start_col"`
+	Horizontal bool   `json:"horizontal"`
+	Size       int    `json:"size"`
+	Hits       int    `json:"hits"` // Derived from the shot log, never stored
 }
 
+// Shot is one row of the battleship_shots log
 type Shot struct {
-	Row int  `json:"row"`
-	Col int  `json:"col"`
-	Hit bool `json:"hit"`
+	ID        int64     `json:"id"`
+	GameID    int64     `json:"game_id"`
+	ShooterID int64     `json:"shooter_id"`
+	Row       int       `json:"row"`
+	Col       int       `json:"col"`
+	Hit       bool      `json:"hit"`
+	SunkShip  string    `json:"sunk_ship,omitempty"` // Ship type sunk by this shot
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Cell struct {
 	Row    int    `json:"row"`
 	Col    int    `json:"col"`
-	Status string `json:"status"` // empty, ship, hit, miss
+	Status string `json:"status"` // empty, ship, hit, miss, sunk
+}
+
+type FleetStatus struct {
+	Type   string `json:"type"`
+	Size   int    `json:"size"`
+	Total  int    `json:"total"`
+	Sunk   int    `json:"sunk"`
+	Afloat int    `json:"afloat"`
 }
 
 // API Request/Response types
 type CreateBattleshipGameRequest struct {
-	OpponentID int64 `json:"opponent_id"`
+	OpponentID int64          `json:"opponent_id"`
+	BoardSize  int            `json:"board_size"` // 8, 10, or 12 (default 10)
+	Fleet      map[string]int `json:"fleet"`      // ship type -> count (default classic fleet)
+	Salvo      bool           `json:"salvo"`
+	NoTouch    bool           `json:"no_touch"`
 }
 
 type PlaceShipsRequest struct {
@@ -57,19 +80,72 @@ type PlaceShipsRequest struct {
 }
 
 type FireShotRequest struct {
-	Row int `json:"row"`
-	Col int `json:"col"`
+	Row   int    `json:"row"`
+	Col   int    `json:"col"`
+	Salvo []Cell `json:"salvo,omitempty"` // Salvo games: one target per surviving ship
 }
 
 type BattleshipGameResponse struct {
 	Game                *BattleshipGame `json:"game"`
-	MyBoard             [][]string      `json:"my_board"`              // Full view of own board
-	EnemyBoard          [][]string      `json:"enemy_board"`           // Only shows hits/misses
+	MyBoard             [][]string      `json:"my_board"`    // Full view of own board
+	EnemyBoard          [][]string      `json:"enemy_board"` // Hits, misses and sunk ship outlines
 	MyShips             []Ship          `json:"my_ships"`
 	IsYourTurn          bool            `json:"is_your_turn"`
 	ShipsReady          bool            `json:"ships_ready"`
-	Phase               string          `json:"phase"`                  // setup, active, completed
-	EnemyShipsRemaining int             `json:"enemy_ships_remaining"`  // How many enemy ships are still afloat
+	Phase               string          `json:"phase"`                 // setup, active, completed
+	EnemyShipsRemaining int             `json:"enemy_ships_remaining"` // How many enemy ships are still afloat
+	Fleet               map[string]int  `json:"fleet"`                 // Ships each player must place
+	ShotsThisTurn       int             `json:"shots_this_turn"`       // Shots allowed on your next turn
+	MyFleet             []FleetStatus   `json:"my_fleet"`              // Sunk vs afloat per ship type
+	EnemyFleet          []FleetStatus   `json:"enemy_fleet"`           // Sunk vs afloat per ship type
+	SunkEnemyShips      []Ship          `json:"sunk_enemy_ships"`      // Full positions of enemy ships you have sunk
+	EnemyShips          []Ship          `json:"enemy_ships,omitempty"` // Whole enemy fleet, only once the game is over
+}
+
+type SuggestShipsResponse struct {
+	Strategy string     `json:"strategy"`
+	Ships    []Ship     `json:"ships"` // Proposed placement, not saved until PlaceShips
+	Board    [][]string `json:"board"`
+}
+
+// ShotAnalysis compares one shot with the best shot available at the time
+type ShotAnalysis struct {
+	Row         int     `json:"row"`
+	Col         int     `json:"col"`
+	Hit         bool    `json:"hit"`
+	Mode        string  `json:"mode"`         // hunt, target
+	Density     float64 `json:"density"`      // Probability density of the chosen cell
+	BestDensity float64 `json:"best_density"` // Highest density on the board at the time
+	BestRow     int     `json:"best_row"`
+	BestCol     int     `json:"best_col"`
+	Rank        int     `json:"rank"` // 1 = as good as the optimal shot
+}
+
+type PlayerShotAnalysis struct {
+	UserID              int64          `json:"user_id"`
+	Username            string         `json:"username"`
+	TotalShots          int            `json:"total_shots"`
+	Hits                int            `json:"hits"`
+	ShotsToFirstHit     int            `json:"shots_to_first_hit"` // 0 if never hit
+	HuntShots           int            `json:"hunt_shots"`
+	HuntHits            int            `json:"hunt_hits"`
+	HuntEfficiency      float64        `json:"hunt_efficiency"`
+	TargetShots         int            `json:"target_shots"`
+	TargetHits          int            `json:"target_hits"`
+	TargetEfficiency    float64        `json:"target_efficiency"`
+	OptimalShots        int            `json:"optimal_shots"`         // Shots at a highest-density cell
+	AverageDensityRatio float64        `json:"average_density_ratio"` // Mean of density / best density
+	Shots               []ShotAnalysis `json:"shots"`
+}
+
+type BattleshipAnalysisResponse struct {
+	Players          []PlayerShotAnalysis `json:"players"`
+	PlacementHeatmap [][]int              `json:"placement_heatmap"` // Your ship placements across games on this board size
+	HeatmapGames     int                  `json:"heatmap_games"`
+}
+
+type BattleshipHistoryResponse struct {
+	Shots []Shot `json:"shots"` // Every shot in the game, oldest first
 }
 
 type BattleshipGamesListResponse struct {
@@ -78,10 +154,19 @@ type BattleshipGamesListResponse struct {
 	Completed []BattleshipGame `json:"completed"`
 }
 
+type ShotResult struct {
+	Row      int    `json:"row"`
+	Col      int    `json:"col"`
+	Hit      bool   `json:"hit"`
+	Sunk     bool   `json:"sunk"`
+	ShipType string `json:"ship_type,omitempty"`
+}
+
 type FireShotResponse struct {
-	Hit        bool   `json:"hit"`
-	Sunk       bool   `json:"sunk"`
-	ShipType   string `json:"ship_type,omitempty"`
-	GameOver   bool   `json:"game_over"`
-	Winner     string `json:"winner,omitempty"`
+	Hit      bool         `json:"hit"`
+	Sunk     bool         `json:"sunk"`
+	ShipType string       `json:"ship_type,omitempty"`
+	GameOver bool         `json:"game_over"`
+	Winner   string       `json:"winner,omitempty"`
+	Results  []ShotResult `json:"results"` // One entry per shot fired this turn
 }
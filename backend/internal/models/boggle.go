@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+// BoggleGame is a word race: both players get the same grid and their own
+// time window, started when they choose
+type BoggleGame struct {
+	ID               int64      `json:"id"`
+	Player1ID        int64      `json:"player1_id"`
+	Player2ID        int64      `json:"player2_id"`
+	Status           string     `json:"status"` // active, completed
+	WinnerID         *int64     `json:"winner_id,omitempty"`
+	Size             int        `json:"size"` // 4 or 5
+	Seed             int64      `json:"-"`
+	Grid             string     `json:"-"` // JSON 2D array of letters; "QU" is one cell
+	DurationSeconds  int        `json:"duration_seconds"`
+	Player1Score     int        `json:"player1_score"` // Set when the game completes
+	Player2Score     int        `json:"player2_score"`
+	Player1StartedAt *time.Time `json:"player1_started_at,omitempty"`
+	Player2StartedAt *time.Time `json:"player2_started_at,omitempty"`
+	Solution         string     `json:"-"` // JSON array of every word on the grid, stored when the game ends
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+
+	// Populated for responses
+	Player1        *User `json:"player1,omitempty"`
+	Player2        *User `json:"player2,omitempty"`
+	UnreadMessages int   `json:"unread_messages,omitempty"` // Unread chat messages, set on games lists
+}
+
+type BoggleCell struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+type BoggleWord struct {
+	ID        int64        `json:"id"`
+	GameID    int64        `json:"game_id"`
+	UserID    int64        `json:"user_id"`
+	Word      string       `json:"word"`
+	Path      []BoggleCell `json:"path"`
+	Points    int          `json:"points"`
+	Cancelled bool         `json:"cancelled"` // Found by both players; only known once the game ends
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// Request types
+type CreateBoggleGameRequest struct {
+	OpponentID int64 `json:"opponent_id"`
+	Size       int   `json:"size"` // 4 (default) or 5
+}
+
+type SubmitBoggleWordRequest struct {
+	Word string       `json:"word"`
+	Path []BoggleCell `json:"path,omitempty"` // Optional; the server traces the word if omitted
+}
+
+// Response types
+type BoggleGameResponse struct {
+	Game              *BoggleGame  `json:"game"`
+	Grid              [][]string   `json:"grid,omitempty"` // Hidden until you start your window
+	MinWordLength     int          `json:"min_word_length"`
+	StartedAt         *time.Time   `json:"started_at,omitempty"`
+	SecondsLeft       int          `json:"seconds_left"`
+	YourWords         []BoggleWord `json:"your_words"`
+	YourScore         int          `json:"your_score"`
+	OpponentWordCount int          `json:"opponent_word_count"`
+	OpponentWords     []BoggleWord `json:"opponent_words,omitempty"` // Revealed once the game ends
+	Solution          []string     `json:"solution,omitempty"`       // Every word on the grid, once the game ends
+}
+
+type BoggleGamesListResponse struct {
+	YourTurn  []BoggleGame `json:"your_turn"`  // Your window has not finished
+	TheirTurn []BoggleGame `json:"their_turn"` // Waiting for your opponent
+	Completed []BoggleGame `json:"completed"`
+}
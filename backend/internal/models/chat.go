@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// ChatMessage belongs to a game's thread, or with no game type is a direct
+// message between friends
+type ChatMessage struct {
+	ID          int64      `json:"id"`
+	GameType    string     `json:"game_type,omitempty"`
+	GameID      int64      `json:"game_id,omitempty"`
+	SenderID    int64      `json:"sender_id"`
+	RecipientID int64      `json:"recipient_id"`
+	Body        string     `json:"body"`
+	ReadAt      *time.Time `json:"read_at,omitempty"` // Read receipt, set when the recipient marks the thread read
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type ChatSettings struct {
+	FilterProfanity bool `json:"filter_profanity"` // Mask profanity in messages from others (default true)
+}
+
+// Request types
+type SendChatMessageRequest struct {
+	Body string `json:"body"` // Up to 500 characters
+}
+
+// Response types
+type ChatMessagesResponse struct {
+	Messages []ChatMessage `json:"messages"` // Oldest first
+	HasMore  bool          `json:"has_more"` // More messages lie beyond this page
+}
+
+type ChatReadResponse struct {
+	Read int `json:"read"` // Messages newly marked read
+}
+
+type ChatGameUnread struct {
+	GameType string `json:"game_type"`
+	GameID   int64  `json:"game_id"`
+	Count    int    `json:"count"`
+}
+
+type ChatDirectUnread struct {
+	UserID int64 `json:"user_id"`
+	Count  int   `json:"count"`
+}
+
+type ChatUnreadResponse struct {
+	Total  int                `json:"total"`
+	Games  []ChatGameUnread   `json:"games"`
+	Direct []ChatDirectUnread `json:"direct"`
+}
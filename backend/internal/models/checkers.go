@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+type CheckersGame struct {
+	ID          int64     `json:"id"`
+	Player1ID   int64     `json:"player1_id"` // Black, moves first
+	Player2ID   int64     `json:"player2_id"` // White
+	CurrentTurn int64     `json:"current_turn"`
+	Status      string    `json:"status"` // active, completed
+	WinnerID    *int64    `json:"winner_id,omitempty"`
+	EndReason   string    `json:"end_reason,omitempty"` // no_moves, forty_move_rule, threefold_repetition, resigned
+	Board       string    `json:"-"`                    // JSON 8x8 array of pieces
+	QuietPlies  int       `json:"quiet_plies"`          // Plies since the last capture or man move
+	MoveCount   int       `json:"move_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Populated for responses
+	Player1        *User `json:"player1,omitempty"`
+	Player2        *User `json:"player2,omitempty"`
+	UnreadMessages int   `json:"unread_messages,omitempty"` // Unread chat messages, set on games lists
+}
+
+type CheckersSquare struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+// CheckersMove is one complete move, including every jump of a multi-jump
+type CheckersMove struct {
+	Path     []CheckersSquare `json:"path"`     // Start square, then each landing square
+	Captured []CheckersSquare `json:"captured"` // Squares of the pieces jumped
+	Notation string           `json:"notation"` // e.g. 9-14 or 22x15x6
+}
+
+// CheckersMoveRecord is a move as stored in a game's move list
+type CheckersMoveRecord struct {
+	ID         int64            `json:"id"`
+	GameID     int64            `json:"game_id"`
+	UserID     int64            `json:"user_id"`
+	MoveNumber int              `json:"move_number"`
+	Path       []CheckersSquare `json:"path"`
+	Captured   []CheckersSquare `json:"captured"`
+	Notation   string           `json:"notation"`
+	Crowned    bool             `json:"crowned"`
+	Position   string           `json:"-"` // Position key after the move, for repetition
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// Request types
+type CreateCheckersGameRequest struct {
+	OpponentID int64 `json:"opponent_id"`
+}
+
+type CheckersMoveRequest struct {
+	Path []CheckersSquare `json:"path"` // Start square, then each landing square
+}
+
+// Response types
+type CheckersGameResponse struct {
+	Game       *CheckersGame        `json:"game"`
+	Board      [][]int              `json:"board"`     // 0 empty, 1 black man, 2 white man, 3 black king, 4 white king
+	YourSide   int                  `json:"your_side"` // 1 black, 2 white
+	IsYourTurn bool                 `json:"is_your_turn"`
+	LegalMoves []CheckersMove       `json:"legal_moves"` // Only for the player to move
+	Moves      []CheckersMoveRecord `json:"moves"`
+}
+
+type CheckersGamesListResponse struct {
+	YourTurn  []CheckersGame `json:"your_turn"`
+	TheirTurn []CheckersGame `json:"their_turn"`
+	Completed []CheckersGame `json:"completed"`
+}
@@ -0,0 +1,87 @@
+package models
+
+import "time"
+
+type ChessGame struct {
+	ID          int64     `json:"id"`
+	Player1ID   int64     `json:"player1_id"` // White
+	Player2ID   int64     `json:"player2_id"` // Black
+	CurrentTurn int64     `json:"current_turn"`
+	Status      string    `json:"status"` // active, completed
+	WinnerID    *int64    `json:"winner_id,omitempty"`
+	EndReason   string    `json:"end_reason,omitempty"` // checkmate, stalemate, fifty_move_rule, threefold_repetition, resigned
+	StartFEN    string    `json:"start_fen"`
+	FEN         string    `json:"fen"`
+	MoveCount   int       `json:"move_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Populated for responses
+	Player1        *User `json:"player1,omitempty"`
+	Player2        *User `json:"player2,omitempty"`
+	UnreadMessages int   `json:"unread_messages,omitempty"` // Unread chat messages, set on games lists
+}
+
+type ChessMove struct {
+	ID            int64     `json:"id"`
+	GameID        int64     `json:"game_id"`
+	UserID        int64     `json:"user_id"`
+	MoveNumber    int       `json:"move_number"` // Half-move count from the start of the game
+	UCI           string    `json:"uci"`
+	SAN           string    `json:"san"`
+	FEN           string    `json:"fen"` // Position after the move
+	RepetitionKey string    `json:"-"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Request types
+type CreateChessGameRequest struct {
+	OpponentID int64  `json:"opponent_id"`
+	FEN        string `json:"fen,omitempty"` // Optional start position
+	PGN        string `json:"pgn,omitempty"` // Optional game to continue from
+}
+
+type ChessMoveRequest struct {
+	Move string `json:"move"` // SAN (Nf3, O-O, e8=Q) or coordinates (g1f3, e7e8q)
+}
+
+// Response types
+type ChessLegalMove struct {
+	UCI string `json:"uci"`
+	SAN string `json:"san"`
+}
+
+type ChessGameResponse struct {
+	Game       *ChessGame       `json:"game"`
+	Board      [][]string       `json:"board"`      // FEN letters, rank 8 first, "" for empty
+	YourColor  string           `json:"your_color"` // white, black
+	IsYourTurn bool             `json:"is_your_turn"`
+	InCheck    bool             `json:"in_check"`    // The side to move is in check
+	LegalMoves []ChessLegalMove `json:"legal_moves"` // Only for the player to move
+	Moves      []ChessMove      `json:"moves"`
+}
+
+type ChessHistoryItem struct {
+	MoveNumber int    `json:"move_number"`
+	PlayerName string `json:"player_name"`
+	Color      string `json:"color"`
+	SAN        string `json:"san"`
+	UCI        string `json:"uci"`
+	FEN        string `json:"fen"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type ChessHistoryResponse struct {
+	StartFEN string             `json:"start_fen"`
+	Moves    []ChessHistoryItem `json:"moves"`
+}
+
+type ChessPGNResponse struct {
+	PGN string `json:"pgn"`
+}
+
+type ChessGamesListResponse struct {
+	YourTurn  []ChessGame `json:"your_turn"`
+	TheirTurn []ChessGame `json:"their_turn"`
+	Completed []ChessGame `json:"completed"`
+}
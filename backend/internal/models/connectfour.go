@@ -0,0 +1,69 @@
+package models
+
+import "time"
+
+type ConnectFourGame struct {
+	ID          int64     `json:"id"`
+	Player1ID   int64     `json:"player1_id"` // Plays disc 1 and moves first
+	Player2ID   int64     `json:"player2_id"` // Plays disc 2
+	CurrentTurn int64     `json:"current_turn"`
+	Status      string    `json:"status"` // active, completed
+	WinnerID    *int64    `json:"winner_id,omitempty"`
+	BoardSize   string    `json:"board_size"` // "7x6" or "8x7" (columns x rows)
+	PopOut      bool      `json:"pop_out"`    // Players may pop their own disc from the bottom row
+	Board       string    `json:"-"`          // JSON 2D array, row 0 at the top
+	MoveCount   int       `json:"move_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Populated for responses
+	Player1        *User `json:"player1,omitempty"`
+	Player2        *User `json:"player2,omitempty"`
+	UnreadMessages int   `json:"unread_messages,omitempty"` // Unread chat messages, set on games lists
+}
+
+type ConnectFourMove struct {
+	ID         int64     `json:"id"`
+	GameID     int64     `json:"game_id"`
+	UserID     int64     `json:"user_id"`
+	MoveNumber int       `json:"move_number"`
+	Action     string    `json:"action"` // drop, pop
+	Column     int       `json:"column"`
+	Row        int       `json:"row"` // Landing row for drops; bottom row for pops
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ConnectFourCell struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+// Request types
+type CreateConnectFourGameRequest struct {
+	OpponentID int64  `json:"opponent_id"`
+	BoardSize  string `json:"board_size"` // "7x6" (default) or "8x7"
+	PopOut     bool   `json:"pop_out"`
+}
+
+type ConnectFourMoveRequest struct {
+	Column int    `json:"column"`
+	Action string `json:"action"` // drop (default) or pop
+}
+
+// Response types
+type ConnectFourGameResponse struct {
+	Game         *ConnectFourGame  `json:"game"`
+	Board        [][]int           `json:"board"`
+	YourPiece    int               `json:"your_piece"` // 1 or 2
+	IsYourTurn   bool              `json:"is_your_turn"`
+	LegalDrops   []int             `json:"legal_drops"`
+	LegalPops    []int             `json:"legal_pops,omitempty"` // PopOut only
+	WinningCells []ConnectFourCell `json:"winning_cells,omitempty"`
+	Moves        []ConnectFourMove `json:"moves"`
+}
+
+type ConnectFourGamesListResponse struct {
+	YourTurn  []ConnectFourGame `json:"your_turn"`
+	TheirTurn []ConnectFourGame `json:"their_turn"`
+	Completed []ConnectFourGame `json:"completed"`
+}
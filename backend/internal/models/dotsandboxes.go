@@ -0,0 +1,85 @@
+package models
+
+import "time"
+
+type DotsAndBoxesGame struct {
+	ID           int64     `json:"id"`
+	Player1ID    int64     `json:"player1_id"` // Moves first
+	Player2ID    int64     `json:"player2_id"`
+	CurrentTurn  int64     `json:"current_turn"`
+	Status       string    `json:"status"` // active, completed
+	WinnerID     *int64    `json:"winner_id,omitempty"`
+	BoardSize    string    `json:"board_size"` // "RxC" in boxes, 2 to 9 each way
+	Board        string    `json:"-"`          // JSON DotsAndBoxesBoard
+	Player1Score int       `json:"player1_score"`
+	Player2Score int       `json:"player2_score"`
+	MoveCount    int       `json:"move_count"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	// Populated for responses
+	Player1        *User `json:"player1,omitempty"`
+	Player2        *User `json:"player2,omitempty"`
+	UnreadMessages int   `json:"unread_messages,omitempty"` // Unread chat messages, set on games lists
+}
+
+// DotsAndBoxesBoard holds who drew each edge and who owns each box
+// (0 nobody, 1 player 1, 2 player 2)
+type DotsAndBoxesBoard struct {
+	Horizontal [][]int `json:"horizontal"` // rows+1 rows of cols edges
+	Vertical   [][]int `json:"vertical"`   // rows rows of cols+1 edges
+	Boxes      [][]int `json:"boxes"`      // rows x cols
+}
+
+type DotsAndBoxesBox struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+type DotsAndBoxesMove struct {
+	ID             int64     `json:"id"`
+	GameID         int64     `json:"game_id"`
+	UserID         int64     `json:"user_id"`
+	MoveNumber     int       `json:"move_number"`
+	Orientation    string    `json:"orientation"` // h, v
+	Row            int       `json:"row"`
+	Col            int       `json:"col"`
+	BoxesCompleted int       `json:"boxes_completed"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Request types
+type CreateDotsAndBoxesGameRequest struct {
+	OpponentID int64  `json:"opponent_id"`
+	BoardSize  string `json:"board_size"` // "RxC" boxes (default "4x4")
+}
+
+type DotsAndBoxesMoveRequest struct {
+	Orientation string `json:"orientation"` // h, v
+	Row         int    `json:"row"`
+	Col         int    `json:"col"`
+}
+
+// Response types
+type DotsAndBoxesMoveResponse struct {
+	Completed    []DotsAndBoxesBox  `json:"completed"`  // Boxes closed by this edge
+	ExtraTurn    bool               `json:"extra_turn"` // Completing a box keeps the turn
+	GameOver     bool               `json:"game_over"`
+	Board        *DotsAndBoxesBoard `json:"board"`
+	Player1Score int                `json:"player1_score"`
+	Player2Score int                `json:"player2_score"`
+}
+
+type DotsAndBoxesGameResponse struct {
+	Game       *DotsAndBoxesGame  `json:"game"`
+	Board      *DotsAndBoxesBoard `json:"board"`
+	YourPlayer int                `json:"your_player"` // 1 or 2, as stored on edges and boxes
+	IsYourTurn bool               `json:"is_your_turn"`
+	Moves      []DotsAndBoxesMove `json:"moves"`
+}
+
+type DotsAndBoxesGamesListResponse struct {
+	YourTurn  []DotsAndBoxesGame `json:"your_turn"`
+	TheirTurn []DotsAndBoxesGame `json:"their_turn"`
+	Completed []DotsAndBoxesGame `json:"completed"`
+}
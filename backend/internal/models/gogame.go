@@ -0,0 +1,106 @@
+package models
+
+import "time"
+
+type GoGame struct {
+	ID                int64     `json:"id"`
+	Player1ID         int64     `json:"player1_id"` // Black, moves first
+	Player2ID         int64     `json:"player2_id"` // White, receives komi
+	CurrentTurn       int64     `json:"current_turn"`
+	Status            string    `json:"status"` // active, scoring, completed
+	WinnerID          *int64    `json:"winner_id,omitempty"`
+	EndReason         string    `json:"end_reason,omitempty"` // scored, resigned
+	Size              int       `json:"size"`                 // 9, 13 or 19
+	Rules             string    `json:"rules"`                // japanese, chinese
+	Komi              float64   `json:"komi"`
+	Board             string    `json:"-"`                // JSON size x size array: 0 empty, 1 black, 2 white
+	Player1Captures   int       `json:"player1_captures"` // White stones captured by Black
+	Player2Captures   int       `json:"player2_captures"`
+	ConsecutivePasses int       `json:"consecutive_passes"`
+	DeadStones        string    `json:"-"` // JSON list of points marked dead while scoring
+	Player1Accepted   bool      `json:"player1_accepted"`
+	Player2Accepted   bool      `json:"player2_accepted"`
+	Player1Score      float64   `json:"player1_score"` // Final score, set when the game is scored
+	Player2Score      float64   `json:"player2_score"`
+	MoveCount         int       `json:"move_count"`
+	ScoringVersion    int       `json:"scoring_version"` // Bumped by every change while scoring
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+
+	// Populated for responses
+	Player1        *User `json:"player1,omitempty"`
+	Player2        *User `json:"player2,omitempty"`
+	UnreadMessages int   `json:"unread_messages,omitempty"` // Unread chat messages, set on games lists
+}
+
+type GoPoint struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+type GoMove struct {
+	ID         int64     `json:"id"`
+	GameID     int64     `json:"game_id"`
+	UserID     int64     `json:"user_id"`
+	MoveNumber int       `json:"move_number"`
+	Color      int       `json:"color"` // 1 black, 2 white
+	Passed     bool      `json:"passed"`
+	Row        int       `json:"row"` // -1 for a pass
+	Col        int       `json:"col"`
+	Captured   int       `json:"captured"`
+	Position   string    `json:"-"` // Whole-board position after the move, for superko
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// GoScore is a count of the board with the marked dead stones removed
+type GoScore struct {
+	Black          float64 `json:"black"`
+	White          float64 `json:"white"` // Includes komi
+	BlackTerritory int     `json:"black_territory"`
+	WhiteTerritory int     `json:"white_territory"`
+	BlackStones    int     `json:"black_stones"` // Living stones on the board
+	WhiteStones    int     `json:"white_stones"`
+	BlackPrisoners int     `json:"black_prisoners"` // Captures plus dead white stones
+	WhitePrisoners int     `json:"white_prisoners"`
+	Ownership      [][]int `json:"ownership"` // Who each point counts for: 0 neutral, 1 black, 2 white
+}
+
+// Request types
+type CreateGoGameRequest struct {
+	OpponentID int64    `json:"opponent_id"`
+	Size       int      `json:"size"`  // 9 (default), 13 or 19
+	Rules      string   `json:"rules"` // japanese (default), chinese
+	Komi       *float64 `json:"komi"`  // Default 6.5 japanese, 7.5 chinese
+}
+
+type GoMoveRequest struct {
+	Action string `json:"action"` // play (default), pass
+	Row    int    `json:"row"`
+	Col    int    `json:"col"`
+}
+
+type GoMarkRequest struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+// Response types
+type GoGameResponse struct {
+	Game       *GoGame   `json:"game"`
+	Board      [][]int   `json:"board"`
+	YourColor  int       `json:"your_color"` // 1 black, 2 white
+	IsYourTurn bool      `json:"is_your_turn"`
+	DeadStones []GoPoint `json:"dead_stones"`
+	Score      *GoScore  `json:"score,omitempty"` // While scoring and once scored
+	Moves      []GoMove  `json:"moves"`
+}
+
+type GoSGFResponse struct {
+	SGF string `json:"sgf"`
+}
+
+type GoGamesListResponse struct {
+	YourTurn  []GoGame `json:"your_turn"` // Includes games waiting for you to accept the score
+	TheirTurn []GoGame `json:"their_turn"`
+	Completed []GoGame `json:"completed"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// GameInvitation offers a friend a game. The game itself is only created
+// when the receiver accepts.
+type GameInvitation struct {
+	ID          int64           `json:"id"`
+	SenderID    int64           `json:"sender_id"` // Moves first once the game starts
+	ReceiverID  int64           `json:"receiver_id"`
+	GameType    string          `json:"game_type"` // scrabble, battleship, chess, ...
+	Settings    json.RawMessage `json:"settings"`  // Variant settings, as for the game's create request
+	Status      string          `json:"status"`    // pending, accepted, declined, cancelled, expired
+	GameID      *int64          `json:"game_id,omitempty"`
+	ExpiresAt   time.Time       `json:"expires_at"`
+	RespondedAt *time.Time      `json:"responded_at,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+
+	// Populated for responses
+	Sender   *User `json:"sender,omitempty"`
+	Receiver *User `json:"receiver,omitempty"`
+}
+
+// Request types
+type CreateGameInvitationRequest struct {
+	OpponentID       int64           `json:"opponent_id"`
+	GameType         string          `json:"game_type"`
+	Settings         json.RawMessage `json:"settings,omitempty"`           // e.g. {"board_size": "8x7", "pop_out": true}
+	ExpiresInMinutes int             `json:"expires_in_minutes,omitempty"` // 5 to 10080 (default 1440)
+}
+
+type GameInvitationAction struct {
+	Action string `json:"action"` // accept or decline; the sender may cancel
+}
+
+// Response types
+type GameInvitationsResponse struct {
+	Invitations []GameInvitation `json:"invitations"`
+}
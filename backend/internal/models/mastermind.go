@@ -9,15 +9,18 @@ type MastermindGame struct {
 	CurrentTurn  int64     `json:"current_turn"`
 	Status       string    `json:"status"` // setup, active, completed
 	WinnerID     *int64    `json:"winner_id,omitempty"`
-	MaxGuesses   int       `json:"max_guesses"`
-	NumColors    int       `json:"num_colors"`    // 4, 6, or 8 colors
+	MaxGuesses   int       `json:"max_guesses"`   // 1 to 20
+	NumColors    int       `json:"num_colors"`    // 2 to 10 colors
+	CodeLength   int       `json:"code_length"`   // 3 to 6 pegs
 	AllowRepeats bool      `json:"allow_repeats"` // whether duplicate colors allowed
+	Assistant    string    `json:"assistant"`     // off, hints, or solver
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 
 	// Populated for responses
-	Player1 *User `json:"player1,omitempty"`
-	Player2 *User `json:"player2,omitempty"`
+	Player1        *User `json:"player1,omitempty"`
+	Player2        *User `json:"player2,omitempty"`
+	UnreadMessages int   `json:"unread_messages,omitempty"` // Unread chat messages, set on games lists
 }
 
 type MastermindSecret struct {
@@ -53,30 +56,38 @@ type MastermindGuessResponse struct {
 
 // Request types
 type CreateMastermindGameRequest struct {
-	OpponentID   int64 `json:"opponent_id"`
-	NumColors    int   `json:"num_colors"`    // 4, 6, or 8 (default 6)
-	AllowRepeats bool  `json:"allow_repeats"` // default true
+	OpponentID   int64  `json:"opponent_id"`
+	NumColors    int    `json:"num_colors"`    // 2 to 10 (default 6)
+	CodeLength   int    `json:"code_length"`   // 3 to 6 (default 4)
+	MaxGuesses   int    `json:"max_guesses"`   // 1 to 20 (default 10)
+	AllowRepeats bool   `json:"allow_repeats"` // default true
+	Assistant    string `json:"assistant"`     // off (default), hints, or solver
 }
 
 type SetMastermindSecretRequest struct {
-	Code []int `json:"code"` // 4 color indices (0-7)
+	Code []int `json:"code"` // code_length color indices (0 to num_colors-1)
 }
 
 type MakeMastermindGuessRequest struct {
-	Guess []int `json:"guess"` // 4 color indices (0-7)
+	Guess []int `json:"guess"` // code_length color indices (0 to num_colors-1)
+}
+
+type MastermindAssistantRequest struct {
+	Guess      []int `json:"guess,omitempty"` // Proposed guess to check against earlier feedback
+	RevealBest bool  `json:"reveal_best"`     // Ask the solver for a best guess (solver mode only)
 }
 
 // Response types
 type MastermindGameResponse struct {
-	Game          *MastermindGame           `json:"game"`
-	MySecret      []int                     `json:"my_secret,omitempty"`      // Only show after game ends or for self
-	OpponentSecret []int                    `json:"opponent_secret,omitempty"` // Only show after game ends
-	MyGuesses     []MastermindGuessResponse `json:"my_guesses"`               // Guesses I made
-	TheirGuesses  []MastermindGuessResponse `json:"their_guesses"`            // Guesses opponent made
-	SecretSet     bool                      `json:"secret_set"`               // Have I set my secret?
-	IsYourTurn    bool                      `json:"is_your_turn"`
-	Phase         string                    `json:"phase"`                    // setup, active, completed
-	Round         int                       `json:"round"`                    // Current round number
+	Game           *MastermindGame           `json:"game"`
+	MySecret       []int                     `json:"my_secret,omitempty"`       // Only show after game ends or for self
+	OpponentSecret []int                     `json:"opponent_secret,omitempty"` // Only show after game ends
+	MyGuesses      []MastermindGuessResponse `json:"my_guesses"`                // Guesses I made
+	TheirGuesses   []MastermindGuessResponse `json:"their_guesses"`             // Guesses opponent made
+	SecretSet      bool                      `json:"secret_set"`                // Have I set my secret?
+	IsYourTurn     bool                      `json:"is_your_turn"`
+	Phase          string                    `json:"phase"` // setup, active, completed
+	Round          int                       `json:"round"` // Current round number
 }
 
 type MastermindGamesListResponse struct {
@@ -84,3 +95,75 @@ type MastermindGamesListResponse struct {
 	TheirTurn []MastermindGame `json:"their_turn"`
 	Completed []MastermindGame `json:"completed"`
 }
+
+type MastermindContradiction struct {
+	GuessNumber    int `json:"guess_number"`
+	Correct        int `json:"correct"` // Feedback that guess actually got
+	Misplaced      int `json:"misplaced"`
+	WouldCorrect   int `json:"would_correct"` // Feedback it would have got if the proposed guess were the secret
+	WouldMisplaced int `json:"would_misplaced"`
+}
+
+type MastermindAssistantResponse struct {
+	RemainingCandidates int                       `json:"remaining_candidates"` // Secret codes still consistent with your feedback
+	Consistent          *bool                     `json:"consistent,omitempty"` // Whether the proposed guess could be the secret
+	Contradictions      []MastermindContradiction `json:"contradictions,omitempty"`
+	BestGuess           []int                     `json:"best_guess,omitempty"`
+}
+
+// MastermindDaily is one player's attempt at a day's solo puzzle
+type MastermindDaily struct {
+	ID          int64      `json:"id"`
+	UserID      int64      `json:"user_id"`
+	Date        string     `json:"date"`   // YYYY-MM-DD (UTC)
+	Status      string     `json:"status"` // active, solved, failed
+	GuessCount  int        `json:"guess_count"`
+	DurationMs  int64      `json:"duration_ms"` // Time from start to the final guess
+	StartedAt   time.Time  `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	// Populated for responses
+	User *User `json:"user,omitempty"`
+}
+
+type MastermindDailyGuess struct {
+	ID          int64     `json:"id"`
+	DailyID     int64     `json:"daily_id"`
+	Guess       string    `json:"-"` // JSON array stored as string
+	Correct     int       `json:"correct"`
+	Misplaced   int       `json:"misplaced"`
+	GuessNumber int       `json:"guess_number"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type MastermindDailyGuessResponse struct {
+	Guess       []int     `json:"guess"`
+	Correct     int       `json:"correct"`
+	Misplaced   int       `json:"misplaced"`
+	GuessNumber int       `json:"guess_number"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type MastermindDailyResponse struct {
+	Date         string                         `json:"date"`
+	CodeLength   int                            `json:"code_length"`
+	NumColors    int                            `json:"num_colors"`
+	MaxGuesses   int                            `json:"max_guesses"`
+	AllowRepeats bool                           `json:"allow_repeats"`
+	Attempt      *MastermindDaily               `json:"attempt,omitempty"` // Nil until started
+	Guesses      []MastermindDailyGuessResponse `json:"guesses"`
+	Secret       []int                          `json:"secret,omitempty"` // Only shown once the attempt is over
+}
+
+type MastermindDailyLeaderboardEntry struct {
+	Rank       int   `json:"rank"`
+	User       *User `json:"user"`
+	Solved     bool  `json:"solved"`
+	GuessCount int   `json:"guess_count"`
+	DurationMs int64 `json:"duration_ms"`
+}
+
+type MastermindDailyLeaderboardResponse struct {
+	Date    string                            `json:"date"`
+	Entries []MastermindDailyLeaderboardEntry `json:"entries"`
+}
@@ -9,7 +9,9 @@ type MemoryGame struct {
 	CurrentTurn  int64     `json:"current_turn"`
 	Status       string    `json:"status"` // active, completed
 	WinnerID     *int64    `json:"winner_id,omitempty"`
-	BoardSize    string    `json:"board_size"` // "4x5", "6x6", "8x8"
+	BoardSize    string    `json:"board_size"` // "RxC", 2 to 10 each way
+	MatchSize    int       `json:"match_size"` // 2 for pairs, 3 for triples
+	Theme        string    `json:"theme"`      // Tile theme name
 	Board        string    `json:"-"`          // JSON 2D array of tile IDs (server only)
 	Matched      string    `json:"-"`          // JSON 2D array of matched booleans
 	Player1Score int       `json:"player1_score"`
@@ -18,28 +20,32 @@ type MemoryGame struct {
 	UpdatedAt    time.Time `json:"updated_at"`
 
 	// Populated for responses
-	Player1 *User `json:"player1,omitempty"`
-	Player2 *User `json:"player2,omitempty"`
+	Player1        *User `json:"player1,omitempty"`
+	Player2        *User `json:"player2,omitempty"`
+	UnreadMessages int   `json:"unread_messages,omitempty"` // Unread chat messages, set on games lists
 }
 
 type MemoryMove struct {
-	ID        int64     `json:"id"`
-	GameID    int64     `json:"game_id"`
-	UserID    int64     `json:"user_id"`
-	Row1      int       `json:"row1"`
-	Col1      int       `json:"col1"`
-	Row2      int       `json:"row2"`
-	Col2      int       `json:"col2"`
-	Tile1     int       `json:"tile1"`
-	Tile2     int       `json:"tile2"`
-	Matched   bool      `json:"matched"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        int64          `json:"id"`
+	GameID    int64          `json:"game_id"`
+	UserID    int64          `json:"user_id"`
+	Row1      int            `json:"row1"`
+	Col1      int            `json:"col1"`
+	Row2      int            `json:"row2"`
+	Col2      int            `json:"col2"`
+	Tile1     int            `json:"tile1"`
+	Tile2     int            `json:"tile2"`
+	Reveals   []MemoryReveal `json:"reveals"` // Every tile turned over, including a third in triples
+	Matched   bool           `json:"matched"`
+	CreatedAt time.Time      `json:"created_at"`
 }
 
 // Request types
 type CreateMemoryGameRequest struct {
 	OpponentID int64  `json:"opponent_id"`
-	BoardSize  string `json:"board_size"` // "4x5", "6x6", "8x8"
+	BoardSize  string `json:"board_size"` // "RxC" up to 10x10 (default "4x5")
+	MatchSize  int    `json:"match_size"` // 2 (default) or 3
+	Theme      string `json:"theme"`      // emoji (default), shapes, numbers
 }
 
 type RevealTilesRequest struct {
@@ -47,14 +53,18 @@ type RevealTilesRequest struct {
 	Col1 int `json:"col1"`
 	Row2 int `json:"row2"`
 	Col2 int `json:"col2"`
+
+	// Positions replaces the fields above and must hold match_size tiles
+	Positions []MemoryPosition `json:"positions,omitempty"`
 }
 
 type RevealTilesResponse struct {
-	Tile1     int  `json:"tile1"`
-	Tile2     int  `json:"tile2"`
-	Matched   bool `json:"matched"`
-	ExtraTurn bool `json:"extra_turn"`
-	GameOver  bool `json:"game_over"`
+	Tile1     int   `json:"tile1"`
+	Tile2     int   `json:"tile2"`
+	Tiles     []int `json:"tiles"` // One per position, in order
+	Matched   bool  `json:"matched"`
+	ExtraTurn bool  `json:"extra_turn"`
+	GameOver  bool  `json:"game_over"`
 }
 
 type MemoryGameResponse struct {
@@ -63,8 +73,9 @@ type MemoryGameResponse struct {
 	FullBoard    [][]int      `json:"full_board,omitempty"` // only sent to current turn player
 	IsYourTurn   bool         `json:"is_your_turn"`
 	Moves        []MemoryMove `json:"moves"`
-	TotalPairs   int          `json:"total_pairs"`
-	MatchedCount int          `json:"matched_count"`
+	TotalPairs   int          `json:"total_pairs"`   // Groups of match_size on the board
+	MatchedCount int          `json:"matched_count"` // Groups matched so far
+	Tiles        []MemoryTile `json:"tiles"`         // Theme metadata for every tile ID on the board
 }
 
 type MemoryGamesListResponse struct {
@@ -72,3 +83,149 @@ type MemoryGamesListResponse struct {
 	TheirTurn []MemoryGame `json:"their_turn"`
 	Completed []MemoryGame `json:"completed"`
 }
+
+type MemoryPosition struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+// MemoryReveal is one tile turned over during a move
+type MemoryReveal struct {
+	Row  int `json:"row"`
+	Col  int `json:"col"`
+	Tile int `json:"tile"`
+}
+
+// MemoryTile describes how to draw a tile ID in a theme
+type MemoryTile struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Symbol string `json:"symbol,omitempty"` // Text or emoji to show
+	Shape  string `json:"shape,omitempty"`  // Shape to draw when there is no symbol
+	Color  string `json:"color,omitempty"`  // Hex colour for the shape
+}
+
+type MemoryTheme struct {
+	Name      string `json:"name"`
+	TileCount int    `json:"tile_count"` // Distinct tiles, i.e. the most groups a board can use
+}
+
+type MemoryThemesResponse struct {
+	Themes []MemoryTheme `json:"themes"`
+}
+
+// MemorySoloGame is a single-player time-attack run. The clock starts at the
+// first reveal and stops when the last group is matched.
+type MemorySoloGame struct {
+	ID          int64      `json:"id"`
+	UserID      int64      `json:"user_id"`
+	Status      string     `json:"status"` // active, completed
+	BoardSize   string     `json:"board_size"`
+	MatchSize   int        `json:"match_size"`
+	Theme       string     `json:"theme"`
+	Board       string     `json:"-"`           // JSON 2D array of tile IDs (server only)
+	Matched     string     `json:"-"`           // JSON 2D array of matched booleans
+	Flips       int        `json:"flips"`       // Reveal turns taken
+	DurationMs  int64      `json:"duration_ms"` // Set on completion
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type MemorySoloMove struct {
+	ID        int64          `json:"id"`
+	GameID    int64          `json:"game_id"`
+	Reveals   []MemoryReveal `json:"reveals"`
+	Matched   bool           `json:"matched"`
+	ElapsedMs int64          `json:"elapsed_ms"` // Server time since the first reveal
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type CreateMemorySoloGameRequest struct {
+	BoardSize string `json:"board_size"` // "RxC" up to 10x10 (default "4x5")
+	MatchSize int    `json:"match_size"` // 2 (default) or 3
+	Theme     string `json:"theme"`      // emoji (default), shapes, numbers
+}
+
+type MemorySoloRevealResponse struct {
+	Tiles     []int `json:"tiles"` // One per position, in order
+	Matched   bool  `json:"matched"`
+	GameOver  bool  `json:"game_over"`
+	Flips     int   `json:"flips"`
+	ElapsedMs int64 `json:"elapsed_ms"`
+}
+
+type MemorySoloGameResponse struct {
+	Game         *MemorySoloGame  `json:"game"`
+	Board        [][]int          `json:"board"` // Matched tiles only; the rest are -1
+	Moves        []MemorySoloMove `json:"moves"`
+	TotalPairs   int              `json:"total_pairs"`   // Groups of match_size on the board
+	MatchedCount int              `json:"matched_count"` // Groups matched so far
+	Tiles        []MemoryTile     `json:"tiles"`
+}
+
+type MemorySoloGamesListResponse struct {
+	Active    []MemorySoloGame `json:"active"`
+	Completed []MemorySoloGame `json:"completed"`
+}
+
+// MemorySoloBest is a player's best results for one board configuration
+type MemorySoloBest struct {
+	BoardSize  string `json:"board_size"`
+	MatchSize  int    `json:"match_size"`
+	BestTimeMs int64  `json:"best_time_ms"`
+	BestFlips  int    `json:"best_flips"`
+	Completed  int    `json:"completed"` // Runs finished on this configuration
+}
+
+type MemorySoloBestsResponse struct {
+	Bests []MemorySoloBest `json:"bests"`
+}
+
+type MemorySoloLeaderboardEntry struct {
+	Rank        int       `json:"rank"`
+	User        *User     `json:"user"`
+	GameID      int64     `json:"game_id"`
+	DurationMs  int64     `json:"duration_ms"`
+	Flips       int       `json:"flips"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+type MemorySoloLeaderboardResponse struct {
+	BoardSize string                       `json:"board_size"`
+	MatchSize int                          `json:"match_size"`
+	Entries   []MemorySoloLeaderboardEntry `json:"entries"`
+}
+
+// MemoryReplayStep is the state of a game right after one move
+type MemoryReplayStep struct {
+	MoveNumber   int            `json:"move_number"`
+	UserID       int64          `json:"user_id"`
+	Reveals      []MemoryReveal `json:"reveals"`
+	Matched      bool           `json:"matched"`
+	MissedMatch  bool           `json:"missed_match"` // The first tile's partners were all known, yet no match
+	Player1Score int            `json:"player1_score"`
+	Player2Score int            `json:"player2_score"`
+	MatchedCount int            `json:"matched_count"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+// MemoryAccuracy measures how well a player used tiles already seen. An
+// opportunity is a move whose first tile had every partner revealed earlier.
+type MemoryAccuracy struct {
+	UserID        int64   `json:"user_id"`
+	Moves         int     `json:"moves"`
+	Matches       int     `json:"matches"`
+	Opportunities int     `json:"opportunities"`
+	Missed        int     `json:"missed"`
+	Accuracy      float64 `json:"accuracy"` // 1 - missed/opportunities; 1 with no opportunities
+}
+
+type MemoryReplayResponse struct {
+	Game     *MemoryGame        `json:"game"`
+	Board    [][]int            `json:"board"` // Full layout
+	Tiles    []MemoryTile       `json:"tiles"`
+	Steps    []MemoryReplayStep `json:"steps"`
+	Accuracy []MemoryAccuracy   `json:"accuracy"` // Player 1 then player 2
+}
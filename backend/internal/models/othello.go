@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+type OthelloGame struct {
+	ID           int64     `json:"id"`
+	Player1ID    int64     `json:"player1_id"` // Black, moves first
+	Player2ID    int64     `json:"player2_id"` // White
+	CurrentTurn  int64     `json:"current_turn"`
+	Status       string    `json:"status"` // active, completed
+	WinnerID     *int64    `json:"winner_id,omitempty"`
+	Board        string    `json:"-"`             // JSON 8x8 array: 0 empty, 1 black, 2 white
+	Player1Score int       `json:"player1_score"` // Discs on the board
+	Player2Score int       `json:"player2_score"`
+	MoveCount    int       `json:"move_count"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	// Populated for responses
+	Player1        *User `json:"player1,omitempty"`
+	Player2        *User `json:"player2,omitempty"`
+	UnreadMessages int   `json:"unread_messages,omitempty"` // Unread chat messages, set on games lists
+}
+
+type OthelloSquare struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+type OthelloMove struct {
+	ID         int64     `json:"id"`
+	GameID     int64     `json:"game_id"`
+	UserID     int64     `json:"user_id"`
+	MoveNumber int       `json:"move_number"`
+	Passed     bool      `json:"passed"` // No legal move, so the turn passed automatically
+	Row        int       `json:"row"`    // -1 for a pass
+	Col        int       `json:"col"`
+	Flipped    int       `json:"flipped"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Request types
+type CreateOthelloGameRequest struct {
+	OpponentID int64 `json:"opponent_id"`
+}
+
+type OthelloMoveRequest struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+// Response types
+type OthelloGameResponse struct {
+	Game       *OthelloGame    `json:"game"`
+	Board      [][]int         `json:"board"`
+	YourPiece  int             `json:"your_piece"` // 1 black, 2 white
+	IsYourTurn bool            `json:"is_your_turn"`
+	LegalMoves []OthelloSquare `json:"legal_moves"`       // For the player to move
+	Flipped    []OthelloSquare `json:"flipped,omitempty"` // Discs turned over by the move just made
+	Moves      []OthelloMove   `json:"moves"`
+}
+
+type OthelloGamesListResponse struct {
+	YourTurn  []OthelloGame `json:"your_turn"`
+	TheirTurn []OthelloGame `json:"their_turn"`
+	Completed []OthelloGame `json:"completed"`
+}
@@ -0,0 +1,33 @@
+package models
+
+// GameSummary is the part of a game common to every game type
+type GameSummary struct {
+	ID        int64  `json:"id"`
+	Player1ID int64  `json:"player1_id"`
+	Player2ID int64  `json:"player2_id"`
+	Status    string `json:"status"`
+	WinnerID  *int64 `json:"winner_id,omitempty"`
+
+	// Player 1 then player 2, for games scored in points
+	Scores []float64 `json:"scores,omitempty"`
+}
+
+type SeriesScore struct {
+	UserID int64 `json:"user_id"`
+	Wins   int   `json:"wins"`
+}
+
+// GameSeries is a chain of games linked by rematches, oldest first
+type GameSeries struct {
+	GameType string        `json:"game_type"`
+	Games    []GameSummary `json:"games"`
+	Scores   []SeriesScore `json:"scores"` // The series' first mover, then the other player
+	Draws    int           `json:"draws"`
+}
+
+type RematchResponse struct {
+	GameType       string      `json:"game_type"`
+	GameID         int64       `json:"game_id"`
+	PreviousGameID int64       `json:"previous_game_id"`
+	Series         *GameSeries `json:"series"`
+}
@@ -18,9 +18,10 @@ type ScrabbleGame struct {
 	UpdatedAt        time.Time `json:"updated_at"`
 
 	// Populated for responses
-	Player1  *User    `json:"player1,omitempty"`
-	Player2  *User    `json:"player2,omitempty"`
-	Board    [][]Tile `json:"board,omitempty"`
+	Player1        *User    `json:"player1,omitempty"`
+	Player2        *User    `json:"player2,omitempty"`
+	UnreadMessages int      `json:"unread_messages,omitempty"` // Unread chat messages, set on games lists
+	Board          [][]Tile `json:"board,omitempty"`
 }
 
 type ScrabbleRack struct {
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// WatchableGame is a game one or both players have opened to their friends
+type WatchableGame struct {
+	GameType         string    `json:"game_type"`
+	GameID           int64     `json:"game_id"`
+	Player1ID        int64     `json:"player1_id"`
+	Player2ID        int64     `json:"player2_id"`
+	Player1Watchable bool      `json:"player1_watchable"` // Open to player 1's friends
+	Player2Watchable bool      `json:"player2_watchable"` // Open to player 2's friends
+	CreatedAt        time.Time `json:"created_at"`
+
+	// Populated for responses
+	Status  string `json:"status,omitempty"`
+	Player1 *User  `json:"player1,omitempty"`
+	Player2 *User  `json:"player2,omitempty"`
+}
+
+type SetWatchableRequest struct {
+	Watchable bool `json:"watchable"`
+}
+
+type WatchableResponse struct {
+	Watchable         bool `json:"watchable"`          // Open to your friends
+	OpponentWatchable bool `json:"opponent_watchable"` // Open to your opponent's friends
+}
+
+type WatchableGamesResponse struct {
+	Games []WatchableGame `json:"games"`
+}
+
+// SpectatorViewResponse wraps a game's usual response, built from player 1's
+// side with nothing to play and hidden information filtered out
+type SpectatorViewResponse struct {
+	GameType string `json:"game_type"`
+	View     any    `json:"view"`
+}
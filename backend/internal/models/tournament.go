@@ -0,0 +1,97 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Tournament is a round-robin or single-elimination event among friends.
+// Games are created for each match as its round comes up.
+type Tournament struct {
+	ID           int64           `json:"id"`
+	OrganizerID  int64           `json:"organizer_id"`
+	Name         string          `json:"name"`
+	GameType     string          `json:"game_type"` // scrabble, chess, ...
+	Format       string          `json:"format"`    // round_robin, single_elimination
+	Settings     json.RawMessage `json:"settings"`  // Variant settings for every game, as for the game's create request
+	Status       string          `json:"status"`    // active, completed
+	CurrentRound int             `json:"current_round"`
+	TotalRounds  int             `json:"total_rounds"`
+	WinnerID     *int64          `json:"winner_id,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+
+	// Populated for responses
+	Organizer    *User                   `json:"organizer,omitempty"`
+	Participants []TournamentParticipant `json:"participants,omitempty"`
+}
+
+type TournamentParticipant struct {
+	UserID int64 `json:"user_id"`
+	Seed   int   `json:"seed"` // 1 is the top seed
+	User   *User `json:"user,omitempty"`
+}
+
+// TournamentMatch pairs two participants in a round. Elimination matches in
+// later rounds have no players until the matches feeding them are decided.
+type TournamentMatch struct {
+	ID           int64     `json:"id"`
+	TournamentID int64     `json:"tournament_id"`
+	Round        int       `json:"round"`
+	Position     int       `json:"position"`             // Order within the round; bracket slot for elimination
+	Player1ID    *int64    `json:"player1_id,omitempty"` // Moves first
+	Player2ID    *int64    `json:"player2_id,omitempty"`
+	GameID       *int64    `json:"game_id,omitempty"`
+	Status       string    `json:"status"` // pending, creating, active, completed, bye
+	WinnerID     *int64    `json:"winner_id,omitempty"`
+	Scores       []float64 `json:"scores,omitempty"` // Player 1 then player 2, for games scored in points
+	Replays      int       `json:"replays"`          // Drawn elimination games that were played again
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TournamentStanding is one participant's record. Ties on points are broken
+// by spread (points scored minus points conceded) in games scored in points.
+type TournamentStanding struct {
+	Rank         int     `json:"rank"`
+	UserID       int64   `json:"user_id"`
+	Seed         int     `json:"seed"`
+	Played       int     `json:"played"`
+	Wins         int     `json:"wins"`
+	Draws        int     `json:"draws"`
+	Losses       int     `json:"losses"`
+	Points       float64 `json:"points"` // 1 per win, 0.5 per draw
+	Spread       float64 `json:"spread"`
+	EliminatedIn int     `json:"eliminated_in,omitempty"` // Round knocked out in, single elimination only
+	User         *User   `json:"user,omitempty"`
+}
+
+type TournamentRound struct {
+	Round   int               `json:"round"`
+	Name    string            `json:"name"` // Round 1, Quarterfinals, Final, ...
+	Matches []TournamentMatch `json:"matches"`
+}
+
+// Request types
+type CreateTournamentRequest struct {
+	Name           string          `json:"name"`
+	GameType       string          `json:"game_type"`
+	Format         string          `json:"format"`             // round_robin or single_elimination
+	Settings       json.RawMessage `json:"settings,omitempty"` // e.g. {"board_size": "8x7"}
+	ParticipantIDs []int64         `json:"participant_ids"`    // In seed order; may include the organizer
+}
+
+// Response types
+type TournamentsResponse struct {
+	Tournaments []Tournament `json:"tournaments"`
+}
+
+type TournamentStandingsResponse struct {
+	Tournament *Tournament          `json:"tournament"`
+	Standings  []TournamentStanding `json:"standings"`
+}
+
+type TournamentBracketResponse struct {
+	Tournament *Tournament       `json:"tournament"`
+	Rounds     []TournamentRound `json:"rounds"`
+}
@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+type WordmindGame struct {
+	ID          int64     `json:"id"`
+	Player1ID   int64     `json:"player1_id"`
+	Player2ID   int64     `json:"player2_id"`
+	CurrentTurn int64     `json:"current_turn"`
+	Status      string    `json:"status"` // setup, active, completed
+	WinnerID    *int64    `json:"winner_id,omitempty"`
+	MaxGuesses  int       `json:"max_guesses"` // 1 to 10
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Populated for responses
+	Player1        *User `json:"player1,omitempty"`
+	Player2        *User `json:"player2,omitempty"`
+	UnreadMessages int   `json:"unread_messages,omitempty"` // Unread chat messages, set on games lists
+}
+
+type WordmindGuess struct {
+	ID          int64     `json:"id"`
+	GameID      int64     `json:"game_id"`
+	UserID      int64     `json:"user_id"` // Guesser
+	Word        string    `json:"word"`
+	Feedback    string    `json:"-"` // JSON array stored as string
+	Correct     int       `json:"correct"`
+	GuessNumber int       `json:"guess_number"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// WordmindGuessResponse is the response format with parsed feedback
+type WordmindGuessResponse struct {
+	ID          int64     `json:"id"`
+	GameID      int64     `json:"game_id"`
+	UserID      int64     `json:"user_id"`
+	Word        string    `json:"word"`
+	Feedback    []string  `json:"feedback"` // correct, present, or absent per letter
+	Correct     int       `json:"correct"`
+	GuessNumber int       `json:"guess_number"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Request types
+type CreateWordmindGameRequest struct {
+	OpponentID int64 `json:"opponent_id"`
+	MaxGuesses int   `json:"max_guesses"` // 1 to 10 (default 6)
+}
+
+type SetWordmindSecretRequest struct {
+	Word string `json:"word"` // five-letter dictionary word
+}
+
+type MakeWordmindGuessRequest struct {
+	Word string `json:"word"` // five-letter dictionary word
+}
+
+// Response types
+type WordmindGameResponse struct {
+	Game           *WordmindGame           `json:"game"`
+	MySecret       string                  `json:"my_secret,omitempty"`       // Only show for self
+	OpponentSecret string                  `json:"opponent_secret,omitempty"` // Only show after game ends
+	MyGuesses      []WordmindGuessResponse `json:"my_guesses"`                // Guesses I made
+	TheirGuesses   []WordmindGuessResponse `json:"their_guesses"`             // Guesses opponent made
+	SecretSet      bool                    `json:"secret_set"`                // Have I set my secret?
+	IsYourTurn     bool                    `json:"is_your_turn"`
+	Phase          string                  `json:"phase"` // setup, active, completed
+	Round          int                     `json:"round"` // Current round number
+}
+
+type WordmindGamesListResponse struct {
+	YourTurn  []WordmindGame `json:"your_turn"`
+	TheirTurn []WordmindGame `json:"their_turn"`
+	Completed []WordmindGame `json:"completed"`
+}
@@ -0,0 +1,95 @@
+package models
+
+import "time"
+
+type YahtzeeGame struct {
+	ID               int64     `json:"id"`
+	Player1ID        int64     `json:"player1_id"` // Moves first
+	Player2ID        int64     `json:"player2_id"`
+	CurrentTurn      int64     `json:"current_turn"`
+	Status           string    `json:"status"` // active, completed
+	WinnerID         *int64    `json:"winner_id,omitempty"`
+	TurnNumber       int       `json:"turn_number"`
+	Dice             string    `json:"-"` // JSON dice of the current turn; empty before the first roll
+	RollsUsed        int       `json:"rolls_used"`
+	Player1Scorecard string    `json:"-"` // JSON YahtzeeScorecard
+	Player2Scorecard string    `json:"-"`
+	Player1Score     int       `json:"player1_score"`
+	Player2Score     int       `json:"player2_score"`
+	MoveCount        int       `json:"move_count"` // rolls and entries made
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+
+	// Populated for responses
+	Player1        *User `json:"player1,omitempty"`
+	Player2        *User `json:"player2,omitempty"`
+	UnreadMessages int   `json:"unread_messages,omitempty"` // Unread chat messages, set on games lists
+}
+
+type YahtzeeScorecard struct {
+	Scores       map[string]int `json:"scores"` // Filled boxes only
+	UpperTotal   int            `json:"upper_total"`
+	UpperBonus   int            `json:"upper_bonus"`   // 35 once the upper section reaches 63
+	YahtzeeBonus int            `json:"yahtzee_bonus"` // 100 per extra Yahtzee
+	Total        int            `json:"total"`
+}
+
+// YahtzeeRoll is one roll of the dice, kept for auditing
+type YahtzeeRoll struct {
+	ID         int64     `json:"id"`
+	GameID     int64     `json:"game_id"`
+	UserID     int64     `json:"user_id"`
+	TurnNumber int       `json:"turn_number"`
+	RollNumber int       `json:"roll_number"` // 1 to 3 within the turn
+	Held       []bool    `json:"held"`
+	Dice       []int     `json:"dice"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// YahtzeeEntry is a box filled on a scorecard
+type YahtzeeEntry struct {
+	ID         int64     `json:"id"`
+	GameID     int64     `json:"game_id"`
+	UserID     int64     `json:"user_id"`
+	TurnNumber int       `json:"turn_number"`
+	Category   string    `json:"category"`
+	Dice       []int     `json:"dice"`
+	Points     int       `json:"points"`
+	Bonus      int       `json:"bonus"` // Yahtzee bonus earned by this entry
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Request types
+type CreateYahtzeeGameRequest struct {
+	OpponentID int64 `json:"opponent_id"`
+}
+
+type YahtzeeRollRequest struct {
+	Held []bool `json:"held"` // One per die; ignored on the first roll of a turn
+}
+
+type YahtzeeScoreRequest struct {
+	Category string `json:"category"`
+}
+
+// Response types
+type YahtzeeGameResponse struct {
+	Game              *YahtzeeGame      `json:"game"`
+	Dice              []int             `json:"dice"` // Empty before the first roll of a turn
+	RollsLeft         int               `json:"rolls_left"`
+	IsYourTurn        bool              `json:"is_your_turn"`
+	YourScorecard     *YahtzeeScorecard `json:"your_scorecard"`
+	OpponentScorecard *YahtzeeScorecard `json:"opponent_scorecard"`
+	Options           map[string]int    `json:"options,omitempty"` // Boxes the current dice may go in, with points
+}
+
+type YahtzeeHistoryResponse struct {
+	Rolls   []YahtzeeRoll  `json:"rolls"`
+	Entries []YahtzeeEntry `json:"entries"`
+}
+
+type YahtzeeGamesListResponse struct {
+	YourTurn  []YahtzeeGame `json:"your_turn"`
+	TheirTurn []YahtzeeGame `json:"their_turn"`
+	Completed []YahtzeeGame `json:"completed"`
+}
@@ -0,0 +1,128 @@
+package othello
+
+import (
+	"encoding/json"
+	"errors"
+
+	"altech/internal/models"
+)
+
+const BoardSize = 8
+
+// Square contents; Black is player 1 and moves first
+const (
+	Empty = 0
+	Black = 1
+	White = 2
+)
+
+var directions = [][2]int{{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}}
+
+// NewBoard sets up the four centre discs
+func NewBoard() [][]int {
+	board := make([][]int, BoardSize)
+	for r := range board {
+		board[r] = make([]int, BoardSize)
+	}
+	board[3][3], board[4][4] = White, White
+	board[3][4], board[4][3] = Black, Black
+	return board
+}
+
+// Opponent returns the other colour
+func Opponent(piece int) int {
+	if piece == Black {
+		return White
+	}
+	return Black
+}
+
+func inBounds(row, col int) bool {
+	return row >= 0 && row < BoardSize && col >= 0 && col < BoardSize
+}
+
+// Flips lists the discs piece would turn over by playing at row, col. An
+// empty result means the move is illegal.
+func Flips(board [][]int, row, col, piece int) []models.OthelloSquare {
+	if !inBounds(row, col) || board[row][col] != Empty {
+		return nil
+	}
+
+	other := Opponent(piece)
+	var flips []models.OthelloSquare
+	for _, d := range directions {
+		var line []models.OthelloSquare
+		r, c := row+d[0], col+d[1]
+		for inBounds(r, c) && board[r][c] == other {
+			line = append(line, models.OthelloSquare{Row: r, Col: c})
+			r, c = r+d[0], c+d[1]
+		}
+		// The run must be closed off by one of our own discs
+		if len(line) > 0 && inBounds(r, c) && board[r][c] == piece {
+			flips = append(flips, line...)
+		}
+	}
+	return flips
+}
+
+// LegalMoves lists every square where piece can play
+func LegalMoves(board [][]int, piece int) []models.OthelloSquare {
+	moves := []models.OthelloSquare{}
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			if len(Flips(board, r, c, piece)) > 0 {
+				moves = append(moves, models.OthelloSquare{Row: r, Col: c})
+			}
+		}
+	}
+	return moves
+}
+
+// Play places a disc and turns over every flanked disc, returning them
+func Play(board [][]int, row, col, piece int) ([]models.OthelloSquare, error) {
+	if !inBounds(row, col) {
+		return nil, errors.New("square out of bounds")
+	}
+	if board[row][col] != Empty {
+		return nil, errors.New("square is already taken")
+	}
+
+	flips := Flips(board, row, col, piece)
+	if len(flips) == 0 {
+		return nil, errors.New("move must flip at least one disc")
+	}
+
+	board[row][col] = piece
+	for _, sq := range flips {
+		board[sq.Row][sq.Col] = piece
+	}
+	return flips, nil
+}
+
+// Count returns how many discs each colour has
+func Count(board [][]int) (black, white int) {
+	for _, row := range board {
+		for _, sq := range row {
+			switch sq {
+			case Black:
+				black++
+			case White:
+				white++
+			}
+		}
+	}
+	return black, white
+}
+
+// JSON helpers
+
+func BoardToJSON(board [][]int) (string, error) {
+	data, err := json.Marshal(board)
+	return string(data), err
+}
+
+func BoardFromJSON(data string) ([][]int, error) {
+	var board [][]int
+	err := json.Unmarshal([]byte(data), &board)
+	return board, err
+}
@@ -38,3 +38,11 @@ func GetDictionarySize() int {
 	dictOnce.Do(loadDictionary)
 	return len(dictionary)
 }
+
+// EachWord calls fn for every word in the dictionary, in no particular order
+func EachWord(fn func(word string)) {
+	dictOnce.Do(loadDictionary)
+	for word := range dictionary {
+		fn(word)
+	}
+}
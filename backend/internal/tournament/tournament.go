@@ -0,0 +1,313 @@
+package tournament
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"altech/internal/models"
+)
+
+// Formats
+const (
+	RoundRobin        = "round_robin"
+	SingleElimination = "single_elimination"
+)
+
+const (
+	MinParticipants = 3
+	MaxParticipants = 16
+	MaxNameLength   = 100
+
+	WinPoints  = 1.0
+	DrawPoints = 0.5
+)
+
+func ValidateFormat(format string) error {
+	if format != RoundRobin && format != SingleElimination {
+		return errors.New("format must be 'round_robin' or 'single_elimination'")
+	}
+	return nil
+}
+
+func ValidateParticipants(ids []int64) error {
+	if len(ids) < MinParticipants || len(ids) > MaxParticipants {
+		return fmt.Errorf("a tournament needs between %d and %d participants", MinParticipants, MaxParticipants)
+	}
+	seen := make(map[int64]bool)
+	for _, id := range ids {
+		if seen[id] {
+			return errors.New("participants must not repeat")
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// Rounds returns how many rounds a tournament of n participants has
+func Rounds(format string, n int) int {
+	if format == RoundRobin {
+		if n%2 == 1 {
+			return n
+		}
+		return n - 1
+	}
+	rounds := 0
+	for size := 1; size < n; size *= 2 {
+		rounds++
+	}
+	return rounds
+}
+
+// Matches builds every match of a tournament from participant IDs in seed
+// order
+func Matches(format string, ids []int64) []models.TournamentMatch {
+	if format == RoundRobin {
+		return roundRobinMatches(ids)
+	}
+	return eliminationMatches(ids)
+}
+
+// roundRobinMatches pairs everyone with everyone using the circle method:
+// the first player stays put while the rest rotate. With an odd number of
+// players one sits out each round. Who moves first alternates.
+func roundRobinMatches(ids []int64) []models.TournamentMatch {
+	circle := append([]int64{}, ids...)
+	if len(circle)%2 == 1 {
+		circle = append(circle, 0) // 0 is the bye
+	}
+	n := len(circle)
+
+	var matches []models.TournamentMatch
+	for round := 1; round < n; round++ {
+		position := 0
+		for i := 0; i < n/2; i++ {
+			a, b := circle[i], circle[n-1-i]
+			if a == 0 || b == 0 {
+				continue
+			}
+			if (i == 0 && round%2 == 0) || (i > 0 && i%2 == 1) {
+				a, b = b, a
+			}
+			position++
+			matches = append(matches, models.TournamentMatch{
+				Round:     round,
+				Position:  position,
+				Player1ID: &a,
+				Player2ID: &b,
+				Status:    "pending",
+			})
+		}
+
+		// Keep the first player, rotate the rest by one
+		circle = append([]int64{circle[0], circle[n-1]}, circle[1:n-1]...)
+	}
+	return matches
+}
+
+// SeedOrder returns bracket slots for a bracket of size players, a power of
+// two, so the top seeds can only meet in the last rounds: 1 v 8, 4 v 5,
+// 2 v 7, 3 v 6 for eight.
+func SeedOrder(size int) []int {
+	order := []int{1}
+	for len(order) < size {
+		next := make([]int, 0, len(order)*2)
+		for _, seed := range order {
+			next = append(next, seed, len(order)*2+1-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// eliminationMatches lays out the whole bracket. Top seeds get byes when the
+// field is not a power of two, and go straight into round two.
+func eliminationMatches(ids []int64) []models.TournamentMatch {
+	rounds := Rounds(SingleElimination, len(ids))
+	size := 1 << rounds
+	order := SeedOrder(size)
+
+	var matches []models.TournamentMatch
+	for round := 1; round <= rounds; round++ {
+		for position := 1; position <= size>>round; position++ {
+			matches = append(matches, models.TournamentMatch{
+				Round:    round,
+				Position: position,
+				Status:   "pending",
+			})
+		}
+	}
+
+	for i := 0; i < size/2; i++ {
+		m := &matches[i]
+		high, low := order[2*i], order[2*i+1]
+		player1 := ids[high-1]
+		m.Player1ID = &player1
+		if low <= len(ids) {
+			player2 := ids[low-1]
+			m.Player2ID = &player2
+			continue
+		}
+		m.Status = "bye"
+		m.WinnerID = &player1
+		round, position := NextMatch(m.Round, m.Position)
+		Advance(&matches[matchIndex(size, round, position)], m.Position, player1)
+	}
+	return matches
+}
+
+// matchIndex finds a match in the slice built by eliminationMatches
+func matchIndex(size, round, position int) int {
+	index := 0
+	for r := 1; r < round; r++ {
+		index += size >> r
+	}
+	return index + position - 1
+}
+
+// NextMatch returns the round and position the winner of an elimination
+// match goes on to
+func NextMatch(round, position int) (int, int) {
+	return round + 1, (position + 1) / 2
+}
+
+// AdvanceSlot returns which player, 1 or 2, the winner of the match at
+// fromPosition becomes in the next match. Odd positions feed player 1.
+func AdvanceSlot(fromPosition int) int {
+	if fromPosition%2 == 1 {
+		return 1
+	}
+	return 2
+}
+
+// Advance seats the winner of the match at fromPosition in the next match
+func Advance(next *models.TournamentMatch, fromPosition int, winnerID int64) {
+	if AdvanceSlot(fromPosition) == 1 {
+		next.Player1ID = &winnerID
+	} else {
+		next.Player2ID = &winnerID
+	}
+}
+
+// RoundName labels a round for the bracket
+func RoundName(format string, round, rounds int) string {
+	if format == SingleElimination {
+		switch rounds - round {
+		case 0:
+			return "Final"
+		case 1:
+			return "Semifinals"
+		case 2:
+			return "Quarterfinals"
+		}
+	}
+	return fmt.Sprintf("Round %d", round)
+}
+
+// CurrentRound returns the earliest round with a match still to be decided,
+// or 0 once every match is
+func CurrentRound(matches []models.TournamentMatch) int {
+	current := 0
+	for _, m := range matches {
+		if m.Status == "completed" || m.Status == "bye" {
+			continue
+		}
+		if current == 0 || m.Round < current {
+			current = m.Round
+		}
+	}
+	return current
+}
+
+// Standings ranks participants. Round robin ranks by points, then spread,
+// then wins. Single elimination ranks by how far each got, then the same.
+// Seed settles anything left.
+func Standings(format string, participants []models.TournamentParticipant, matches []models.TournamentMatch) []models.TournamentStanding {
+	standings := make([]models.TournamentStanding, len(participants))
+	index := make(map[int64]int)
+	for i, p := range participants {
+		standings[i] = models.TournamentStanding{UserID: p.UserID, Seed: p.Seed, User: p.User}
+		index[p.UserID] = i
+	}
+
+	for _, m := range matches {
+		if m.Status != "completed" || m.Player1ID == nil || m.Player2ID == nil {
+			continue
+		}
+		p1, ok1 := index[*m.Player1ID]
+		p2, ok2 := index[*m.Player2ID]
+		if !ok1 || !ok2 {
+			continue
+		}
+		s1, s2 := &standings[p1], &standings[p2]
+		s1.Played++
+		s2.Played++
+
+		if m.WinnerID == nil {
+			s1.Draws++
+			s2.Draws++
+			s1.Points += DrawPoints
+			s2.Points += DrawPoints
+		} else {
+			winner, loser := s1, s2
+			if *m.WinnerID == *m.Player2ID {
+				winner, loser = s2, s1
+			}
+			winner.Wins++
+			winner.Points += WinPoints
+			loser.Losses++
+			if format == SingleElimination {
+				loser.EliminatedIn = m.Round
+			}
+		}
+
+		if len(m.Scores) == 2 {
+			s1.Spread += m.Scores[0] - m.Scores[1]
+			s2.Spread += m.Scores[1] - m.Scores[0]
+		}
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if format == SingleElimination && a.EliminatedIn != b.EliminatedIn {
+			// Still in (0) ranks above everyone knocked out
+			if a.EliminatedIn == 0 || b.EliminatedIn == 0 {
+				return a.EliminatedIn == 0
+			}
+			return a.EliminatedIn > b.EliminatedIn
+		}
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.Spread != b.Spread {
+			return a.Spread > b.Spread
+		}
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+		return a.Seed < b.Seed
+	})
+
+	for i := range standings {
+		standings[i].Rank = i + 1
+	}
+	return standings
+}
+
+// Winner returns the champion once every match is decided: the top of the
+// standings for round robin, the winner of the final for elimination
+func Winner(format string, participants []models.TournamentParticipant, matches []models.TournamentMatch) *int64 {
+	if format == SingleElimination {
+		for _, m := range matches {
+			if m.Round == Rounds(SingleElimination, len(participants)) {
+				return m.WinnerID
+			}
+		}
+		return nil
+	}
+	standings := Standings(format, participants, matches)
+	if len(standings) == 0 {
+		return nil
+	}
+	return &standings[0].UserID
+}
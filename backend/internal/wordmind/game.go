@@ -0,0 +1,118 @@
+package wordmind
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"altech/internal/mastermind"
+	"altech/internal/scrabble"
+)
+
+const (
+	WordLength    = 5
+	MaxGuesses    = 6 // default guess limit
+	MaxGuessLimit = 10
+)
+
+// Per-letter feedback
+const (
+	Correct = "correct" // right letter, right position
+	Present = "present" // in the word, but elsewhere
+	Absent  = "absent"  // not in the word (or every copy already accounted for)
+)
+
+// NormalizeWord trims and upper-cases a word the way the dictionary stores it
+func NormalizeWord(word string) string {
+	return strings.ToUpper(strings.TrimSpace(word))
+}
+
+// ValidateWord checks that a normalized word is a five-letter dictionary word
+func ValidateWord(word string) error {
+	if len(word) != WordLength {
+		return errors.New("word must have exactly 5 letters")
+	}
+	for _, c := range word {
+		if c < 'A' || c > 'Z' {
+			return errors.New("word must only contain letters")
+		}
+	}
+	if !scrabble.IsValidWord(word) {
+		return errors.New("not a valid word: " + word)
+	}
+	return nil
+}
+
+// ValidateMaxGuesses ensures max_guesses is valid (1 to 10)
+func ValidateMaxGuesses(n int) int {
+	if n >= 1 && n <= MaxGuessLimit {
+		return n
+	}
+	return MaxGuesses
+}
+
+// EvaluateGuess returns per-letter feedback for guess against secret. Exact
+// matches are marked first; a repeated letter is only marked present while
+// the secret still has an unmatched copy of it, so guessing SPEED against
+// ABIDE marks the first E present and the second absent.
+func EvaluateGuess(secret, guess string) []string {
+	feedback := make([]string, len(guess))
+	remaining := make(map[byte]int)
+
+	for i := 0; i < len(guess); i++ {
+		if guess[i] == secret[i] {
+			feedback[i] = Correct
+		} else {
+			remaining[secret[i]]++
+		}
+	}
+
+	for i := 0; i < len(guess); i++ {
+		if feedback[i] == Correct {
+			continue
+		}
+		if remaining[guess[i]] > 0 {
+			feedback[i] = Present
+			remaining[guess[i]]--
+		} else {
+			feedback[i] = Absent
+		}
+	}
+
+	return feedback
+}
+
+// CountCorrect returns how many letters are in the right position
+func CountCorrect(feedback []string) int {
+	count := 0
+	for _, f := range feedback {
+		if f == Correct {
+			count++
+		}
+	}
+	return count
+}
+
+// CheckWinCondition applies Mastermind's head-to-head rules, treating each
+// correctly placed letter like a correct peg
+func CheckWinCondition(
+	player1ID, player2ID int64,
+	p1Guesses, p2Guesses []mastermind.GuessResult,
+	maxGuesses int,
+) (gameOver bool, winnerID *int64) {
+	return mastermind.CheckWinCondition(player1ID, player2ID, p1Guesses, p2Guesses, nil, nil, maxGuesses, WordLength)
+}
+
+func FeedbackToJSON(feedback []string) (string, error) {
+	data, err := json.Marshal(feedback)
+	return string(data), err
+}
+
+func FeedbackFromJSON(data string) ([]string, error) {
+	var feedback []string
+	if data == "" || data == "[]" {
+		return feedback, nil
+	}
+	err := json.Unmarshal([]byte(data), &feedback)
+	return feedback, err
+}
@@ -0,0 +1,313 @@
+package yahtzee
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"altech/internal/models"
+)
+
+const (
+	NumDice      = 5
+	RollsPerTurn = 3
+
+	UpperBonusThreshold = 63
+	UpperBonus          = 35
+	YahtzeeScore        = 50
+	YahtzeeBonus        = 100
+)
+
+// Scorecard categories
+const (
+	Ones          = "ones"
+	Twos          = "twos"
+	Threes        = "threes"
+	Fours         = "fours"
+	Fives         = "fives"
+	Sixes         = "sixes"
+	ThreeOfAKind  = "three_of_a_kind"
+	FourOfAKind   = "four_of_a_kind"
+	FullHouse     = "full_house"
+	SmallStraight = "small_straight"
+	LargeStraight = "large_straight"
+	Yahtzee       = "yahtzee"
+	Chance        = "chance"
+)
+
+// Categories lists every scorecard box in order
+var Categories = []string{
+	Ones, Twos, Threes, Fours, Fives, Sixes,
+	ThreeOfAKind, FourOfAKind, FullHouse, SmallStraight, LargeStraight, Yahtzee, Chance,
+}
+
+// upperFace maps an upper-section box to the face it counts
+var upperFace = map[string]int{Ones: 1, Twos: 2, Threes: 3, Fours: 4, Fives: 5, Sixes: 6}
+
+// Roll rerolls every die that is not held. Each die is drawn from the
+// system's secure random source, so no roll can be predicted; the rolls
+// stored with the game are the record of what was thrown.
+func Roll(dice []int, held []bool) ([]int, error) {
+	next := make([]int, NumDice)
+	for i := range next {
+		if i < len(held) && held[i] && i < len(dice) && dice[i] > 0 {
+			next[i] = dice[i]
+			continue
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(6))
+		if err != nil {
+			return nil, err
+		}
+		next[i] = int(n.Int64()) + 1
+	}
+	return next, nil
+}
+
+func counts(dice []int) [7]int {
+	var c [7]int
+	for _, d := range dice {
+		c[d]++
+	}
+	return c
+}
+
+func sum(dice []int) int {
+	total := 0
+	for _, d := range dice {
+		total += d
+	}
+	return total
+}
+
+// IsYahtzee reports whether all five dice show the same face
+func IsYahtzee(dice []int) bool {
+	c := counts(dice)
+	for face := 1; face <= 6; face++ {
+		if c[face] == NumDice {
+			return true
+		}
+	}
+	return false
+}
+
+func longestRun(dice []int) int {
+	c := counts(dice)
+	best, run := 0, 0
+	for face := 1; face <= 6; face++ {
+		if c[face] > 0 {
+			run++
+			if run > best {
+				best = run
+			}
+		} else {
+			run = 0
+		}
+	}
+	return best
+}
+
+// CategoryScore is what dice are worth in a category under the normal rules
+func CategoryScore(category string, dice []int) int {
+	c := counts(dice)
+	if face, ok := upperFace[category]; ok {
+		return face * c[face]
+	}
+
+	maxCount := 0
+	pair, three := false, false
+	for face := 1; face <= 6; face++ {
+		if c[face] > maxCount {
+			maxCount = c[face]
+		}
+		switch c[face] {
+		case 2:
+			pair = true
+		case 3:
+			three = true
+		}
+	}
+
+	switch category {
+	case ThreeOfAKind:
+		if maxCount >= 3 {
+			return sum(dice)
+		}
+	case FourOfAKind:
+		if maxCount >= 4 {
+			return sum(dice)
+		}
+	case FullHouse:
+		if pair && three {
+			return 25
+		}
+	case SmallStraight:
+		if longestRun(dice) >= 4 {
+			return 30
+		}
+	case LargeStraight:
+		if longestRun(dice) == 5 {
+			return 40
+		}
+	case Yahtzee:
+		if maxCount == NumDice {
+			return YahtzeeScore
+		}
+	case Chance:
+		return sum(dice)
+	}
+	return 0
+}
+
+// jokerScore is what a lower box is worth for an extra Yahtzee played as a joker
+func jokerScore(category string, dice []int) int {
+	switch category {
+	case FullHouse:
+		return 25
+	case SmallStraight:
+		return 30
+	case LargeStraight:
+		return 40
+	}
+	return CategoryScore(category, dice)
+}
+
+func isUpper(category string) bool {
+	_, ok := upperFace[category]
+	return ok
+}
+
+// Entry works out what scoring dice in category would earn, enforcing the
+// forced-joker rules for a Yahtzee rolled after the Yahtzee box is filled:
+// the matching upper box must be used if open, then any lower box (straights
+// and full house count in full), and only then another upper box for zero.
+// A Yahtzee also earns a 100 point bonus if the Yahtzee box holds 50.
+func Entry(card *models.YahtzeeScorecard, category string, dice []int) (points, bonus int, err error) {
+	valid := false
+	for _, c := range Categories {
+		if c == category {
+			valid = true
+		}
+	}
+	if !valid {
+		return 0, 0, errors.New("invalid category")
+	}
+	if _, filled := card.Scores[category]; filled {
+		return 0, 0, errors.New("category already filled")
+	}
+
+	yahtzeeBox, yahtzeeFilled := card.Scores[Yahtzee]
+	if !IsYahtzee(dice) || !yahtzeeFilled {
+		return CategoryScore(category, dice), 0, nil
+	}
+
+	// Extra Yahtzee: joker rules apply
+	if yahtzeeBox == YahtzeeScore {
+		bonus = YahtzeeBonus
+	}
+
+	matching := Categories[dice[0]-1]
+	if _, filled := card.Scores[matching]; !filled {
+		if category != matching {
+			return 0, 0, errors.New("an extra Yahtzee must be scored in its upper section box: " + matching)
+		}
+		return CategoryScore(category, dice), bonus, nil
+	}
+
+	if isUpper(category) {
+		for _, c := range Categories {
+			if _, filled := card.Scores[c]; !filled && !isUpper(c) {
+				return 0, 0, errors.New("an extra Yahtzee must be scored in an open lower section box")
+			}
+		}
+		return 0, bonus, nil
+	}
+	return jokerScore(category, dice), bonus, nil
+}
+
+// Options lists what the dice would score in every box the player may use
+func Options(card *models.YahtzeeScorecard, dice []int) map[string]int {
+	options := make(map[string]int)
+	for _, c := range Categories {
+		if points, _, err := Entry(card, c, dice); err == nil {
+			options[c] = points
+		}
+	}
+	return options
+}
+
+// NewScorecard returns an empty scorecard
+func NewScorecard() *models.YahtzeeScorecard {
+	return &models.YahtzeeScorecard{Scores: map[string]int{}}
+}
+
+// Record fills a box and updates the card's totals
+func Record(card *models.YahtzeeScorecard, category string, points, bonus int) {
+	card.Scores[category] = points
+	card.YahtzeeBonus += bonus
+	Total(card)
+}
+
+// Total recomputes the upper bonus and grand total
+func Total(card *models.YahtzeeScorecard) {
+	upper, lower := 0, 0
+	for c, points := range card.Scores {
+		if isUpper(c) {
+			upper += points
+		} else {
+			lower += points
+		}
+	}
+	card.UpperTotal = upper
+	card.UpperBonus = 0
+	if upper >= UpperBonusThreshold {
+		card.UpperBonus = UpperBonus
+	}
+	card.Total = upper + card.UpperBonus + lower + card.YahtzeeBonus
+}
+
+// IsComplete reports whether every box is filled
+func IsComplete(card *models.YahtzeeScorecard) bool {
+	return len(card.Scores) == len(Categories)
+}
+
+// OpenCategories lists the boxes not yet filled, in scorecard order
+func OpenCategories(card *models.YahtzeeScorecard) []string {
+	open := []string{}
+	for _, c := range Categories {
+		if _, filled := card.Scores[c]; !filled {
+			open = append(open, c)
+		}
+	}
+	return open
+}
+
+// JSON helpers
+
+func ScorecardToJSON(card *models.YahtzeeScorecard) (string, error) {
+	data, err := json.Marshal(card)
+	return string(data), err
+}
+
+func ScorecardFromJSON(data string) (*models.YahtzeeScorecard, error) {
+	card := NewScorecard()
+	if data == "" {
+		return card, nil
+	}
+	err := json.Unmarshal([]byte(data), card)
+	if card.Scores == nil {
+		card.Scores = map[string]int{}
+	}
+	return card, err
+}
+
+func DiceToJSON(dice []int) (string, error) {
+	data, err := json.Marshal(dice)
+	return string(data), err
+}
+
+func DiceFromJSON(data string) ([]int, error) {
+	var dice []int
+	err := json.Unmarshal([]byte(data), &dice)
+	return dice, err
+}
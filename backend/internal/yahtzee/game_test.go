@@ -0,0 +1,106 @@
+package yahtzee
+
+import (
+	"testing"
+
+	"altech/internal/models"
+)
+
+func card(scores map[string]int) *models.YahtzeeScorecard {
+	c := NewScorecard()
+	for category, points := range scores {
+		c.Scores[category] = points
+	}
+	Total(c)
+	return c
+}
+
+// Every lower box filled, as for the last joker choice
+var lowerFilled = map[string]int{
+	Fours: 12, ThreeOfAKind: 20, FourOfAKind: 0, FullHouse: 25,
+	SmallStraight: 30, LargeStraight: 40, Yahtzee: 50, Chance: 22,
+}
+
+func TestEntry(t *testing.T) {
+	tests := []struct {
+		name          string
+		scores        map[string]int
+		category      string
+		dice          []int
+		points, bonus int
+		wantErr       bool
+	}{
+		{"three of a kind", nil, ThreeOfAKind, []int{3, 3, 3, 2, 5}, 16, 0, false},
+		{"no three of a kind", nil, ThreeOfAKind, []int{3, 3, 2, 2, 5}, 0, 0, false},
+		{"full house", nil, FullHouse, []int{2, 2, 3, 3, 3}, 25, 0, false},
+		{"small straight", nil, SmallStraight, []int{1, 2, 3, 4, 6}, 30, 0, false},
+		{"large straight", nil, LargeStraight, []int{2, 3, 4, 5, 6}, 40, 0, false},
+		{"small straight is no large straight", nil, LargeStraight, []int{1, 2, 3, 4, 6}, 0, 0, false},
+		{"upper box", nil, Sixes, []int{6, 6, 1, 6, 2}, 18, 0, false},
+		{"first yahtzee", nil, Yahtzee, []int{4, 4, 4, 4, 4}, 50, 0, false},
+		{"yahtzee elsewhere while its box is open", nil, Chance, []int{4, 4, 4, 4, 4}, 20, 0, false},
+		{"box already filled", map[string]int{Chance: 20}, Chance, []int{1, 2, 3, 4, 5}, 0, 0, true},
+		{"unknown box", nil, "sevens", []int{1, 2, 3, 4, 5}, 0, 0, true},
+
+		// Extra Yahtzees
+		{"joker in its upper box", map[string]int{Yahtzee: 50}, Fours, []int{4, 4, 4, 4, 4}, 20, 100, false},
+		{"joker must use its open upper box", map[string]int{Yahtzee: 50}, Chance, []int{4, 4, 4, 4, 4}, 0, 0, true},
+		{"no bonus after a scratched yahtzee", map[string]int{Yahtzee: 0}, Fours, []int{4, 4, 4, 4, 4}, 20, 0, false},
+		{"joker full house", map[string]int{Yahtzee: 50, Fours: 12}, FullHouse, []int{4, 4, 4, 4, 4}, 25, 100, false},
+		{"joker small straight", map[string]int{Yahtzee: 50, Fours: 12}, SmallStraight, []int{4, 4, 4, 4, 4}, 30, 100, false},
+		{"joker large straight", map[string]int{Yahtzee: 50, Fours: 12}, LargeStraight, []int{4, 4, 4, 4, 4}, 40, 100, false},
+		{"joker four of a kind", map[string]int{Yahtzee: 50, Fours: 12}, FourOfAKind, []int{4, 4, 4, 4, 4}, 20, 100, false},
+		{"joker after a scratched yahtzee earns no bonus", map[string]int{Yahtzee: 0, Fours: 12}, FullHouse, []int{4, 4, 4, 4, 4}, 25, 0, false},
+		{"joker must use an open lower box", map[string]int{Yahtzee: 50, Fours: 12}, Twos, []int{4, 4, 4, 4, 4}, 0, 0, true},
+		{"joker zeroes an upper box last", lowerFilled, Twos, []int{4, 4, 4, 4, 4}, 0, 100, false},
+	}
+
+	for _, tt := range tests {
+		points, bonus, err := Entry(card(tt.scores), tt.category, tt.dice)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: entry allowed", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if points != tt.points || bonus != tt.bonus {
+			t.Errorf("%s: got %d points and %d bonus, want %d and %d", tt.name, points, bonus, tt.points, tt.bonus)
+		}
+	}
+}
+
+func TestRecord(t *testing.T) {
+	upper := map[string]int{Ones: 3, Twos: 6, Threes: 9, Fours: 12, Fives: 15}
+
+	tests := []struct {
+		name                     string
+		scores                   map[string]int
+		category                 string
+		dice                     []int
+		upperBonus, yahtzeeBonus int
+		total                    int
+	}{
+		{"upper bonus at 63", upper, Sixes, []int{6, 6, 6, 1, 2}, 35, 0, 98},
+		{"no upper bonus below 63", upper, Sixes, []int{6, 6, 1, 1, 2}, 0, 0, 57},
+		{"yahtzee bonus", map[string]int{Yahtzee: 50}, Fours, []int{4, 4, 4, 4, 4}, 0, 100, 170},
+		{"upper bonus from a joker", map[string]int{Ones: 3, Twos: 6, Threes: 9, Fours: 12, Sixes: 18, Yahtzee: 50}, Fives, []int{5, 5, 5, 5, 5}, 35, 100, 258},
+	}
+
+	for _, tt := range tests {
+		c := card(tt.scores)
+		points, bonus, err := Entry(c, tt.category, tt.dice)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		Record(c, tt.category, points, bonus)
+		if c.UpperBonus != tt.upperBonus || c.YahtzeeBonus != tt.yahtzeeBonus || c.Total != tt.total {
+			t.Errorf("%s: got upper bonus %d, yahtzee bonus %d, total %d, want %d, %d, %d",
+				tt.name, c.UpperBonus, c.YahtzeeBonus, c.Total, tt.upperBonus, tt.yahtzeeBonus, tt.total)
+		}
+	}
+}
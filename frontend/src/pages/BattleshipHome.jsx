@@ -11,6 +11,7 @@ export default function BattleshipHome() {
   const [friends, setFriends] = useState([])
   const [loading, setLoading] = useState(true)
   const [error, setError] = useState('')
+  const [success, setSuccess] = useState('')
   const [showNewGameModal, setShowNewGameModal] = useState(false)
   const [creating, setCreating] = useState(false)
 
@@ -57,10 +58,11 @@ export default function BattleshipHome() {
   const handleNewGame = async (friendId) => {
     setCreating(true)
     setError('')
+    setSuccess('')
     try {
-      const result = await api.createBattleshipGame(friendId)
+      await api.inviteToGame('battleship', friendId)
       setShowNewGameModal(false)
-      navigate(`/battleship/${result.game.id}`)
+      setSuccess('Invitation sent! The game starts when your friend accepts.')
     } catch (err) {
       setError(err.message)
     } finally {
@@ -144,6 +146,7 @@ export default function BattleshipHome() {
         </div>
 
         {error && <div className="alert alert-error">{error}</div>}
+        {success && <div className="alert alert-success">{success}</div>}
 
         {/* Your Turn */}
         {yourTurnGames.length > 0 && (
@@ -3,18 +3,47 @@ import { useNavigate } from 'react-router-dom'
 import Header from '../components/Header'
 import { api } from '../services/api'
 
+// Game types with a page of their own, at /<game type>/<game id>
+const gamePages = ['scrabble', 'battleship', 'mastermind', 'memory']
+
 export default function Games() {
   const navigate = useNavigate()
   const [scrabbleCount, setScrabbleCount] = useState(0)
   const [battleshipCount, setBattleshipCount] = useState(0)
   const [mastermindCount, setMastermindCount] = useState(0)
   const [memoryCount, setMemoryCount] = useState(0)
+  const [invitations, setInvitations] = useState([])
   const [loading, setLoading] = useState(true)
+  const [error, setError] = useState('')
 
   useEffect(() => {
     loadCounts()
+    loadInvitations()
   }, [])
 
+  const loadInvitations = async () => {
+    try {
+      const data = await api.getIncomingInvitations()
+      setInvitations(data.invitations.filter((inv) => inv.status === 'pending'))
+    } catch {
+      // Ignore errors for invitations
+    }
+  }
+
+  const handleInvitation = async (invitation, action) => {
+    setError('')
+    try {
+      const result = await api.respondToInvitation(invitation.id, action)
+      if (action === 'accept' && result.game_id && gamePages.includes(invitation.game_type)) {
+        navigate(`/${invitation.game_type}/${result.game_id}`)
+        return
+      }
+      await loadInvitations()
+    } catch (err) {
+      setError(err.message)
+    }
+  }
+
   const loadCounts = async () => {
     try {
       const [scrabbleData, battleshipData, mastermindData, memoryData] = await Promise.all([
@@ -44,6 +73,37 @@ export default function Games() {
       <main className="container main-content">
         <h1 className="page-title">Games</h1>
 
+        {error && <div className="alert alert-error">{error}</div>}
+
+        {invitations.length > 0 && (
+          <div className="card mb-2">
+            <h2 className="section-title">Game Invitations</h2>
+            <ul className="friend-list">
+              {invitations.map((invitation) => (
+                <li key={invitation.id} className="friend-item">
+                  <span className="friend-name">
+                    {invitation.sender?.username} &middot; {invitation.game_type}
+                  </span>
+                  <div className="friend-actions">
+                    <button
+                      onClick={() => handleInvitation(invitation, 'accept')}
+                      className="btn btn-primary btn-small"
+                    >
+                      Accept
+                    </button>
+                    <button
+                      onClick={() => handleInvitation(invitation, 'decline')}
+                      className="btn btn-secondary btn-small"
+                    >
+                      Decline
+                    </button>
+                  </div>
+                </li>
+              ))}
+            </ul>
+          </div>
+        )}
+
         <div className="games-hub">
           <button
             className="game-hub-card"
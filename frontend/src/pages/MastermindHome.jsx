@@ -11,6 +11,7 @@ export default function MastermindHome() {
   const [friends, setFriends] = useState([])
   const [loading, setLoading] = useState(true)
   const [error, setError] = useState('')
+  const [success, setSuccess] = useState('')
   const [showNewGameModal, setShowNewGameModal] = useState(false)
   const [creating, setCreating] = useState(false)
   const [selectedFriend, setSelectedFriend] = useState(null)
@@ -57,11 +58,12 @@ export default function MastermindHome() {
     if (!selectedFriend) return
     setCreating(true)
     setError('')
+    setSuccess('')
     try {
-      const result = await api.createMastermindGame(selectedFriend, numColors, allowRepeats)
+      await api.inviteToGame('mastermind', selectedFriend, { num_colors: numColors, allow_repeats: allowRepeats })
       setShowNewGameModal(false)
       setSelectedFriend(null)
-      navigate(`/mastermind/${result.game.id}`)
+      setSuccess('Invitation sent! The game starts when your friend accepts.')
     } catch (err) {
       setError(err.message)
     } finally {
@@ -155,6 +157,7 @@ export default function MastermindHome() {
         </div>
 
         {error && <div className="alert alert-error">{error}</div>}
+        {success && <div className="alert alert-success">{success}</div>}
 
         {yourTurnGames.length > 0 && (
           <section className="game-section">
@@ -259,7 +262,7 @@ export default function MastermindHome() {
                     onClick={handleStartGame}
                     disabled={!selectedFriend || creating}
                   >
-                    {creating ? 'Creating...' : 'Start Game'}
+                    {creating ? 'Sending...' : 'Send Invitation'}
                   </button>
                 </>
               )}
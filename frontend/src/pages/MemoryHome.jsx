@@ -11,6 +11,7 @@ export default function MemoryHome() {
   const [friends, setFriends] = useState([])
   const [loading, setLoading] = useState(true)
   const [error, setError] = useState('')
+  const [success, setSuccess] = useState('')
   const [showNewGameModal, setShowNewGameModal] = useState(false)
   const [creating, setCreating] = useState(false)
   const [selectedFriend, setSelectedFriend] = useState(null)
@@ -56,11 +57,12 @@ export default function MemoryHome() {
     if (!selectedFriend) return
     setCreating(true)
     setError('')
+    setSuccess('')
     try {
-      const result = await api.createMemoryGame(selectedFriend, boardSize)
+      await api.inviteToGame('memory', selectedFriend, { board_size: boardSize })
       setShowNewGameModal(false)
       setSelectedFriend(null)
-      navigate(`/memory/${result.game.id}`)
+      setSuccess('Invitation sent! The game starts when your friend accepts.')
     } catch (err) {
       setError(err.message)
     } finally {
@@ -146,6 +148,7 @@ export default function MemoryHome() {
         </div>
 
         {error && <div className="alert alert-error">{error}</div>}
+        {success && <div className="alert alert-success">{success}</div>}
 
         {yourTurnGames.length > 0 && (
           <section className="game-section">
@@ -228,7 +231,7 @@ export default function MemoryHome() {
                     onClick={handleStartGame}
                     disabled={!selectedFriend || creating}
                   >
-                    {creating ? 'Creating...' : 'Start Game'}
+                    {creating ? 'Sending...' : 'Send Invitation'}
                   </button>
                 </>
               )}
@@ -11,6 +11,7 @@ export default function ScrabbleHome() {
   const [friends, setFriends] = useState([])
   const [loading, setLoading] = useState(true)
   const [error, setError] = useState('')
+  const [success, setSuccess] = useState('')
   const [showNewGameModal, setShowNewGameModal] = useState(false)
   const [creating, setCreating] = useState(false)
 
@@ -57,10 +58,11 @@ export default function ScrabbleHome() {
   const handleNewGame = async (friendId) => {
     setCreating(true)
     setError('')
+    setSuccess('')
     try {
-      const result = await api.createScrabbleGame(friendId)
+      await api.inviteToGame('scrabble', friendId)
       setShowNewGameModal(false)
-      navigate(`/scrabble/${result.game.id}`)
+      setSuccess('Invitation sent! The game starts when your friend accepts.')
     } catch (err) {
       setError(err.message)
     } finally {
@@ -143,6 +145,7 @@ export default function ScrabbleHome() {
         </div>
 
         {error && <div className="alert alert-error">{error}</div>}
+        {success && <div className="alert alert-success">{success}</div>}
 
         {/* Your Turn */}
         {yourTurnGames.length > 0 && (
@@ -172,28 +172,50 @@ class ApiService {
     return data
   }
 
-  // Scrabble API
-  async getScrabbleGames() {
-    const response = await this.request('/scrabble/games')
+  // Games are created when the invited friend accepts
+  async inviteToGame(gameType, opponentId, settings = {}) {
+    const response = await this.request('/invitations', {
+      method: 'POST',
+      body: JSON.stringify({ game_type: gameType, opponent_id: opponentId, settings }),
+    })
+    const data = await response.json()
+    if (!response.ok) {
+      throw new Error(data.error || 'Failed to send invitation')
+    }
+    return data
+  }
+
+  async getIncomingInvitations() {
+    const response = await this.request('/invitations/incoming')
     if (!response.ok) {
       const data = await response.json()
-      throw new Error(data.error || 'Failed to get games')
+      throw new Error(data.error || 'Failed to get invitations')
     }
     return response.json()
   }
 
-  async createScrabbleGame(opponentId) {
-    const response = await this.request('/scrabble/games', {
+  async respondToInvitation(invitationId, action) {
+    const response = await this.request(`/invitations/${invitationId}`, {
       method: 'POST',
-      body: JSON.stringify({ opponent_id: opponentId }),
+      body: JSON.stringify({ action }),
     })
     const data = await response.json()
     if (!response.ok) {
-      throw new Error(data.error || 'Failed to create game')
+      throw new Error(data.error || 'Failed to respond to invitation')
     }
     return data
   }
 
+  // Scrabble API
+  async getScrabbleGames() {
+    const response = await this.request('/scrabble/games')
+    if (!response.ok) {
+      const data = await response.json()
+      throw new Error(data.error || 'Failed to get games')
+    }
+    return response.json()
+  }
+
   async getScrabbleGame(gameId) {
     const response = await this.request(`/scrabble/games/${gameId}`)
     if (!response.ok) {
@@ -289,18 +311,6 @@ class ApiService {
     return response.json()
   }
 
-  async createBattleshipGame(opponentId) {
-    const response = await this.request('/battleship/games', {
-      method: 'POST',
-      body: JSON.stringify({ opponent_id: opponentId }),
-    })
-    const data = await response.json()
-    if (!response.ok) {
-      throw new Error(data.error || 'Failed to create game')
-    }
-    return data
-  }
-
   async getBattleshipGame(gameId) {
     const response = await this.request(`/battleship/games/${gameId}`)
     if (!response.ok) {
@@ -355,18 +365,6 @@ class ApiService {
     return response.json()
   }
 
-  async createMastermindGame(opponentId, numColors = 6, allowRepeats = true) {
-    const response = await this.request('/mastermind/games', {
-      method: 'POST',
-      body: JSON.stringify({ opponent_id: opponentId, num_colors: numColors, allow_repeats: allowRepeats }),
-    })
-    const data = await response.json()
-    if (!response.ok) {
-      throw new Error(data.error || 'Failed to create game')
-    }
-    return data
-  }
-
   async getMastermindGame(gameId) {
     const response = await this.request(`/mastermind/games/${gameId}`)
     if (!response.ok) {
@@ -420,18 +418,6 @@ class ApiService {
     return response.json()
   }
 
-  async createMemoryGame(opponentId, boardSize = '4x5') {
-    const response = await this.request('/memory/games', {
-      method: 'POST',
-      body: JSON.stringify({ opponent_id: opponentId, board_size: boardSize }),
-    })
-    const data = await response.json()
-    if (!response.ok) {
-      throw new Error(data.error || 'Failed to create game')
-    }
-    return data
-  }
-
   async getMemoryGame(gameId) {
     const response = await this.request(`/memory/games/${gameId}`)
     if (!response.ok) {